@@ -5,13 +5,18 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/encoding"
+	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 )
 
@@ -42,6 +47,28 @@ var (
 		Value:    false,
 		Required: false,
 	}
+	selectorFlag = cli.StringFlag{
+		Name:     "selector",
+		Usage:    "Function selector, as 4 hex bytes or a signature like 'transfer(address,uint256)', the exceptions to the 'call' policy apply to",
+		Required: false,
+	}
+	maxValueFlag = cli.StringFlag{
+		Name:     "max-value",
+		Usage:    "Max value in wei the addresses on list may transfer in a transaction, for the 'value' policy",
+		Required: false,
+	}
+	validFromFlag = cli.TimestampFlag{
+		Name:     "valid-from",
+		Usage:    "Time from which the exceptions apply, in RFC3339 format",
+		Layout:   time.RFC3339,
+		Required: false,
+	}
+	validUntilFlag = cli.TimestampFlag{
+		Name:     "valid-until",
+		Usage:    "Time until which the exceptions apply, in RFC3339 format",
+		Layout:   time.RFC3339,
+		Required: false,
+	}
 
 	policyActionFlags = []cli.Flag{&policyFlag}
 )
@@ -56,7 +83,7 @@ var policyCommands = cli.Command{
 			Name:   "add",
 			Usage:  "Add address(es) to a policy exclusion list",
 			Action: addAcl,
-			Flags:  append(policyActionFlags, &csvFlag),
+			Flags:  append(policyActionFlags, &csvFlag, &selectorFlag, &maxValueFlag, &validFromFlag, &validUntilFlag),
 		}, {
 			Name:   "clear",
 			Usage:  "Clear the addresses listed as exceptions to a policy",
@@ -71,7 +98,7 @@ var policyCommands = cli.Command{
 			Name:   "remove",
 			Usage:  "Remove address(es) from a policy exclusion list",
 			Action: removeAcl,
-			Flags:  append(policyActionFlags, &csvFlag),
+			Flags:  append(policyActionFlags, &csvFlag, &selectorFlag),
 		}, {
 			Name:   "update",
 			Usage:  "Update the default action for a policy",
@@ -122,7 +149,16 @@ func addAcl(cli *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	err = db.AddAddressesToPolicy(context.Background(), policy, addresses)
+	acl, err := resolveAcl(cli, policy)
+	if err != nil {
+		return err
+	}
+	var acls []pool.Acl
+	for _, address := range addresses {
+		acl.Address = address
+		acls = append(acls, acl)
+	}
+	err = db.AddAcls(context.Background(), acls)
 	if err != nil {
 		return err
 	}
@@ -138,6 +174,18 @@ func removeAcl(cli *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if cli.IsSet(selectorFlag.Name) {
+		// only the exceptions for the selector are removed
+		selector, err := resolveSelector(cli, policy)
+		if err != nil {
+			return err
+		}
+		var acls []pool.Acl
+		for _, address := range addresses {
+			acls = append(acls, pool.Acl{PolicyName: policy, Address: address, Selector: selector})
+		}
+		return db.RemoveAcls(context.Background(), acls)
+	}
 	err = db.RemoveAddressesFromPolicy(context.Background(), policy, addresses)
 	if err != nil {
 		return err
@@ -200,8 +248,8 @@ func describePolicy(cli *cli.Context, showHeader bool) error {
 	if showHeader {
 		fmt.Println("Addresses:")
 	}
-	for _, address := range list {
-		fmt.Println(address.Hex())
+	for _, acl := range list {
+		fmt.Println(describeAcl(acl))
 	}
 	return nil
 }
@@ -226,6 +274,24 @@ func describePolicies(cli *cli.Context, showHeader bool) error {
 	return nil
 }
 
+// describeAcl returns the address of an exception followed by its selector, max value and time window, if any
+func describeAcl(acl pool.Acl) string {
+	desc := acl.Address.Hex()
+	if len(acl.Selector) > 0 {
+		desc += " selector=" + hex.EncodeToHex(acl.Selector)
+	}
+	if acl.MaxValue != nil {
+		desc += " max_value=" + acl.MaxValue.String()
+	}
+	if acl.ValidFrom != nil {
+		desc += " valid_from=" + acl.ValidFrom.Format(time.RFC3339)
+	}
+	if acl.ValidUntil != nil {
+		desc += " valid_until=" + acl.ValidUntil.Format(time.RFC3339)
+	}
+	return desc
+}
+
 func configAndStorage(cli *cli.Context) (*config.Config, *pgpoolstorage.PostgresPoolStorage, error) {
 	c, err := config.Load(cli, false)
 	if err != nil {
@@ -306,3 +372,50 @@ func resolveAddresses(cli *cli.Context, failIfEmpty bool) ([]common.Address, err
 	}
 	return ret, nil
 }
+
+// resolveAcl returns the exception to the policy with the selector, max value and time window flags, without address
+func resolveAcl(cli *cli.Context, policy pool.PolicyName) (pool.Acl, error) {
+	acl := pool.Acl{PolicyName: policy}
+
+	var err error
+	if acl.Selector, err = resolveSelector(cli, policy); err != nil {
+		return pool.Acl{}, err
+	}
+
+	if cli.IsSet(maxValueFlag.Name) {
+		if policy != pool.Value {
+			return pool.Acl{}, fmt.Errorf("--%s only applies to the %s policy", maxValueFlag.Name, pool.Value)
+		}
+		maxValue, ok := new(big.Int).SetString(cli.String(maxValueFlag.Name), encoding.Base10)
+		if !ok || maxValue.Sign() < 0 {
+			return pool.Acl{}, fmt.Errorf("invalid max value: %s", cli.String(maxValueFlag.Name))
+		}
+		acl.MaxValue = maxValue
+	}
+
+	acl.ValidFrom = cli.Timestamp(validFromFlag.Name)
+	acl.ValidUntil = cli.Timestamp(validUntilFlag.Name)
+	if acl.ValidFrom != nil && acl.ValidUntil != nil && !acl.ValidUntil.After(*acl.ValidFrom) {
+		return pool.Acl{}, fmt.Errorf("--%s must be after --%s", validUntilFlag.Name, validFromFlag.Name)
+	}
+	return acl, nil
+}
+
+// resolveSelector returns the function selector flag, which is either 4 hex bytes or a function signature
+func resolveSelector(cli *cli.Context, policy pool.PolicyName) ([]byte, error) {
+	if !cli.IsSet(selectorFlag.Name) {
+		return nil, nil
+	}
+	if policy != pool.Call {
+		return nil, fmt.Errorf("--%s only applies to the %s policy", selectorFlag.Name, pool.Call)
+	}
+	selector := strings.TrimSpace(cli.String(selectorFlag.Name))
+	if strings.Contains(selector, "(") {
+		return crypto.Keccak256([]byte(selector))[:pool.SelectorLength], nil
+	}
+	b, err := hex.DecodeHex(selector)
+	if err != nil || len(b) != pool.SelectorLength {
+		return nil, fmt.Errorf("invalid selector: %s", selector)
+	}
+	return b, nil
+}
@@ -405,8 +405,10 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerS
 }
 
 func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64, pool *pool.Pool, st *state.State, apis map[string]bool) {
-	var err error
-	storage := jsonrpc.NewStorage()
+	storage, err := jsonrpc.NewFilterStorage(c.RPC.FilterStorage, c.Pool.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
 	c.RPC.MaxCumulativeGasUsed = c.State.Batch.Constraints.MaxCumulativeGasUsed
 	c.RPC.L2Coinbase = c.SequenceSender.L2Coinbase
 	c.RPC.ZKCountersLimits = jsonrpc.ZKCountersLimits{
@@ -455,7 +457,7 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if _, ok := apis[jsonrpc.APITxPool]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APITxPool,
-			Service: &jsonrpc.TxPoolEndpoints{},
+			Service: jsonrpc.NewTxPoolEndpoints(c.RPC, pool),
 		})
 	}
 
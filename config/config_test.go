@@ -12,6 +12,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/aggregator"
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -137,6 +138,14 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.Metrics.EnableLog",
 			expectedValue: true,
 		},
+		{
+			path:          "Sequencer.Finalizer.TxOrdering.Policy",
+			expectedValue: "gasprice",
+		},
+		{
+			path:          "Sequencer.Finalizer.TxOrdering.PriorityAddresses",
+			expectedValue: []common.Address{},
+		},
 		{
 			path:          "Sequencer.StreamServer.Port",
 			expectedValue: uint16(0),
@@ -302,6 +311,26 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.GlobalQueue",
 			expectedValue: uint64(1024),
 		},
+		{
+			path:          "Pool.AccountSlots",
+			expectedValue: uint64(1),
+		},
+		{
+			path:          "Pool.PriceBump",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Pool.ListenPendingTxNotifications",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.PrivateTxMaxBlocks",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "Pool.MaxBundleTxs",
+			expectedValue: uint64(16),
+		},
 		{
 			path:          "Pool.EffectiveGasPrice.Enabled",
 			expectedValue: false,
@@ -414,10 +443,111 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxNativeBlockHashBlockRange",
 			expectedValue: uint64(60000),
 		},
+		{
+			path:          "RPC.MaxTxPoolContentAccounts",
+			expectedValue: uint64(1000),
+		},
+		{
+			path:          "RPC.MaxFeeHistoryBlockCount",
+			expectedValue: uint64(1024),
+		},
+		{
+			path:          "RPC.MaxFeeHistoryRewardBlockCount",
+			expectedValue: uint64(128),
+		},
 		{
 			path:          "RPC.EnableHttpLog",
 			expectedValue: true,
 		},
+		{
+			path:          "RPC.FilterStorage.Type",
+			expectedValue: "memory",
+		},
+		{
+			path:          "RPC.FilterStorage.FilterTimeout",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
+		{
+			path:          "RPC.RateLimit.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.RateLimit.DefaultMethodCost",
+			expectedValue: uint64(1),
+		},
+		{
+			path:          "RPC.RateLimit.DefaultTier",
+			expectedValue: "default",
+		},
+		{
+			path: "RPC.RateLimit.MethodCosts",
+			expectedValue: map[string]uint64{
+				"debug_tracebatchbynumber": 100,
+				"debug_traceblockbyhash":   50,
+				"debug_traceblockbynumber": 50,
+				"debug_tracecall":          20,
+				"debug_tracetransaction":   20,
+				"eth_getlogs":              10,
+			},
+		},
+		{
+			path: "RPC.RateLimit.Tiers",
+			expectedValue: map[string]jsonrpc.RateLimitTierConfig{
+				"default": {CostPerSecond: 100, Burst: 200},
+			},
+		},
+		{
+			path:          "RPC.Auth.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.Auth.ProtectedAPIs",
+			expectedValue: []string{"debug", "txpool", "zkevm"},
+		},
+		{
+			path:          "RPC.Auth.JWTSecretFile",
+			expectedValue: "",
+		},
+		{
+			path:          "RPC.ResponseCache.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.ResponseCache.MaxEntries",
+			expectedValue: 10000,
+		},
+		{
+			path:          "RPC.ResponseCache.VirtualizedBlockCheckInterval",
+			expectedValue: types.NewDuration(time.Second),
+		},
+		{
+			path:          "RPC.GraphQL.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.GraphQL.Host",
+			expectedValue: "0.0.0.0",
+		},
+		{
+			path:          "RPC.GraphQL.Port",
+			expectedValue: int(8547),
+		},
+		{
+			path:          "RPC.GraphQL.MaxBlockRange",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "RPC.GraphQL.MaxDepth",
+			expectedValue: int(10),
+		},
+		{
+			path:          "RPC.GraphQL.MaxParallelism",
+			expectedValue: int(10),
+		},
+		{
+			path:          "RPC.GraphQL.MaxComplexity",
+			expectedValue: uint64(5000),
+		},
 		{
 			path:          "RPC.WebSockets.Enabled",
 			expectedValue: true,
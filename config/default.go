@@ -44,6 +44,11 @@ MinAllowedGasPriceInterval = "5m"
 PollMinAllowedGasPriceInterval = "15s"
 AccountQueue = 64
 GlobalQueue = 1024
+AccountSlots = 1
+PriceBump = 10
+ListenPendingTxNotifications = false
+PrivateTxMaxBlocks = 100
+MaxBundleTxs = 16
     [Pool.EffectiveGasPrice]
 	Enabled = false
 	L1GasPriceFactor = 0.25
@@ -91,7 +96,43 @@ BatchRequestsLimit = 20
 MaxLogsCount = 10000
 MaxLogsBlockRange = 10000
 MaxNativeBlockHashBlockRange = 60000
+MaxTxPoolContentAccounts = 1000
+MaxFeeHistoryBlockCount = 1024
+MaxFeeHistoryRewardBlockCount = 128
 EnableHttpLog = true
+	[RPC.FilterStorage]
+		Type = "memory"
+		FilterTimeout = "5m"
+	[RPC.RateLimit]
+		Enabled = false
+		DefaultMethodCost = 1
+		DefaultTier = "default"
+		[RPC.RateLimit.MethodCosts]
+			debug_traceBatchByNumber = 100
+			debug_traceBlockByHash = 50
+			debug_traceBlockByNumber = 50
+			debug_traceCall = 20
+			debug_traceTransaction = 20
+			eth_getLogs = 10
+		[RPC.RateLimit.Tiers.default]
+			CostPerSecond = 100
+			Burst = 200
+	[RPC.Auth]
+		Enabled = false
+		ProtectedAPIs = ["debug", "txpool", "zkevm"]
+		JWTSecretFile = ""
+	[RPC.ResponseCache]
+		Enabled = false
+		MaxEntries = 10000
+		VirtualizedBlockCheckInterval = "1s"
+	[RPC.GraphQL]
+		Enabled = false
+		Host = "0.0.0.0"
+		Port = 8547
+		MaxBlockRange = 100
+		MaxDepth = 10
+		MaxParallelism = 10
+		MaxComplexity = 5000
 	[RPC.WebSockets]
 		Enabled = true
 		Host = "0.0.0.0"
@@ -146,6 +187,9 @@ StateConsistencyCheckInterval = "5s"
 	[Sequencer.Finalizer.Metrics]
 		Interval = "60m"
 		EnableLog = true
+	[Sequencer.Finalizer.TxOrdering]
+		Policy = "gasprice"
+		PriorityAddresses = []
 	[Sequencer.StreamServer]
 		Port = 0
 		Filename = ""
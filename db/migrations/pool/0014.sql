@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE SCHEMA IF NOT EXISTS rpc;
+
+CREATE TABLE IF NOT EXISTS rpc.filter
+(
+    id         VARCHAR PRIMARY KEY,
+    type       VARCHAR NOT NULL,
+    parameters JSONB,
+    last_poll  TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_filter_last_poll ON rpc.filter (last_poll);
+
+-- +migrate Down
+DROP INDEX IF EXISTS rpc.idx_filter_last_poll;
+DROP TABLE IF EXISTS rpc.filter;
+DROP SCHEMA IF EXISTS rpc CASCADE;
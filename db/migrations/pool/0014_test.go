@@ -0,0 +1,42 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the rpc.filter table to share the filters between rpc instances
+type migrationTest0014 struct{}
+
+func (m migrationTest0014) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertFilter = `
+		INSERT INTO rpc.filter (id, type, parameters, last_poll)
+		VALUES ('0x0001', 'log', '{"addresses":["0x0000000000000000000000000000000000000001"]}', '2023-12-07')`
+
+	_, err := db.Exec(insertFilter)
+	require.NoError(t, err)
+
+	var filterType string
+	err = db.QueryRow(`SELECT type FROM rpc.filter WHERE id = '0x0001'`).Scan(&filterType)
+	require.NoError(t, err)
+	require.Equal(t, "log", filterType)
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const insertFilter = `
+		INSERT INTO rpc.filter (id, type, parameters, last_poll)
+		VALUES ('0x0001', 'log', NULL, '2023-12-07')`
+
+	_, err := db.Exec(insertFilter)
+	require.Error(t, err)
+}
+
+func TestMigration0014(t *testing.T) {
+	runMigrationTest(t, 14, migrationTest0014{})
+}
@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN is_executable BOOLEAN NOT NULL DEFAULT TRUE;
+CREATE INDEX IF NOT EXISTS idx_transaction_status_is_executable ON pool.transaction (status, is_executable);
+
+CREATE TABLE IF NOT EXISTS pool.sender_nonce
+(
+    from_address VARCHAR PRIMARY KEY,
+    next_nonce   DECIMAL(78, 0) NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS pool.sender_nonce;
+DROP INDEX IF EXISTS pool.idx_transaction_status_is_executable;
+ALTER TABLE pool.transaction
+    DROP COLUMN is_executable;
@@ -0,0 +1,54 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the executable flag of the txs and the next nonce of the senders
+type migrationTest0015 struct{}
+
+func (m migrationTest0015) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011', 1)`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the txs added before the migration are kept as executable
+	var isExecutable bool
+	err := db.QueryRow(`SELECT is_executable FROM pool.transaction WHERE hash = '0x0001'`).Scan(&isExecutable)
+	require.NoError(t, err)
+	assert.True(t, isExecutable)
+
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_status_is_executable';`
+	var result int
+	require.NoError(t, db.QueryRow(getIndex).Scan(&result))
+	assert.Equal(t, 1, result)
+
+	_, err = db.Exec(`INSERT INTO pool.sender_nonce (from_address, next_nonce) VALUES ('0x0011', 2)`)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var nonce uint64
+	err := db.QueryRow(`SELECT nonce FROM pool.transaction WHERE hash = '0x0001'`).Scan(&nonce)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+
+	_, err = db.Exec(`SELECT is_executable FROM pool.transaction`)
+	assert.Error(t, err)
+
+	_, err = db.Exec(`SELECT next_nonce FROM pool.sender_nonce`)
+	assert.Error(t, err)
+}
+
+func TestMigration0015(t *testing.T) {
+	runMigrationTest(t, 15, migrationTest0015{})
+}
@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN max_l2_block_number BIGINT;
+CREATE INDEX IF NOT EXISTS idx_transaction_is_private_max_l2_block_number ON pool.transaction (max_l2_block_number) WHERE is_private;
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_transaction_is_private_max_l2_block_number;
+ALTER TABLE pool.transaction
+    DROP COLUMN is_private,
+    DROP COLUMN max_l2_block_number;
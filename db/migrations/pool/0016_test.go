@@ -0,0 +1,59 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the private flag of the txs and their max L2 block number
+type migrationTest0016 struct{}
+
+func (m migrationTest0016) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011', 1)`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the txs added before the migration are kept as public without max L2 block number
+	var isPrivate bool
+	var maxL2BlockNumber *uint64
+	err := db.QueryRow(`SELECT is_private, max_l2_block_number FROM pool.transaction WHERE hash = '0x0001'`).Scan(&isPrivate, &maxL2BlockNumber)
+	require.NoError(t, err)
+	assert.False(t, isPrivate)
+	assert.Nil(t, maxL2BlockNumber)
+
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_is_private_max_l2_block_number';`
+	var result int
+	require.NoError(t, db.QueryRow(getIndex).Scan(&result))
+	assert.Equal(t, 1, result)
+
+	const insertPrivateTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce, is_private, max_l2_block_number)
+		VALUES ('0x0002', '127.0.0.1', '2023-12-07', '0x0011', 2, TRUE, 100)`
+	_, err = db.Exec(insertPrivateTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var nonce uint64
+	err := db.QueryRow(`SELECT nonce FROM pool.transaction WHERE hash = '0x0002'`).Scan(&nonce)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	_, err = db.Exec(`SELECT is_private FROM pool.transaction`)
+	assert.Error(t, err)
+
+	_, err = db.Exec(`SELECT max_l2_block_number FROM pool.transaction`)
+	assert.Error(t, err)
+}
+
+func TestMigration0016(t *testing.T) {
+	runMigrationTest(t, 16, migrationTest0016{})
+}
@@ -0,0 +1,59 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the bundle hash of the txs and their index in the bundle
+type migrationTest0017 struct{}
+
+func (m migrationTest0017) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011', 1)`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the txs added before the migration don't belong to any bundle
+	var bundleHash *string
+	var bundleIndex *uint64
+	err := db.QueryRow(`SELECT bundle_hash, bundle_index FROM pool.transaction WHERE hash = '0x0001'`).Scan(&bundleHash, &bundleIndex)
+	require.NoError(t, err)
+	assert.Nil(t, bundleHash)
+	assert.Nil(t, bundleIndex)
+
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_bundle_hash';`
+	var result int
+	require.NoError(t, db.QueryRow(getIndex).Scan(&result))
+	assert.Equal(t, 1, result)
+
+	const insertBundleTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce, bundle_hash, bundle_index)
+		VALUES ('0x0002', '127.0.0.1', '2023-12-07', '0x0011', 2, '0x0b01', 0)`
+	_, err = db.Exec(insertBundleTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var nonce uint64
+	err := db.QueryRow(`SELECT nonce FROM pool.transaction WHERE hash = '0x0002'`).Scan(&nonce)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	_, err = db.Exec(`SELECT bundle_hash FROM pool.transaction`)
+	assert.Error(t, err)
+
+	_, err = db.Exec(`SELECT bundle_index FROM pool.transaction`)
+	assert.Error(t, err)
+}
+
+func TestMigration0017(t *testing.T) {
+	runMigrationTest(t, 17, migrationTest0017{})
+}
@@ -0,0 +1,25 @@
+-- +migrate Up
+ALTER TABLE pool.acl
+    ADD COLUMN selector VARCHAR NOT NULL DEFAULT '',
+    ADD COLUMN max_value DECIMAL(78, 0),
+    ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN valid_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE pool.acl DROP CONSTRAINT acl_pkey;
+ALTER TABLE pool.acl ADD PRIMARY KEY (address, policy, selector);
+
+INSERT INTO pool.policy (name, allow) VALUES ('call', false) ON CONFLICT DO NOTHING;
+INSERT INTO pool.policy (name, allow) VALUES ('value', false) ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+-- WARNING: this rollback is lossy, the call and value policies and the exceptions with a selector, a max value or a
+-- time window are deleted, as they can't be represented before this migration
+DELETE FROM pool.policy WHERE name IN ('call', 'value');
+DELETE FROM pool.acl
+    WHERE policy IN ('call', 'value') OR selector <> '' OR valid_from IS NOT NULL OR valid_until IS NOT NULL;
+ALTER TABLE pool.acl DROP CONSTRAINT acl_pkey;
+ALTER TABLE pool.acl
+    DROP COLUMN selector,
+    DROP COLUMN max_value,
+    DROP COLUMN valid_from,
+    DROP COLUMN valid_until;
+ALTER TABLE pool.acl ADD PRIMARY KEY (address, policy);
@@ -0,0 +1,67 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the call and value policies, and the selector, max value and time window of the exceptions
+type migrationTestValidium002 struct{}
+
+func (m migrationTestValidium002) InsertData(db *sql.DB) error {
+	const insertAcl = `
+		INSERT INTO pool.acl (address, policy)
+		VALUES ('0x0011', 'send_tx'), ('0x0022', 'deploy')`
+
+	_, err := db.Exec(insertAcl)
+	return err
+}
+
+func (m migrationTestValidium002) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the existing exceptions apply to any selector, with no max value nor time window
+	var selector string
+	var maxValue, validFrom, validUntil *string
+	err := db.QueryRow(`SELECT selector, max_value, valid_from, valid_until FROM pool.acl WHERE address = '0x0011'`).
+		Scan(&selector, &maxValue, &validFrom, &validUntil)
+	require.NoError(t, err)
+	assert.Empty(t, selector)
+	assert.Nil(t, maxValue)
+	assert.Nil(t, validFrom)
+	assert.Nil(t, validUntil)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM pool.policy WHERE name IN ('call', 'value') AND NOT allow`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	// the same contract can be associated with several selectors
+	const insertAcl = `
+		INSERT INTO pool.acl (address, policy, selector, max_value, valid_from, valid_until)
+		VALUES ('0x0033', 'call', '', NULL, NULL, NULL),
+		       ('0x0033', 'call', '0xa9059cbb', NULL, NULL, NULL),
+		       ('0x0044', 'value', '', 1000, '2023-12-07', '2023-12-08')`
+	_, err = db.Exec(insertAcl)
+	require.NoError(t, err)
+}
+
+func (m migrationTestValidium002) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM pool.acl`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM pool.policy WHERE name IN ('call', 'value')`).Scan(&count))
+	assert.Equal(t, 0, count)
+
+	_, err := db.Exec(`SELECT selector FROM pool.acl`)
+	assert.Error(t, err)
+
+	_, err = db.Exec(`SELECT max_value FROM pool.acl`)
+	assert.Error(t, err)
+}
+
+func TestMigrationValidium002(t *testing.T) {
+	// the validium migrations run after the numbered ones
+	runMigrationTest(t, 19, migrationTestValidium002{})
+}
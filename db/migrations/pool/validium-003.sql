@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN is_in_wip_l2_block BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE pool.transaction
+    DROP COLUMN is_in_wip_l2_block;
@@ -0,0 +1,50 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the flag of the txs processed in the WIP L2 block of the sequencer
+type migrationTestValidium003 struct{}
+
+func (m migrationTestValidium003) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011', 1)`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTestValidium003) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	// the txs added before the migration are not in the WIP L2 block
+	var isInWIPL2Block bool
+	err := db.QueryRow(`SELECT is_in_wip_l2_block FROM pool.transaction WHERE hash = '0x0001'`).Scan(&isInWIPL2Block)
+	require.NoError(t, err)
+	assert.False(t, isInWIPL2Block)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, nonce, is_in_wip_l2_block)
+		VALUES ('0x0002', '127.0.0.1', '2023-12-07', '0x0011', 2, true)`
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTestValidium003) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var nonce uint64
+	err := db.QueryRow(`SELECT nonce FROM pool.transaction WHERE hash = '0x0002'`).Scan(&nonce)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	_, err = db.Exec(`SELECT is_in_wip_l2_block FROM pool.transaction`)
+	assert.Error(t, err)
+}
+
+func TestMigrationValidium003(t *testing.T) {
+	// the validium migrations run after the numbered ones
+	runMigrationTest(t, 20, migrationTestValidium003{})
+}
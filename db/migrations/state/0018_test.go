@@ -0,0 +1,38 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0018 struct{}
+
+func (m migrationTest0018) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	_, err := db.Exec("INSERT INTO state.l1_reorg (block_num) VALUES (10), (5)")
+	assert.NoError(t, err)
+
+	var count int
+	row := db.QueryRow("SELECT COUNT(*) FROM state.l1_reorg")
+	assert.NoError(t, row.Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var result int
+
+	// Check table l1_reorg doesn't exist
+	const getTable = `SELECT count(*) FROM information_schema.tables WHERE table_schema='state' and table_name='l1_reorg'`
+	row := db.QueryRow(getTable)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0018(t *testing.T) {
+	runMigrationTest(t, 18, migrationTest0018{})
+}
@@ -51,6 +51,8 @@ const (
 	EventID_UsedZKCountersOverflow EventID = "USED ZKCOUNTERS OVERFLOW"
 	// EventID_ReservedZKCountersOverflow is triggered when reserved ZK counters exceeds remaining batch ZK counters
 	EventID_ReservedZKCountersOverflow EventID = "RESERVED ZKCOUNTERS OVERFLOW"
+	// EventID_PoolTxEvicted is triggered when a pending tx is evicted from the full pool by a tx with a higher gas price
+	EventID_PoolTxEvicted EventID = "POOL TX EVICTED"
 	// Source_Node is the source of the event
 	Source_Node Source = "node"
 
@@ -0,0 +1,55 @@
+package gasprice
+
+import (
+	"math/big"
+	"sort"
+)
+
+// TxGasAndReward contains the gas used by a tx and the reward paid per gas
+// unit, which is the part of its effective gas price over the block base fee
+type TxGasAndReward struct {
+	GasUsed uint64
+	Reward  *big.Int
+}
+
+type txGasAndRewardSorter []TxGasAndReward
+
+func (s txGasAndRewardSorter) Len() int           { return len(s) }
+func (s txGasAndRewardSorter) Less(i, j int) bool { return s[i].Reward.Cmp(s[j].Reward) < 0 }
+func (s txGasAndRewardSorter) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// RewardPercentiles computes the rewards at the provided percentiles of the gas
+// used by a block. The txs are sorted by reward and, for each percentile, the
+// reward of the first tx that makes the accumulated gas used reach that
+// percentile of the block gas used is selected. Percentiles must be sorted in
+// ascending order, if the block has no txs, all the rewards are zero.
+func RewardPercentiles(txs []TxGasAndReward, percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(txs) == 0 {
+		for i := range rewards {
+			rewards[i] = big.NewInt(0)
+		}
+		return rewards
+	}
+
+	sorted := make(txGasAndRewardSorter, len(txs))
+	copy(sorted, txs)
+	sort.Stable(sorted)
+
+	var blockGasUsed uint64
+	for _, tx := range sorted {
+		blockGasUsed += tx.GasUsed
+	}
+
+	var txIndex int
+	sumGasUsed := sorted[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(blockGasUsed) * p / 100) // nolint:gomnd
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].GasUsed
+		}
+		rewards[i] = new(big.Int).Set(sorted[txIndex].Reward)
+	}
+	return rewards
+}
@@ -0,0 +1,57 @@
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardPercentiles(t *testing.T) {
+	var tests = []struct {
+		name            string
+		txs             []TxGasAndReward
+		percentiles     []float64
+		expectedRewards []int64
+	}{
+		{
+			name:            "no txs",
+			txs:             nil,
+			percentiles:     []float64{10, 50, 90},
+			expectedRewards: []int64{0, 0, 0},
+		},
+		{
+			name:            "single tx",
+			txs:             []TxGasAndReward{{GasUsed: 21000, Reward: big.NewInt(7)}},
+			percentiles:     []float64{0, 50, 100},
+			expectedRewards: []int64{7, 7, 7},
+		},
+		{
+			name: "rewards weighted by gas used",
+			txs: []TxGasAndReward{
+				{GasUsed: 50000, Reward: big.NewInt(30)},
+				{GasUsed: 25000, Reward: big.NewInt(10)},
+				{GasUsed: 25000, Reward: big.NewInt(20)},
+			},
+			percentiles:     []float64{0, 25, 26, 50, 51, 100},
+			expectedRewards: []int64{10, 10, 20, 20, 30, 30},
+		},
+		{
+			name:            "no percentiles",
+			txs:             []TxGasAndReward{{GasUsed: 21000, Reward: big.NewInt(7)}},
+			percentiles:     []float64{},
+			expectedRewards: []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rewards := RewardPercentiles(tt.txs, tt.percentiles)
+			actualRewards := make([]int64, 0, len(rewards))
+			for _, reward := range rewards {
+				actualRewards = append(actualRewards, reward.Int64())
+			}
+			assert.Equal(t, tt.expectedRewards, actualRewards)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	// jwtSecretLength is the length in bytes of the secret used to verify the JWTs
+	jwtSecretLength = 32
+	// jwtExpiryTimeout is the max drift allowed between the issued at claim of
+	// a JWT and the current time, the same used by the engine API
+	jwtExpiryTimeout = 60 * time.Second
+)
+
+// authenticator restricts the access to the protected namespaces to the clients
+// providing a valid JWT or a known API key
+type authenticator struct {
+	protectedAPIs map[string]bool
+	jwtSecret     []byte
+	// apiKeys maps every API key to the protected namespaces it can access,
+	// a nil value means all of them
+	apiKeys map[string]map[string]bool
+}
+
+// authGrant represents the protected namespaces a client can access
+type authGrant struct {
+	allAPIs bool
+	apis    map[string]bool
+	// err is the reason why the credentials provided were rejected
+	err error
+}
+
+// newAuthenticator creates the authenticator for the provided config, nil is
+// returned when the access control is disabled
+func newAuthenticator(cfg AuthConfig) (*authenticator, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	a := &authenticator{
+		protectedAPIs: make(map[string]bool, len(cfg.ProtectedAPIs)),
+		apiKeys:       make(map[string]map[string]bool, len(cfg.APIKeys)),
+	}
+	for _, api := range cfg.ProtectedAPIs {
+		a.protectedAPIs[api] = true
+	}
+
+	if cfg.JWTSecretFile != "" {
+		secret, err := loadJWTSecret(cfg.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwtSecret = secret
+	}
+
+	for _, apiKey := range cfg.APIKeys {
+		if apiKey.Key == "" {
+			return nil, fmt.Errorf("auth API key can't be empty")
+		}
+		var apis map[string]bool
+		if len(apiKey.APIs) > 0 {
+			apis = make(map[string]bool, len(apiKey.APIs))
+			for _, api := range apiKey.APIs {
+				if !a.protectedAPIs[api] {
+					return nil, fmt.Errorf("auth API key grants access to %v, which is not a protected namespace", api)
+				}
+				apis[api] = true
+			}
+		}
+		a.apiKeys[apiKey.Key] = apis
+	}
+
+	return a, nil
+}
+
+// loadJWTSecret reads the hex encoded JWT secret from the provided file
+func loadJWTSecret(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the JWT secret file: %w", err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) != jwtSecretLength {
+		return nil, fmt.Errorf("invalid JWT secret, it must be %d hex encoded bytes", jwtSecretLength)
+	}
+	return secret, nil
+}
+
+// authenticate returns the protected namespaces the client sending the request
+// can access based on the credentials provided
+func (a *authenticator) authenticate(httpRequest *http.Request, now time.Time) authGrant {
+	if auth := httpRequest.Header.Get(authorizationHeader); strings.HasPrefix(auth, bearerPrefix) {
+		if err := a.verifyJWT(strings.TrimPrefix(auth, bearerPrefix), now); err != nil {
+			return authGrant{err: err}
+		}
+		return authGrant{allAPIs: true}
+	}
+
+	apiKey := httpRequest.Header.Get(apiKeyHeader)
+	if apiKey == "" {
+		apiKey = httpRequest.URL.Query().Get(apiKeyQueryParam)
+	}
+	if apiKey != "" {
+		apis, found := a.apiKeys[apiKey]
+		if !found {
+			return authGrant{err: fmt.Errorf("unknown API key")}
+		}
+		return authGrant{allAPIs: apis == nil, apis: apis}
+	}
+
+	return authGrant{}
+}
+
+// verifyJWT checks the token is signed with the JWT secret using HS256 and that
+// it was issued recently
+func (a *authenticator) verifyJWT(strToken string, now time.Time) error {
+	if a.jwtSecret == nil {
+		return fmt.Errorf("JWT authentication is not enabled")
+	}
+
+	var claims jwt.RegisteredClaims
+	// the claims are validated below to allow some drift in the issued at claim
+	token, err := jwt.ParseWithClaims(strToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		return a.jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation())
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("invalid JWT: %w", err)
+	case !token.Valid:
+		return fmt.Errorf("invalid JWT")
+	case !claims.VerifyExpiresAt(now, false):
+		return fmt.Errorf("JWT is expired")
+	case claims.IssuedAt == nil:
+		return fmt.Errorf("JWT is missing the issued at claim")
+	case now.Sub(claims.IssuedAt.Time) > jwtExpiryTimeout:
+		return fmt.Errorf("stale JWT")
+	case claims.IssuedAt.Time.Sub(now) > jwtExpiryTimeout:
+		return fmt.Errorf("future JWT")
+	}
+	return nil
+}
+
+// authMethod returns the method whose namespace is checked to authorize the
+// request, the subscriptions of other namespaces created with eth_subscribe,
+// like zkevm_newBatches, require access to the namespace of the subscription
+func authMethod(request types.Request) string {
+	if request.Method != "eth_subscribe" {
+		return request.Method
+	}
+
+	var params []json.RawMessage
+	if err := json.Unmarshal(request.Params, &params); err != nil || len(params) == 0 {
+		return request.Method
+	}
+	var name string
+	if err := json.Unmarshal(params[0], &name); err != nil || !strings.Contains(name, "_") {
+		return request.Method
+	}
+	return name
+}
+
+// authorize returns an error if the method belongs to a protected namespace
+// that the grant doesn't give access to
+func (a *authenticator) authorize(grant authGrant, method string) types.Error {
+	api, _, _ := strings.Cut(method, "_")
+	if !a.protectedAPIs[api] || grant.allAPIs || grant.apis[api] {
+		return nil
+	}
+
+	if grant.err != nil {
+		return types.NewRPCError(types.AccessDeniedCode, "unauthorized: %v", grant.err)
+	} else if grant.apis != nil {
+		return types.NewRPCError(types.AccessDeniedCode, "unauthorized: the credentials provided don't grant access to the %v namespace", api)
+	}
+	return types.NewRPCError(types.AccessDeniedCode, "unauthorized: the %v namespace requires credentials", api)
+}
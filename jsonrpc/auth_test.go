@@ -0,0 +1,344 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "0x7365637265747365637265747365637265747365637265747365637265747365"
+
+func getAuthTestConfig(t *testing.T) AuthConfig {
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(secretFile, []byte(testJWTSecret+"\n"), 0600))
+
+	return AuthConfig{
+		Enabled:       true,
+		ProtectedAPIs: []string{APIDebug, APITxPool, APIZKEVM},
+		JWTSecretFile: secretFile,
+		APIKeys: []AuthAPIKeyConfig{
+			{Key: "admin-key"},
+			{Key: "txpool-key", APIs: []string{APITxPool}},
+		},
+	}
+}
+
+func newTestJWT(t *testing.T, secret []byte, method jwt.SigningMethod, issuedAt time.Time) string {
+	token, err := jwt.NewWithClaims(method, jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)}).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func writeTempFile(t *testing.T, content string) string {
+	fileName := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0600))
+	return fileName
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	a, err := newAuthenticator(AuthConfig{Enabled: false})
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	cfg := getAuthTestConfig(t)
+	cfg.JWTSecretFile = writeTempFile(t, "0x1234")
+	_, err = newAuthenticator(cfg)
+	assert.EqualError(t, err, "invalid JWT secret, it must be 32 hex encoded bytes")
+
+	cfg = getAuthTestConfig(t)
+	cfg.JWTSecretFile = filepath.Join(t.TempDir(), "missing")
+	_, err = newAuthenticator(cfg)
+	assert.ErrorContains(t, err, "failed to read the JWT secret file")
+
+	cfg = getAuthTestConfig(t)
+	cfg.APIKeys = append(cfg.APIKeys, AuthAPIKeyConfig{APIs: []string{APIDebug}})
+	_, err = newAuthenticator(cfg)
+	assert.EqualError(t, err, "auth API key can't be empty")
+
+	cfg = getAuthTestConfig(t)
+	cfg.APIKeys = append(cfg.APIKeys, AuthAPIKeyConfig{Key: "key", APIs: []string{APIEth}})
+	_, err = newAuthenticator(cfg)
+	assert.EqualError(t, err, "auth API key grants access to eth, which is not a protected namespace")
+}
+
+func TestAuthenticatorAuthorize(t *testing.T) {
+	a, err := newAuthenticator(getAuthTestConfig(t))
+	require.NoError(t, err)
+
+	now := time.Now()
+	testCases := []struct {
+		name          string
+		headers       map[string]string
+		query         string
+		method        string
+		expectedError string
+	}{
+		{
+			name:   "public namespace without credentials",
+			method: "eth_blockNumber",
+		},
+		{
+			name:          "protected namespace without credentials",
+			method:        "debug_traceTransaction",
+			expectedError: "unauthorized: the debug namespace requires credentials",
+		},
+		{
+			name:    "valid JWT",
+			headers: map[string]string{authorizationHeader: bearerPrefix + newTestJWT(t, common.FromHex(testJWTSecret), jwt.SigningMethodHS256, now)},
+			method:  "debug_traceTransaction",
+		},
+		{
+			name:    "valid JWT with drift",
+			headers: map[string]string{authorizationHeader: bearerPrefix + newTestJWT(t, common.FromHex(testJWTSecret), jwt.SigningMethodHS256, now.Add(30*time.Second))},
+			method:  "zkevm_batchNumber",
+		},
+		{
+			name:          "stale JWT",
+			headers:       map[string]string{authorizationHeader: bearerPrefix + newTestJWT(t, common.FromHex(testJWTSecret), jwt.SigningMethodHS256, now.Add(-2*time.Minute))},
+			method:        "debug_traceTransaction",
+			expectedError: "unauthorized: stale JWT",
+		},
+		{
+			name:          "future JWT",
+			headers:       map[string]string{authorizationHeader: bearerPrefix + newTestJWT(t, common.FromHex(testJWTSecret), jwt.SigningMethodHS256, now.Add(2*time.Minute))},
+			method:        "debug_traceTransaction",
+			expectedError: "unauthorized: future JWT",
+		},
+		{
+			name:          "JWT signed with another secret",
+			headers:       map[string]string{authorizationHeader: bearerPrefix + newTestJWT(t, []byte("another secret"), jwt.SigningMethodHS256, now)},
+			method:        "debug_traceTransaction",
+			expectedError: "unauthorized: invalid JWT: signature is invalid",
+		},
+		{
+			name:          "JWT signed with another method",
+			headers:       map[string]string{authorizationHeader: bearerPrefix + newTestJWT(t, common.FromHex(testJWTSecret), jwt.SigningMethodHS512, now)},
+			method:        "debug_traceTransaction",
+			expectedError: "unauthorized: invalid JWT: signing method HS512 is invalid",
+		},
+		{
+			name:    "invalid credentials don't block public namespaces",
+			headers: map[string]string{authorizationHeader: bearerPrefix + "invalid"},
+			method:  "eth_blockNumber",
+		},
+		{
+			name:    "API key with access to all the namespaces",
+			headers: map[string]string{apiKeyHeader: "admin-key"},
+			method:  "debug_traceTransaction",
+		},
+		{
+			name:   "API key as query param",
+			query:  "?apikey=txpool-key",
+			method: "txpool_status",
+		},
+		{
+			name:          "API key without access to the namespace",
+			headers:       map[string]string{apiKeyHeader: "txpool-key"},
+			method:        "debug_traceTransaction",
+			expectedError: "unauthorized: the credentials provided don't grant access to the debug namespace",
+		},
+		{
+			name:          "unknown API key",
+			headers:       map[string]string{apiKeyHeader: "unknown-key"},
+			method:        "txpool_status",
+			expectedError: "unauthorized: unknown API key",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/"+testCase.query, nil)
+			for key, value := range testCase.headers {
+				req.Header.Set(key, value)
+			}
+
+			rpcErr := a.authorize(a.authenticate(req, now), testCase.method)
+			if testCase.expectedError == "" {
+				assert.Nil(t, rpcErr)
+			} else {
+				require.NotNil(t, rpcErr)
+				assert.Equal(t, types.AccessDeniedCode, rpcErr.ErrorCode())
+				assert.Equal(t, testCase.expectedError, rpcErr.Error())
+			}
+		})
+	}
+}
+
+func TestAuthRequests(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.Auth = getAuthTestConfig(t)
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	m.Storage.On("UninstallFilterByWSConn", mock.Anything).Return(nil).Maybe()
+	m.Pool.On("GetStatus", mock.Anything).Return(pool.TxPoolStatus{Pending: 1}, nil)
+
+	doRequest := func(t *testing.T, body interface{}, headers map[string]string) (*http.Response, []byte) {
+		reqBody, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, s.ServerURL, bytes.NewReader(reqBody))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		httpRes, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer httpRes.Body.Close()
+		resBody, err := io.ReadAll(httpRes.Body)
+		require.NoError(t, err)
+		return httpRes, resBody
+	}
+	newRequest := func(id int, method string) types.Request {
+		return types.Request{JSONRPC: "2.0", ID: float64(id), Method: method, Params: json.RawMessage("[]")}
+	}
+
+	t.Run("single requests", func(t *testing.T) {
+		httpRes, resBody := doRequest(t, newRequest(1, "eth_chainId"), nil)
+		require.Equal(t, http.StatusOK, httpRes.StatusCode)
+		var res types.Response
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		assert.Nil(t, res.Error)
+
+		httpRes, resBody = doRequest(t, newRequest(2, "txpool_status"), nil)
+		require.Equal(t, http.StatusUnauthorized, httpRes.StatusCode)
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.AccessDeniedCode, res.Error.Code)
+
+		jwtToken := newTestJWT(t, common.FromHex(testJWTSecret), jwt.SigningMethodHS256, time.Now())
+		httpRes, resBody = doRequest(t, newRequest(3, "txpool_status"), map[string]string{authorizationHeader: bearerPrefix + jwtToken})
+		require.Equal(t, http.StatusOK, httpRes.StatusCode)
+		res = types.Response{}
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		assert.Nil(t, res.Error)
+	})
+
+	t.Run("batch requests", func(t *testing.T) {
+		httpRes, resBody := doRequest(t, []types.Request{
+			newRequest(1, "eth_chainId"),
+			newRequest(2, "txpool_status"),
+			newRequest(3, "debug_traceTransaction"),
+		}, map[string]string{apiKeyHeader: "txpool-key"})
+		require.Equal(t, http.StatusOK, httpRes.StatusCode)
+
+		var res []types.Response
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		require.Len(t, res, 3)
+		assert.Nil(t, res[0].Error)
+		assert.Nil(t, res[1].Error)
+		require.NotNil(t, res[2].Error)
+		assert.Equal(t, types.AccessDeniedCode, res[2].Error.Code)
+	})
+
+	t.Run("web socket messages", func(t *testing.T) {
+		sendMessage := func(t *testing.T, wsConn *websocket.Conn, method string) types.Response {
+			message, err := json.Marshal(newRequest(1, method))
+			require.NoError(t, err)
+			require.NoError(t, wsConn.WriteMessage(websocket.TextMessage, message))
+
+			_, resBody, err := wsConn.ReadMessage()
+			require.NoError(t, err)
+			var res types.Response
+			require.NoError(t, json.Unmarshal(resBody, &res))
+			return res
+		}
+
+		wsConn, _, err := websocket.DefaultDialer.Dial(s.ServerWebSocketsURL, nil)
+		require.NoError(t, err)
+		defer wsConn.Close()
+
+		res := sendMessage(t, wsConn, "eth_chainId")
+		assert.Nil(t, res.Error)
+		res = sendMessage(t, wsConn, "txpool_status")
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.AccessDeniedCode, res.Error.Code)
+
+		authWsConn, _, err := websocket.DefaultDialer.Dial(s.ServerWebSocketsURL+"?apikey=admin-key", nil)
+		require.NoError(t, err)
+		defer authWsConn.Close()
+
+		res = sendMessage(t, authWsConn, "txpool_status")
+		assert.Nil(t, res.Error)
+	})
+
+	t.Run("web socket subscriptions of protected namespaces", func(t *testing.T) {
+		subscribe := func(t *testing.T, wsConn *websocket.Conn, name string) types.Response {
+			message, err := json.Marshal(types.Request{JSONRPC: "2.0", ID: float64(1), Method: "eth_subscribe", Params: json.RawMessage(`["` + name + `"]`)})
+			require.NoError(t, err)
+			require.NoError(t, wsConn.WriteMessage(websocket.TextMessage, message))
+
+			_, resBody, err := wsConn.ReadMessage()
+			require.NoError(t, err)
+			var res types.Response
+			require.NoError(t, json.Unmarshal(resBody, &res))
+			return res
+		}
+		m.Storage.On("NewSubscriptionFilter", mock.Anything, FilterType(FilterTypeNewBatches)).Return("0x1", nil).Once()
+		m.Storage.On("NewSubscriptionFilter", mock.Anything, FilterType(FilterTypeSyncing)).Return("0x2", nil).Once()
+
+		wsConn, _, err := websocket.DefaultDialer.Dial(s.ServerWebSocketsURL+"?apikey=txpool-key", nil)
+		require.NoError(t, err)
+		defer wsConn.Close()
+
+		res := subscribe(t, wsConn, "zkevm_newBatches")
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.AccessDeniedCode, res.Error.Code)
+		res = subscribe(t, wsConn, "syncing")
+		assert.Nil(t, res.Error)
+
+		authWsConn, _, err := websocket.DefaultDialer.Dial(s.ServerWebSocketsURL+"?apikey=admin-key", nil)
+		require.NoError(t, err)
+		defer authWsConn.Close()
+
+		res = subscribe(t, authWsConn, "zkevm_newBatches")
+		assert.Nil(t, res.Error)
+	})
+}
+
+func TestAuthMethod(t *testing.T) {
+	testCases := []struct {
+		method   string
+		params   string
+		expected string
+	}{
+		{method: "zkevm_batchNumber", params: `[]`, expected: "zkevm_batchNumber"},
+		{method: "eth_subscribe", params: `["newHeads"]`, expected: "eth_subscribe"},
+		{method: "eth_subscribe", params: `["logs", {"address": "0x1"}]`, expected: "eth_subscribe"},
+		{method: "eth_subscribe", params: `["zkevm_newBatches"]`, expected: "zkevm_newBatches"},
+		{method: "eth_subscribe", params: `[]`, expected: "eth_subscribe"},
+		{method: "eth_subscribe", params: `{}`, expected: "eth_subscribe"},
+	}
+
+	for _, testCase := range testCases {
+		request := types.Request{Method: testCase.method, Params: json.RawMessage(testCase.params)}
+		assert.Equal(t, testCase.expected, authMethod(request), testCase.params)
+	}
+}
+
+func TestSubscriptionOfDisabledNamespace(t *testing.T) {
+	handler := newJSONRpcHandler()
+	handler.registerService(Service{Name: APIEth, Service: &EthEndpoints{}})
+
+	_, _, rpcErr := handler.getFnHandler(types.Request{Method: "eth_subscribe", Params: json.RawMessage(`["zkevm_newBatches"]`)})
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.NotFoundErrorCode, rpcErr.ErrorCode())
+
+	_, _, rpcErr = handler.getFnHandler(types.Request{Method: "eth_subscribe", Params: json.RawMessage(`["newHeads"]`)})
+	assert.Nil(t, rpcErr)
+}
@@ -0,0 +1,208 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// cacheBlockNumberResolver returns the number of the L2 block the response of a
+// request refers to, false is returned when it can't be determined
+type cacheBlockNumberResolver func(ctx context.Context, st types.StateInterface, params, result []byte) (uint64, bool)
+
+// cachedMethods are the methods whose responses never change once the L2 block
+// they refer to is virtualized, with the way to find that block
+var cachedMethods = map[string]cacheBlockNumberResolver{
+	"eth_getBlockByHash":        blockNumberFromResult("number"),
+	"eth_getBlockByNumber":      blockNumberFromResult("number"),
+	"eth_getTransactionByHash":  blockNumberFromResult("blockNumber"),
+	"eth_getTransactionReceipt": blockNumberFromResult("blockNumber"),
+	"debug_traceTransaction":    blockNumberFromTxReceipt,
+}
+
+// mutableBlockTags are the block tags whose block changes over time, the
+// responses to requests using them are never cached
+var mutableBlockTags = []string{types.Latest, types.Pending, types.Safe, types.Finalized}
+
+// responseCache keeps the responses of the requests that refer to virtualized
+// L2 blocks, which can't change unless there is a reorg. The synchronizer runs
+// with its own state instance, so the cache is purged when the number of state
+// resets it records in the DB changes. As a fallback, it is also purged when the
+// last virtualized L2 block goes backwards, which is the effect of a reset. Both
+// are checked at most once per check interval
+type responseCache struct {
+	state         types.StateInterface
+	entries       *lru.Cache[string, []byte]
+	checkInterval time.Duration
+
+	l1Reorgs               uint64
+	l1ReorgsLoaded         bool
+	lastVirtualizedL2Block uint64
+	lastCheck              time.Time
+	mutex                  *sync.Mutex
+}
+
+// newResponseCache creates the response cache for the provided config, nil is
+// returned when the cache is disabled
+func newResponseCache(cfg ResponseCacheConfig, st types.StateInterface) *responseCache {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &responseCache{
+		state:         st,
+		entries:       lru.NewCache[string, []byte](cfg.MaxEntries),
+		checkInterval: cfg.VirtualizedBlockCheckInterval.Duration,
+		mutex:         &sync.Mutex{},
+	}
+}
+
+// cacheable returns true if the response of the request can be cached
+func (c *responseCache) cacheable(req types.Request) bool {
+	if _, found := cachedMethods[req.Method]; !found {
+		return false
+	}
+
+	var params []interface{}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return false
+	}
+	for _, param := range params {
+		if value, isString := param.(string); isString {
+			for _, tag := range mutableBlockTags {
+				if value == tag {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// get returns the cached response of the request
+func (c *responseCache) get(ctx context.Context, req types.Request) ([]byte, bool) {
+	// make sure the cache is purged in case of a reorg before using it
+	if _, err := c.virtualizedL2BlockNumber(ctx); err != nil {
+		log.Errorf("failed to check the last virtualized l2 block for the response cache: %v", err)
+		return nil, false
+	}
+
+	result, found := c.entries.Get(c.key(req))
+	if found {
+		metrics.CacheHit(req.Method)
+	} else {
+		metrics.CacheMiss(req.Method)
+	}
+	return result, found
+}
+
+// add caches the response of the request if the L2 block it refers to is virtualized
+func (c *responseCache) add(ctx context.Context, req types.Request, result []byte) {
+	blockNumber, found := cachedMethods[req.Method](ctx, c.state, req.Params, result)
+	if !found {
+		return
+	}
+
+	lastVirtualizedL2Block, err := c.virtualizedL2BlockNumber(ctx)
+	if err != nil {
+		log.Errorf("failed to check the last virtualized l2 block for the response cache: %v", err)
+		return
+	} else if blockNumber > lastVirtualizedL2Block {
+		return
+	}
+
+	c.entries.Add(c.key(req), result)
+}
+
+// key returns the cache key of the request, made of its method and params
+func (c *responseCache) key(req types.Request) string {
+	params := bytes.Buffer{}
+	if err := json.Compact(&params, req.Params); err != nil {
+		return req.Method + string(req.Params)
+	}
+	return req.Method + params.String()
+}
+
+// virtualizedL2BlockNumber returns the number of the last virtualized L2 block.
+// The state resets and the last virtualized L2 block are loaded from the state at
+// most once per check interval, so the requests in between don't hit the DB. The
+// cache is purged on every reset, or if the last virtualized L2 block is lower
+// than the one loaded previously
+func (c *responseCache) virtualizedL2BlockNumber(ctx context.Context) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if !c.lastCheck.IsZero() && now.Sub(c.lastCheck) < c.checkInterval {
+		return c.lastVirtualizedL2Block, nil
+	}
+
+	l1Reorgs, err := c.state.CountL1Reorgs(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if l1Reorgs != c.l1Reorgs {
+		if c.l1ReorgsLoaded {
+			log.Infof("state reset detected, purging the response cache")
+		}
+		c.entries.Purge()
+		c.l1Reorgs = l1Reorgs
+		c.l1ReorgsLoaded = true
+	}
+
+	lastVirtualizedL2Block, err := c.state.GetLastVirtualizedL2BlockNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if lastVirtualizedL2Block < c.lastVirtualizedL2Block {
+		log.Infof("last virtualized l2 block moved back from %v to %v, purging the response cache", c.lastVirtualizedL2Block, lastVirtualizedL2Block)
+		c.entries.Purge()
+	}
+	c.lastVirtualizedL2Block = lastVirtualizedL2Block
+	c.lastCheck = now
+
+	return lastVirtualizedL2Block, nil
+}
+
+// blockNumberFromResult returns a resolver that reads the block number from the
+// provided field of the result
+func blockNumberFromResult(field string) cacheBlockNumberResolver {
+	return func(_ context.Context, _ types.StateInterface, _, result []byte) (uint64, bool) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(result, &fields); err != nil || fields == nil {
+			return 0, false
+		}
+		var blockNumber *types.ArgUint64
+		if err := json.Unmarshal(fields[field], &blockNumber); err != nil || blockNumber == nil {
+			return 0, false
+		}
+		return uint64(*blockNumber), true
+	}
+}
+
+// blockNumberFromTxReceipt is a resolver that loads the block number from the
+// receipt of the transaction provided as first param
+func blockNumberFromTxReceipt(ctx context.Context, st types.StateInterface, params, _ []byte) (uint64, bool) {
+	var hashes []json.RawMessage
+	if err := json.Unmarshal(params, &hashes); err != nil || len(hashes) == 0 {
+		return 0, false
+	}
+	var hash common.Hash
+	if err := json.Unmarshal(hashes[0], &hash); err != nil {
+		return 0, false
+	}
+
+	receipt, err := st.GetTransactionReceipt(ctx, hash, nil)
+	if err != nil {
+		return 0, false
+	}
+	return receipt.BlockNumber.Uint64(), true
+}
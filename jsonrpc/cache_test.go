@@ -0,0 +1,163 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type cacheTestEthService struct {
+	calls int
+}
+
+func (s *cacheTestEthService) GetBlockByNumber(number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
+	s.calls++
+	if number > 100 {
+		return nil, nil
+	}
+	return map[string]interface{}{"number": hex.EncodeUint64(uint64(number)), "calls": s.calls}, nil
+}
+
+type cacheTestDebugService struct {
+	calls int
+}
+
+func (s *cacheTestDebugService) TraceTransaction(hash types.ArgHash) (interface{}, types.Error) {
+	s.calls++
+	return map[string]interface{}{"gas": s.calls}, nil
+}
+
+func TestResponseCache(t *testing.T) {
+	st := mocks.NewStateMock(t)
+	cfg := ResponseCacheConfig{Enabled: true, MaxEntries: 10}
+	assert.Nil(t, newResponseCache(ResponseCacheConfig{}, st))
+
+	virtualizedL2Block := uint64(10)
+	st.On("GetLastVirtualizedL2BlockNumber", mock.Anything, nil).Return(func(context.Context, pgx.Tx) (uint64, error) {
+		return virtualizedL2Block, nil
+	}, nil)
+	l1Reorgs := uint64(1)
+	l1ReorgsChecks := 0
+	st.On("CountL1Reorgs", mock.Anything, nil).Return(func(context.Context, pgx.Tx) (uint64, error) {
+		l1ReorgsChecks++
+		return l1Reorgs, nil
+	}, nil)
+
+	txHash := common.HexToHash("0x1")
+	st.On("GetTransactionReceipt", mock.Anything, txHash, nil).Return(&ethTypes.Receipt{BlockNumber: big.NewInt(3)}, nil).Once()
+
+	ethService := &cacheTestEthService{}
+	debugService := &cacheTestDebugService{}
+	handler := newJSONRpcHandler()
+	handler.registerService(Service{Name: APIEth, Service: ethService})
+	handler.registerService(Service{Name: APIDebug, Service: debugService})
+	handler.cache = newResponseCache(cfg, st)
+
+	call := func(t *testing.T, method string, params ...interface{}) json.RawMessage {
+		rawParams, err := json.Marshal(params)
+		require.NoError(t, err)
+		res := handler.Handle(handleRequest{Request: types.Request{JSONRPC: "2.0", ID: float64(1), Method: method, Params: rawParams}})
+		require.Nil(t, res.Error)
+		return res.Result
+	}
+
+	t.Run("virtualized block is cached", func(t *testing.T) {
+		ethService.calls = 0
+		first := call(t, "eth_getBlockByNumber", "0x5", false)
+		second := call(t, "eth_getBlockByNumber", "0x5", false)
+		assert.Equal(t, 1, ethService.calls)
+		assert.JSONEq(t, string(first), string(second))
+
+		// different params are cached separately
+		call(t, "eth_getBlockByNumber", "0x5", true)
+		assert.Equal(t, 2, ethService.calls)
+	})
+
+	t.Run("block not virtualized yet is not cached", func(t *testing.T) {
+		ethService.calls = 0
+		call(t, "eth_getBlockByNumber", "0xb", false)
+		call(t, "eth_getBlockByNumber", "0xb", false)
+		assert.Equal(t, 2, ethService.calls)
+	})
+
+	t.Run("not found responses are not cached", func(t *testing.T) {
+		ethService.calls = 0
+		call(t, "eth_getBlockByNumber", "0x65", false)
+		call(t, "eth_getBlockByNumber", "0x65", false)
+		assert.Equal(t, 2, ethService.calls)
+	})
+
+	t.Run("mutable block tags are not cached", func(t *testing.T) {
+		ethService.calls = 0
+		call(t, "eth_getBlockByNumber", types.Latest, false)
+		call(t, "eth_getBlockByNumber", types.Latest, false)
+		assert.Equal(t, 2, ethService.calls)
+	})
+
+	t.Run("trace of a virtualized transaction is cached", func(t *testing.T) {
+		call(t, "debug_traceTransaction", txHash.String())
+		call(t, "debug_traceTransaction", txHash.String())
+		assert.Equal(t, 1, debugService.calls)
+	})
+
+	t.Run("state reset purges the cache", func(t *testing.T) {
+		ethService.calls = 0
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		assert.Equal(t, 0, ethService.calls)
+
+		// the last virtualized l2 block is the same after the reset
+		l1Reorgs++
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		assert.Equal(t, 1, ethService.calls)
+	})
+
+	t.Run("last virtualized block moving back purges the cache", func(t *testing.T) {
+		ethService.calls = 0
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		assert.Equal(t, 0, ethService.calls)
+
+		virtualizedL2Block = 2
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		call(t, "eth_getBlockByNumber", "0x5", false)
+		assert.Equal(t, 2, ethService.calls)
+		assert.Equal(t, 0, handler.cache.entries.Len())
+	})
+
+	t.Run("state is checked at most once per interval", func(t *testing.T) {
+		intervalCfg := cfg
+		intervalCfg.VirtualizedBlockCheckInterval.Duration = time.Hour
+		handler.cache = newResponseCache(intervalCfg, st)
+		ethService.calls = 0
+		l1ReorgsChecks = 0
+		call(t, "eth_getBlockByNumber", "0x1", false)
+		call(t, "eth_getBlockByNumber", "0x1", false)
+		call(t, "eth_getBlockByNumber", "0x1", false)
+		assert.Equal(t, 1, ethService.calls)
+		assert.Equal(t, 1, l1ReorgsChecks)
+
+		// a reset is only detected on the next check
+		l1Reorgs++
+		call(t, "eth_getBlockByNumber", "0x1", false)
+		assert.Equal(t, 1, ethService.calls)
+		assert.Equal(t, 1, l1ReorgsChecks)
+
+		handler.cache.lastCheck = time.Now().Add(-time.Hour)
+		call(t, "eth_getBlockByNumber", "0x1", false)
+		assert.Equal(t, 2, ethService.calls)
+		assert.Equal(t, 2, l1ReorgsChecks)
+	})
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,8 @@ const jsonRPCVersion = "2.0"
 // Client defines typed wrappers for the zkEVM RPC API.
 type Client struct {
 	url string
+	// ws is the web socket connection of the clients created with DialWS
+	ws *wsConnection
 }
 
 // NewClient creates an instance of client
@@ -24,10 +27,63 @@ func NewClient(url string) *Client {
 	}
 }
 
+// DialWS creates an instance of client connected to the web socket server of
+// a node, which sends the calls through the connection and allows to subscribe
+// to the node notifications
+func DialWS(ctx context.Context, url string) (*Client, error) {
+	ws, err := dialWS(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		url: url,
+		ws:  ws,
+	}, nil
+}
+
+// Close closes the web socket connection of the client, if any
+func (c *Client) Close() error {
+	if c.ws == nil {
+		return nil
+	}
+	return c.ws.close()
+}
+
+// call executes the provided method through the web socket connection
+// of the client if it has one, otherwise through HTTP
+func (c *Client) call(ctx context.Context, method string, parameters ...interface{}) (types.Response, error) {
+	if c.ws != nil {
+		return c.ws.call(ctx, nil, method, parameters...)
+	}
+	return JSONRPCCallWithContext(ctx, c.url, method, parameters...)
+}
+
+// callResult executes the provided method and decodes its result into the
+// provided value, returning the RPC error of the response if any
+func (c *Client) callResult(ctx context.Context, result interface{}, method string, parameters ...interface{}) error {
+	response, err := c.call(ctx, method, parameters...)
+	if err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return response.Error.RPCError()
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
 // JSONRPCCall executes a 2.0 JSON RPC HTTP Post Request to the provided URL with
 // the provided method and parameters, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response, error) {
+	return JSONRPCCallWithContext(context.Background(), url, method, parameters...)
+}
+
+// JSONRPCCallWithContext executes a 2.0 JSON RPC HTTP Post Request like
+// JSONRPCCall, which is canceled when the provided context is done.
+func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters ...interface{}) (types.Response, error) {
 	params, err := json.Marshal(parameters)
 	if err != nil {
 		return types.Response{}, err
@@ -40,7 +96,7 @@ func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response,
 		Params:  params,
 	}
 
-	httpRes, err := sendJSONRPC_HTTPRequest(url, request)
+	httpRes, err := sendJSONRPC_HTTPRequest(ctx, url, request)
 	if err != nil {
 		return types.Response{}, err
 	}
@@ -91,7 +147,7 @@ func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error)
 		requests = append(requests, req)
 	}
 
-	httpRes, err := sendJSONRPC_HTTPRequest(url, requests)
+	httpRes, err := sendJSONRPC_HTTPRequest(context.Background(), url, requests)
 	if err != nil {
 		return nil, err
 	}
@@ -116,14 +172,14 @@ func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error)
 	return res, nil
 }
 
-func sendJSONRPC_HTTPRequest(url string, payload interface{}) (*http.Response, error) {
+func sendJSONRPC_HTTPRequest(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
 	reqBodyReader := bytes.NewReader(reqBody)
-	httpReq, err := http.NewRequest(http.MethodPost, url, reqBodyReader)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqBodyReader)
 	if err != nil {
 		return nil, err
 	}
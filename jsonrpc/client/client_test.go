@@ -0,0 +1,477 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is a stand-in of the JSON RPC server of a node, which answers
+// the calls with the results registered for each method and params, both
+// through HTTP and web sockets
+type testServer struct {
+	t        *testing.T
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mutex   sync.Mutex
+	results map[string]json.RawMessage
+	errors  map[string]*types.ErrorObject
+	// notifications are sent to the web socket subscriptions after they are created
+	notifications map[string][]json.RawMessage
+	subscriptions int
+	unsubscribed  []string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		t:             t,
+		results:       map[string]json.RawMessage{},
+		errors:        map[string]*types.ErrorObject{},
+		notifications: map[string][]json.RawMessage{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *testServer) httpURL() string {
+	return s.server.URL
+}
+
+func (s *testServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http")
+}
+
+// on registers the result of a method called with the provided params
+func (s *testServer) on(method, params, result string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.results[method+params] = json.RawMessage(result)
+}
+
+// onError registers the error of a method called with the provided params
+func (s *testServer) onError(method, params string, err *types.ErrorObject) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errors[method+params] = err
+}
+
+// onSubscribe registers the notifications sent to the subscriptions created with the provided params
+func (s *testServer) onSubscribe(params string, notifications ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, notification := range notifications {
+		s.notifications[params] = append(s.notifications[params], json.RawMessage(notification))
+	}
+}
+
+func (s *testServer) response(req types.Request) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := req.Method + string(req.Params)
+	res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if err, found := s.errors[key]; found {
+		res["error"] = err
+	} else if result, found := s.results[key]; found {
+		res["result"] = result
+	} else {
+		res["error"] = types.ErrorObject{Code: types.NotFoundErrorCode, Message: "unexpected call " + key}
+	}
+	return res
+}
+
+func (s *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		s.handleWS(w, r)
+		return
+	}
+
+	var req types.Request
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(s.t, json.NewEncoder(w).Encode(s.response(req)))
+}
+
+func (s *testServer) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	require.NoError(s.t, err)
+	defer conn.Close()
+
+	for {
+		var req types.Request
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		switch req.Method {
+		case "eth_subscribe":
+			s.mutex.Lock()
+			s.subscriptions++
+			id := types.ArgUint64(s.subscriptions).Hex()
+			notifications := s.notifications[string(req.Params)]
+			s.mutex.Unlock()
+
+			require.NoError(s.t, conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": id}))
+			// the notifications are sent right after the response, like the node does
+			for _, notification := range notifications {
+				require.NoError(s.t, conn.WriteJSON(types.SubscriptionResponse{
+					JSONRPC: "2.0",
+					Method:  "eth_subscription",
+					Params:  types.SubscriptionResponseParams{Subscription: id, Result: notification},
+				}))
+			}
+		case "eth_unsubscribe":
+			var params []string
+			require.NoError(s.t, json.Unmarshal(req.Params, &params))
+			s.mutex.Lock()
+			s.unsubscribed = append(s.unsubscribed, params...)
+			s.mutex.Unlock()
+			require.NoError(s.t, conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": true}))
+		default:
+			require.NoError(s.t, conn.WriteJSON(s.response(req)))
+		}
+	}
+}
+
+func TestZKEVMCalls(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+
+	hash := common.HexToHash("0x1")
+	tx := types.TxArgs{To: &common.Address{1}}
+	txParam, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	type testCase struct {
+		name     string
+		method   string
+		params   string
+		result   string
+		call     func(c *Client) (interface{}, error)
+		expected interface{}
+	}
+
+	testCases := []testCase{
+		{
+			name:     "consolidated block number",
+			method:   "zkevm_consolidatedBlockNumber",
+			params:   `null`,
+			result:   `"0xa"`,
+			call:     func(c *Client) (interface{}, error) { return c.ConsolidatedBlockNumber(ctx) },
+			expected: uint64(10),
+		},
+		{
+			name:     "is block consolidated",
+			method:   "zkevm_isBlockConsolidated",
+			params:   `["0x2"]`,
+			result:   `true`,
+			call:     func(c *Client) (interface{}, error) { return c.IsBlockConsolidated(ctx, 2) },
+			expected: true,
+		},
+		{
+			name:     "is block virtualized",
+			method:   "zkevm_isBlockVirtualized",
+			params:   `["0x3"]`,
+			result:   `false`,
+			call:     func(c *Client) (interface{}, error) { return c.IsBlockVirtualized(ctx, 3) },
+			expected: false,
+		},
+		{
+			name:     "batch number by block number",
+			method:   "zkevm_batchNumberByBlockNumber",
+			params:   `["0x4"]`,
+			result:   `"0x2"`,
+			call:     func(c *Client) (interface{}, error) { return c.BatchNumberByBlockNumber(ctx, 4) },
+			expected: uint64(2),
+		},
+		{
+			name:     "batch number",
+			method:   "zkevm_batchNumber",
+			params:   `null`,
+			result:   `"0x7"`,
+			call:     func(c *Client) (interface{}, error) { return c.BatchNumber(ctx) },
+			expected: uint64(7),
+		},
+		{
+			name:     "virtual batch number",
+			method:   "zkevm_virtualBatchNumber",
+			params:   `null`,
+			result:   `"0x6"`,
+			call:     func(c *Client) (interface{}, error) { return c.VirtualBatchNumber(ctx) },
+			expected: uint64(6),
+		},
+		{
+			name:     "verified batch number",
+			method:   "zkevm_verifiedBatchNumber",
+			params:   `null`,
+			result:   `"0x5"`,
+			call:     func(c *Client) (interface{}, error) { return c.VerifiedBatchNumber(ctx) },
+			expected: uint64(5),
+		},
+		{
+			name:   "full block by number",
+			method: "zkevm_getFullBlockByNumber",
+			params: `["0x8",true]`,
+			result: `{"number":"0x8","hash":"` + hash.String() + `","transactions":[]}`,
+			call: func(c *Client) (interface{}, error) {
+				block, err := c.FullBlockByNumber(ctx, big.NewInt(8), true)
+				if err != nil {
+					return nil, err
+				}
+				return *block.Hash, nil
+			},
+			expected: hash,
+		},
+		{
+			name:   "full block by hash",
+			method: "zkevm_getFullBlockByHash",
+			params: `["` + hash.String() + `",false]`,
+			result: `{"number":"0x8","hash":"` + hash.String() + `","transactions":[]}`,
+			call: func(c *Client) (interface{}, error) {
+				block, err := c.FullBlockByHash(ctx, hash, false)
+				if err != nil {
+					return nil, err
+				}
+				return block.Number, nil
+			},
+			expected: types.ArgUint64(8),
+		},
+		{
+			name:     "native block hashes in range",
+			method:   "zkevm_getNativeBlockHashesInRange",
+			params:   `[{"fromBlock":"0x1","toBlock":"0x2"}]`,
+			result:   `["` + hash.String() + `","` + common.HexToHash("0x2").String() + `"]`,
+			call:     func(c *Client) (interface{}, error) { return c.NativeBlockHashesInRange(ctx, 1, 2) },
+			expected: []common.Hash{hash, common.HexToHash("0x2")},
+		},
+		{
+			name:   "transaction by l2 hash",
+			method: "zkevm_getTransactionByL2Hash",
+			params: `["` + hash.String() + `"]`,
+			result: `{"nonce":"0x3","gasPrice":"0x1","gas":"0x5208","value":"0x0","input":"0x","v":"0x1","r":"0x1","s":"0x1","hash":"` + hash.String() + `","from":"0x0000000000000000000000000000000000000001","chainId":"0x3e8","type":"0x0"}`,
+			call: func(c *Client) (interface{}, error) {
+				tx, err := c.TransactionByL2Hash(ctx, hash)
+				if err != nil {
+					return nil, err
+				}
+				return tx.Nonce, nil
+			},
+			expected: types.ArgUint64(3),
+		},
+		{
+			name:   "transaction receipt by l2 hash",
+			method: "zkevm_getTransactionReceiptByL2Hash",
+			params: `["` + hash.String() + `"]`,
+			result: `{"status":"0x1","transactionHash":"` + hash.String() + `","blockNumber":"0x9","logs":[]}`,
+			call: func(c *Client) (interface{}, error) {
+				receipt, err := c.TransactionReceiptByL2Hash(ctx, hash)
+				if err != nil {
+					return nil, err
+				}
+				return receipt.BlockNumber, nil
+			},
+			expected: types.ArgUint64(9),
+		},
+		{
+			name:   "exit roots by GER",
+			method: "zkevm_getExitRootsByGER",
+			params: `["` + hash.String() + `"]`,
+			result: `{"blockNumber":"0x1","timestamp":"0x2","mainnetExitRoot":"` + hash.String() + `","rollupExitRoot":"` + hash.String() + `"}`,
+			call: func(c *Client) (interface{}, error) {
+				r, err := c.ExitRootsByGER(ctx, hash)
+				return r.MainnetExitRoot, err
+			},
+			expected: hash,
+		},
+		{
+			name:     "latest global exit root",
+			method:   "zkevm_getLatestGlobalExitRoot",
+			params:   `null`,
+			result:   `"` + hash.String() + `"`,
+			call:     func(c *Client) (interface{}, error) { return c.GetLatestGlobalExitRoot(ctx) },
+			expected: hash,
+		},
+		{
+			name:     "estimate gas price",
+			method:   "zkevm_estimateGasPrice",
+			params:   `[` + string(txParam) + `,"latest"]`,
+			result:   `"0x3b9aca00"`,
+			call:     func(c *Client) (interface{}, error) { return c.EstimateGasPrice(ctx, tx, nil) },
+			expected: big.NewInt(1000000000),
+		},
+		{
+			name:     "estimate fee",
+			method:   "zkevm_estimateFee",
+			params:   `[` + string(txParam) + `,"0x1"]`,
+			result:   `"0x1"`,
+			call:     func(c *Client) (interface{}, error) { return c.EstimateFee(ctx, tx, big.NewInt(1)) },
+			expected: big.NewInt(1),
+		},
+		{
+			name:   "estimate counters",
+			method: "zkevm_estimateCounters",
+			params: `[` + string(txParam) + `,"latest"]`,
+			result: `{"countersUsed":{"gasUsed":"0x5208","usedSteps":"0x64"},"countersLimit":{"maxSteps":"0x3e8"},"oocError":"out of counters"}`,
+			call: func(c *Client) (interface{}, error) {
+				counters, err := c.EstimateCounters(ctx, tx, nil)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{counters.CountersUsed.UsedSteps, counters.CountersLimits.MaxSteps, *counters.OOCError}, nil
+			},
+			expected: []interface{}{types.ArgUint64(100), types.ArgUint64(1000), "out of counters"},
+		},
+	}
+
+	for _, tc := range testCases {
+		server.on(tc.method, tc.params, tc.result)
+	}
+
+	wsClient, err := DialWS(ctx, server.wsURL())
+	require.NoError(t, err)
+	defer wsClient.Close()
+
+	for transport, c := range map[string]*Client{"http": NewClient(server.httpURL()), "ws": wsClient} {
+		for _, testCase := range testCases {
+			tc := testCase
+			t.Run(transport+" "+tc.name, func(t *testing.T) {
+				result, err := tc.call(c)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			})
+		}
+	}
+}
+
+func TestZKEVMCallErrors(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+
+	server.on("zkevm_batchNumberByBlockNumber", `["0x9"]`, `null`)
+	server.onError("zkevm_batchNumber", `null`, &types.ErrorObject{Code: types.DefaultErrorCode, Message: "failed to get the last batch number from state"})
+
+	wsClient, err := DialWS(ctx, server.wsURL())
+	require.NoError(t, err)
+
+	for transport, c := range map[string]*Client{"http": NewClient(server.httpURL()), "ws": wsClient} {
+		t.Run(transport, func(t *testing.T) {
+			_, err := c.BatchNumberByBlockNumber(ctx, 9)
+			assert.ErrorIs(t, err, ethereum.NotFound)
+
+			_, err = c.BatchNumber(ctx)
+			var rpcErr types.RPCError
+			require.ErrorAs(t, err, &rpcErr)
+			assert.Equal(t, types.DefaultErrorCode, rpcErr.ErrorCode())
+			assert.Equal(t, "failed to get the last batch number from state", rpcErr.Error())
+		})
+	}
+
+	require.NoError(t, wsClient.Close())
+	_, err = wsClient.BatchNumber(ctx)
+	assert.ErrorIs(t, err, ErrClientClosed)
+
+	_, err = NewClient(server.httpURL()).SubscribeNewBatches(ctx, make(chan *types.Batch))
+	assert.ErrorIs(t, err, ErrSubscriptionsNotSupported)
+}
+
+func TestSubscriptions(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+
+	hash := common.HexToHash("0x1")
+	server.onSubscribe(`["zkevm_newBatches"]`, `{"number":"0x1","blocks":[]}`, `{"number":"0x2","blocks":[]}`)
+	server.onSubscribe(`["newPendingTransactions"]`, `"`+hash.String()+`"`)
+	server.onSubscribe(`["newPendingTransactions",true]`, `{"nonce":"0x3","gasPrice":"0x1","gas":"0x5208","value":"0x0","input":"0x","v":"0x1","r":"0x1","s":"0x1","hash":"`+hash.String()+`","from":"0x0000000000000000000000000000000000000001","chainId":"0x3e8","type":"0x0"}`)
+	server.onSubscribe(`["syncing"]`, `{"syncing":true,"status":{"startingBlock":"0x1","currentBlock":"0x2","highestBlock":"0x3"}}`, `false`)
+	server.onSubscribe(`["logs",{"address":"0x0000000000000000000000000000000000000001"}]`, `{"address":"0x0000000000000000000000000000000000000001","topics":[],"data":"0x","blockNumber":"0x1","transactionHash":"`+hash.String()+`","transactionIndex":"0x0","blockHash":"`+hash.String()+`","logIndex":"0x0","removed":false}`)
+
+	c, err := DialWS(ctx, server.wsURL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	t.Run("batches", func(t *testing.T) {
+		ch := make(chan *types.Batch)
+		sub, err := c.SubscribeNewBatches(ctx, ch)
+		require.NoError(t, err)
+		assert.NotEmpty(t, sub.ID())
+
+		assert.Equal(t, types.ArgUint64(1), receiveNotification(t, ch).Number)
+		assert.Equal(t, types.ArgUint64(2), receiveNotification(t, ch).Number)
+
+		sub.Unsubscribe()
+		_, open := <-sub.Err()
+		assert.False(t, open)
+		require.Eventually(t, func() bool {
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+			return len(server.unsubscribed) == 1 && server.unsubscribed[0] == sub.ID()
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("pending transactions", func(t *testing.T) {
+		hashes := make(chan common.Hash, 1)
+		_, err := c.SubscribeNewPendingTransactions(ctx, hashes)
+		require.NoError(t, err)
+		assert.Equal(t, hash, receiveNotification(t, hashes))
+
+		txs := make(chan *types.Transaction, 1)
+		_, err = c.SubscribeNewPendingFullTransactions(ctx, txs)
+		require.NoError(t, err)
+		assert.Equal(t, types.ArgUint64(3), receiveNotification(t, txs).Nonce)
+	})
+
+	t.Run("syncing", func(t *testing.T) {
+		ch := make(chan SyncingStatus, 2)
+		_, err := c.SubscribeSyncing(ctx, ch)
+		require.NoError(t, err)
+		assert.Equal(t, SyncingStatus{Syncing: true, StartingBlock: 1, CurrentBlock: 2, HighestBlock: 3}, receiveNotification(t, ch))
+		assert.Equal(t, SyncingStatus{Syncing: false}, receiveNotification(t, ch))
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		ch := make(chan types.Log, 1)
+		_, err := c.SubscribeLogs(ctx, types.LogFilterRequest{Address: "0x0000000000000000000000000000000000000001"}, ch)
+		require.NoError(t, err)
+		assert.Equal(t, hash, receiveNotification(t, ch).TxHash)
+	})
+
+	t.Run("closing the client finishes the subscriptions", func(t *testing.T) {
+		sub, err := c.SubscribeNewBatches(ctx, make(chan *types.Batch))
+		require.NoError(t, err)
+		require.NoError(t, c.Close())
+
+		select {
+		case err := <-sub.Err():
+			assert.ErrorIs(t, err, ErrClientClosed)
+		case <-time.After(time.Second):
+			require.FailNow(t, "subscription not finished")
+		}
+	})
+}
+
+func receiveNotification[T any](t *testing.T, ch chan T) T {
+	t.Helper()
+	select {
+	case notification := <-ch:
+		return notification
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "subscription notification not received")
+		var empty T
+		return empty
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SyncingStatus is the notification of the syncing subscriptions, the
+// blocks are only set while the node is syncing
+type SyncingStatus struct {
+	Syncing       bool
+	StartingBlock uint64
+	CurrentBlock  uint64
+	HighestBlock  uint64
+}
+
+// UnmarshalJSON decodes the syncing notification, which is false when the
+// node is not syncing or the syncing status otherwise
+func (s *SyncingStatus) UnmarshalJSON(data []byte) error {
+	var syncing bool
+	if err := json.Unmarshal(data, &syncing); err == nil {
+		*s = SyncingStatus{Syncing: syncing}
+		return nil
+	}
+
+	var notification struct {
+		Syncing bool `json:"syncing"`
+		Status  struct {
+			StartingBlock types.ArgUint64 `json:"startingBlock"`
+			CurrentBlock  types.ArgUint64 `json:"currentBlock"`
+			HighestBlock  types.ArgUint64 `json:"highestBlock"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(data, &notification); err != nil {
+		return err
+	}
+
+	*s = SyncingStatus{
+		Syncing:       notification.Syncing,
+		StartingBlock: uint64(notification.Status.StartingBlock),
+		CurrentBlock:  uint64(notification.Status.CurrentBlock),
+		HighestBlock:  uint64(notification.Status.HighestBlock),
+	}
+	return nil
+}
+
+// SubscribeNewHeads subscribes to the new L2 blocks
+func (c *Client) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Block) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "newHeads")
+}
+
+// SubscribeLogs subscribes to the logs of the new L2 blocks matching the provided filter
+func (c *Client) SubscribeLogs(ctx context.Context, filter types.LogFilterRequest, ch chan<- types.Log) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "logs", filter)
+}
+
+// SubscribeNewPendingTransactions subscribes to the hashes of the transactions added to the pool
+func (c *Client) SubscribeNewPendingTransactions(ctx context.Context, ch chan<- common.Hash) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "newPendingTransactions")
+}
+
+// SubscribeNewPendingFullTransactions subscribes to the transactions added to the pool
+func (c *Client) SubscribeNewPendingFullTransactions(ctx context.Context, ch chan<- *types.Transaction) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "newPendingTransactions", true)
+}
+
+// SubscribeSyncing subscribes to the changes of the syncing status of the node
+func (c *Client) SubscribeSyncing(ctx context.Context, ch chan<- SyncingStatus) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "syncing")
+}
+
+// SubscribeNewBatches subscribes to the trusted batches closed
+func (c *Client) SubscribeNewBatches(ctx context.Context, ch chan<- *types.Batch) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "zkevm_newBatches")
+}
+
+// SubscribeVirtualizedBatches subscribes to the batches sequenced on L1
+func (c *Client) SubscribeVirtualizedBatches(ctx context.Context, ch chan<- *types.Batch) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "zkevm_virtualizedBatches")
+}
+
+// SubscribeVerifiedBatches subscribes to the batches verified on L1
+func (c *Client) SubscribeVerifiedBatches(ctx context.Context, ch chan<- *types.Batch) (*Subscription, error) {
+	return subscribe(ctx, c, ch, "zkevm_verifiedBatches")
+}
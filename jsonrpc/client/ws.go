@@ -0,0 +1,328 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/gorilla/websocket"
+)
+
+// subscriptionQueueSize is the number of notifications kept for a
+// subscription that are not received yet before it fails
+const subscriptionQueueSize = 1000
+
+var (
+	// ErrSubscriptionsNotSupported is returned when subscribing with a client
+	// that doesn't have a web socket connection
+	ErrSubscriptionsNotSupported = errors.New("subscriptions require a web socket connection, use DialWS to create the client")
+
+	// ErrClientClosed is returned when the web socket connection of the client is closed
+	ErrClientClosed = errors.New("client closed")
+
+	// ErrSubscriptionQueueOverflow is sent to the error channel of a subscription
+	// when its notifications are not received fast enough
+	ErrSubscriptionQueueOverflow = errors.New("subscription queue overflow")
+)
+
+// wsMessage is a message received through the web socket connection, which
+// is either a response to a call or a subscription notification
+type wsMessage struct {
+	ID     *uint64                           `json:"id"`
+	Result json.RawMessage                   `json:"result"`
+	Error  *types.ErrorObject                `json:"error"`
+	Method string                            `json:"method"`
+	Params *types.SubscriptionResponseParams `json:"params"`
+}
+
+// pendingCall is a call waiting for its response, the subscription is
+// registered by the reader when the response of a subscribe call arrives
+// so no notification sent right after it is lost
+type pendingCall struct {
+	response     chan types.Response
+	subscription *Subscription
+}
+
+// wsConnection sends the calls and receives the subscription notifications
+// through a web socket connection
+type wsConnection struct {
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+
+	mutex         sync.Mutex
+	nextID        uint64
+	pendingCalls  map[uint64]*pendingCall
+	subscriptions map[string]*Subscription
+	err           error
+	closed        chan struct{}
+}
+
+func dialWS(ctx context.Context, url string) (*wsConnection, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &wsConnection{
+		conn:          conn,
+		pendingCalls:  make(map[uint64]*pendingCall),
+		subscriptions: make(map[string]*Subscription),
+		closed:        make(chan struct{}),
+	}
+	go c.readMessages()
+	return c, nil
+}
+
+// close closes the connection, failing the pending calls and subscriptions
+func (c *wsConnection) close() error {
+	c.fail(ErrClientClosed)
+	return c.conn.Close()
+}
+
+// call sends a request with the provided method and parameters and waits for
+// its response. If a subscription is provided, it is registered with the id
+// returned in the response.
+func (c *wsConnection) call(ctx context.Context, subscription *Subscription, method string, parameters ...interface{}) (types.Response, error) {
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return types.Response{}, err
+	}
+
+	c.mutex.Lock()
+	if c.err != nil {
+		c.mutex.Unlock()
+		return types.Response{}, c.err
+	}
+	c.nextID++
+	id := c.nextID
+	call := &pendingCall{response: make(chan types.Response, 1), subscription: subscription}
+	c.pendingCalls[id] = call
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pendingCalls, id)
+		c.mutex.Unlock()
+	}()
+
+	request := types.Request{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	}
+	c.writeMutex.Lock()
+	err = c.conn.WriteJSON(request)
+	c.writeMutex.Unlock()
+	if err != nil {
+		return types.Response{}, err
+	}
+
+	select {
+	case response := <-call.response:
+		return response, nil
+	case <-c.closed:
+		return types.Response{}, c.closedErr()
+	case <-ctx.Done():
+		return types.Response{}, ctx.Err()
+	}
+}
+
+// subscribe creates a subscription with the provided parameters
+func (c *wsConnection) subscribe(ctx context.Context, parameters ...interface{}) (*Subscription, error) {
+	subscription := &Subscription{
+		conn:          c,
+		notifications: make(chan json.RawMessage, subscriptionQueueSize),
+		err:           make(chan error, 1),
+		quit:          make(chan struct{}),
+	}
+
+	response, err := c.call(ctx, subscription, "eth_subscribe", parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	return subscription, nil
+}
+
+// readMessages dispatches the messages received until the connection fails
+func (c *wsConnection) readMessages() {
+	for {
+		var message wsMessage
+		if err := c.conn.ReadJSON(&message); err != nil {
+			c.fail(err)
+			return
+		}
+
+		if message.Method == "eth_subscription" && message.Params != nil {
+			c.dispatchNotification(*message.Params)
+		} else if message.ID != nil {
+			c.dispatchResponse(*message.ID, types.Response{
+				JSONRPC: jsonRPCVersion,
+				ID:      *message.ID,
+				Result:  message.Result,
+				Error:   message.Error,
+			})
+		}
+	}
+}
+
+func (c *wsConnection) dispatchResponse(id uint64, response types.Response) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	call, found := c.pendingCalls[id]
+	if !found {
+		return
+	}
+
+	if call.subscription != nil && response.Error == nil {
+		var subscriptionID string
+		if err := json.Unmarshal(response.Result, &subscriptionID); err != nil {
+			response.Error = &types.ErrorObject{Code: types.ParserErrorCode, Message: "invalid subscription id: " + err.Error()}
+		} else {
+			call.subscription.id = subscriptionID
+			c.subscriptions[subscriptionID] = call.subscription
+		}
+	}
+	call.response <- response
+}
+
+func (c *wsConnection) dispatchNotification(params types.SubscriptionResponseParams) {
+	c.mutex.Lock()
+	subscription, found := c.subscriptions[params.Subscription]
+	c.mutex.Unlock()
+	if !found {
+		return
+	}
+
+	select {
+	case subscription.notifications <- params.Result:
+	default:
+		subscription.close(ErrSubscriptionQueueOverflow)
+	}
+}
+
+// fail closes the connection with the provided error, which is returned by
+// the pending calls and sent to the active subscriptions
+func (c *wsConnection) fail(err error) {
+	c.mutex.Lock()
+	if c.err != nil {
+		c.mutex.Unlock()
+		return
+	}
+	c.err = err
+	close(c.closed)
+	subscriptions := make([]*Subscription, 0, len(c.subscriptions))
+	for _, subscription := range c.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	c.mutex.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.close(err)
+	}
+}
+
+func (c *wsConnection) closedErr() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+func (c *wsConnection) removeSubscription(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.subscriptions, id)
+}
+
+// Subscription is an active subscription to the notifications of a node,
+// created through a client connected with DialWS
+type Subscription struct {
+	id            string
+	conn          *wsConnection
+	notifications chan json.RawMessage
+	err           chan error
+	quit          chan struct{}
+	closeOnce     sync.Once
+}
+
+// ID returns the id of the subscription assigned by the node
+func (s *Subscription) ID() string {
+	return s.id
+}
+
+// Err returns a channel that receives the error that made the subscription
+// fail, if any, and is closed when the subscription finishes
+func (s *Subscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe stops the notifications of the subscription and uninstalls it from the node
+func (s *Subscription) Unsubscribe() {
+	if !s.close(nil) {
+		return
+	}
+	// the connection could be already closed, so the result is ignored
+	_, _ = s.conn.call(context.Background(), nil, "eth_unsubscribe", s.id)
+}
+
+// close finishes the subscription sending the provided error, if any,
+// and returns whether it was still active
+func (s *Subscription) close(err error) bool {
+	closed := false
+	s.closeOnce.Do(func() {
+		closed = true
+		s.conn.removeSubscription(s.id)
+		close(s.quit)
+		if err != nil {
+			s.err <- err
+		}
+		close(s.err)
+	})
+	return closed
+}
+
+// forwardNotifications decodes the notifications of the subscription and
+// sends them to the provided channel until it finishes
+func forwardNotifications[T any](s *Subscription, ch chan<- T) {
+	for {
+		select {
+		case data := <-s.notifications:
+			var notification T
+			if err := json.Unmarshal(data, &notification); err != nil {
+				s.close(err)
+				return
+			}
+			select {
+			case ch <- notification:
+			case <-s.quit:
+				return
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// subscribe creates a subscription with the provided parameters that sends
+// the notifications decoded as T to the provided channel
+func subscribe[T any](ctx context.Context, c *Client, ch chan<- T, parameters ...interface{}) (*Subscription, error) {
+	if c.ws == nil {
+		return nil, ErrSubscriptionsNotSupported
+	}
+
+	subscription, err := c.ws.subscribe(ctx, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	go forwardNotifications(subscription, ch)
+	return subscription, nil
+}
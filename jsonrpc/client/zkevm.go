@@ -7,12 +7,13 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // BatchNumber returns the latest batch number
 func (c *Client) BatchNumber(ctx context.Context) (uint64, error) {
-	response, err := JSONRPCCall(c.url, "zkevm_batchNumber")
+	response, err := c.call(ctx, "zkevm_batchNumber")
 	if err != nil {
 		return 0, err
 	}
@@ -40,7 +41,7 @@ func (c *Client) BatchByNumber(ctx context.Context, number *big.Int) (*types.Bat
 	if number != nil {
 		bn = types.BatchNumber(number.Int64())
 	}
-	response, err := JSONRPCCall(c.url, "zkevm_getBatchByNumber", bn.StringOrHex(), true)
+	response, err := c.call(ctx, "zkevm_getBatchByNumber", bn.StringOrHex(), true)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +61,7 @@ func (c *Client) BatchByNumber(ctx context.Context, number *big.Int) (*types.Bat
 
 // BatchesByNumbers returns batches from the current canonical chain by batch numbers. If the list is empty, the last
 // known batch is returned as a list.
-func (c *Client) BatchesByNumbers(_ context.Context, numbers []*big.Int) ([]*types.BatchData, error) {
+func (c *Client) BatchesByNumbers(ctx context.Context, numbers []*big.Int) ([]*types.BatchData, error) {
 	var list []types.BatchNumber
 	for _, n := range numbers {
 		list = append(list, types.BatchNumber(n.Int64()))
@@ -74,7 +75,7 @@ func (c *Client) BatchesByNumbers(_ context.Context, numbers []*big.Int) ([]*typ
 		batchNumbers = append(batchNumbers, n.StringOrHex())
 	}
 
-	response, err := JSONRPCCall(c.url, "zkevm_getBatchDataByNumbers", batchNumbers, true)
+	response, err := c.call(ctx, "zkevm_getBatchDataByNumbers", batchNumbers, true)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +95,7 @@ func (c *Client) BatchesByNumbers(_ context.Context, numbers []*big.Int) ([]*typ
 
 // ExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
 func (c *Client) ExitRootsByGER(ctx context.Context, globalExitRoot common.Hash) (*types.ExitRoots, error) {
-	response, err := JSONRPCCall(c.url, "zkevm_getExitRootsByGER", globalExitRoot.String())
+	response, err := c.call(ctx, "zkevm_getExitRootsByGER", globalExitRoot.String())
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +115,7 @@ func (c *Client) ExitRootsByGER(ctx context.Context, globalExitRoot common.Hash)
 
 // GetLatestGlobalExitRoot returns the latest global exit root
 func (c *Client) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error) {
-	response, err := JSONRPCCall(c.url, "zkevm_getLatestGlobalExitRoot")
+	response, err := c.call(ctx, "zkevm_getLatestGlobalExitRoot")
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -131,3 +132,194 @@ func (c *Client) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, erro
 
 	return common.HexToHash(result), nil
 }
+
+// GetProof returns the merkle tree proofs of the balance, nonce, code hash and
+// the provided storage keys of an account at the given block. If number is nil,
+// the latest known block is used.
+func (c *Client) GetProof(ctx context.Context, address common.Address, storageKeys []common.Hash, number *big.Int) (*types.AccountProof, error) {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+	keys := make([]string, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		keys = append(keys, key.String())
+	}
+	response, err := c.call(ctx, "zkevm_getProof", address.String(), keys, bn.StringOrHex())
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	var result *types.AccountProof
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ConsolidatedBlockNumber returns the number of the last L2 block of the last verified batch
+func (c *Client) ConsolidatedBlockNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	if err := c.callResult(ctx, &result, "zkevm_consolidatedBlockNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// IsBlockConsolidated returns whether the L2 block is in a verified batch
+func (c *Client) IsBlockConsolidated(ctx context.Context, blockNumber uint64) (bool, error) {
+	var result bool
+	err := c.callResult(ctx, &result, "zkevm_isBlockConsolidated", types.ArgUint64(blockNumber))
+	return result, err
+}
+
+// IsBlockVirtualized returns whether the L2 block is in a virtual batch
+func (c *Client) IsBlockVirtualized(ctx context.Context, blockNumber uint64) (bool, error) {
+	var result bool
+	err := c.callResult(ctx, &result, "zkevm_isBlockVirtualized", types.ArgUint64(blockNumber))
+	return result, err
+}
+
+// BatchNumberByBlockNumber returns the number of the batch of the L2 block,
+// or ethereum.NotFound if the block doesn't exist
+func (c *Client) BatchNumberByBlockNumber(ctx context.Context, blockNumber uint64) (uint64, error) {
+	var result *types.ArgUint64
+	if err := c.callResult(ctx, &result, "zkevm_batchNumberByBlockNumber", types.ArgUint64(blockNumber)); err != nil {
+		return 0, err
+	}
+	if result == nil {
+		return 0, ethereum.NotFound
+	}
+	return uint64(*result), nil
+}
+
+// VirtualBatchNumber returns the latest virtual batch number
+func (c *Client) VirtualBatchNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	if err := c.callResult(ctx, &result, "zkevm_virtualBatchNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// VerifiedBatchNumber returns the latest verified batch number
+func (c *Client) VerifiedBatchNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	if err := c.callResult(ctx, &result, "zkevm_verifiedBatchNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// FullBlockByNumber returns a block with the L2 extra information of its
+// transactions. If number is nil, the latest known block is returned.
+func (c *Client) FullBlockByNumber(ctx context.Context, number *big.Int, fullTx bool) (*types.Block, error) {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+
+	var result *types.Block
+	err := c.callResult(ctx, &result, "zkevm_getFullBlockByNumber", bn.StringOrHex(), fullTx)
+	return result, err
+}
+
+// FullBlockByHash returns a block with the L2 extra information of its transactions
+func (c *Client) FullBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (*types.Block, error) {
+	var result *types.Block
+	err := c.callResult(ctx, &result, "zkevm_getFullBlockByHash", hash.String(), fullTx)
+	return result, err
+}
+
+// NativeBlockHashesInRange returns the native block hashes, which are the
+// state roots, of the L2 blocks in the provided range
+func (c *Client) NativeBlockHashesInRange(ctx context.Context, fromBlock, toBlock uint64) ([]common.Hash, error) {
+	filter := map[string]interface{}{
+		"fromBlock": hex.EncodeUint64(fromBlock),
+		"toBlock":   hex.EncodeUint64(toBlock),
+	}
+
+	var result []common.Hash
+	err := c.callResult(ctx, &result, "zkevm_getNativeBlockHashesInRange", filter)
+	return result, err
+}
+
+// TransactionByL2Hash returns a transaction by its L2 hash
+func (c *Client) TransactionByL2Hash(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	var result *types.Transaction
+	err := c.callResult(ctx, &result, "zkevm_getTransactionByL2Hash", hash.String())
+	return result, err
+}
+
+// TransactionReceiptByL2Hash returns the receipt of a transaction by its L2 hash
+func (c *Client) TransactionReceiptByL2Hash(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	var result *types.Receipt
+	err := c.callResult(ctx, &result, "zkevm_getTransactionReceiptByL2Hash", hash.String())
+	return result, err
+}
+
+// TransactionStatus returns the status of a transaction in its lifecycle,
+// nil is returned if the transaction is unknown to the node
+func (c *Client) TransactionStatus(ctx context.Context, hash common.Hash) (*types.TransactionStatus, error) {
+	var result *types.TransactionStatus
+	err := c.callResult(ctx, &result, "zkevm_getTransactionStatus", hash.String())
+	return result, err
+}
+
+// EstimateGasPrice returns the gas price the transaction needs to be accepted
+// by the pool at the given block. If number is nil, the latest known block is used.
+func (c *Client) EstimateGasPrice(ctx context.Context, tx types.TxArgs, number *big.Int) (*big.Int, error) {
+	return c.estimate(ctx, "zkevm_estimateGasPrice", tx, number)
+}
+
+// EstimateFee returns the fee the transaction needs to pay to be accepted
+// by the pool at the given block. If number is nil, the latest known block is used.
+func (c *Client) EstimateFee(ctx context.Context, tx types.TxArgs, number *big.Int) (*big.Int, error) {
+	return c.estimate(ctx, "zkevm_estimateFee", tx, number)
+}
+
+func (c *Client) estimate(ctx context.Context, method string, tx types.TxArgs, number *big.Int) (*big.Int, error) {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+
+	var result types.ArgBig
+	if err := c.callResult(ctx, &result, method, tx, bn.StringOrHex()); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// EstimateCounters returns the zk counters used by the transaction at the
+// given block and their limits. If number is nil, the latest known block is used.
+func (c *Client) EstimateCounters(ctx context.Context, tx types.TxArgs, number *big.Int) (*types.ZKCountersResponse, error) {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+
+	var result *types.ZKCountersResponse
+	err := c.callResult(ctx, &result, "zkevm_estimateCounters", tx, bn.StringOrHex())
+	return result, err
+}
+
+// EstimateBundleCounters returns the zk counters used by the bundle of
+// transactions executed in order at the given block and their limits.
+// If number is nil, the latest known block is used.
+func (c *Client) EstimateBundleCounters(ctx context.Context, txs []types.TxArgs, number *big.Int) (*types.ZKCountersBundleResponse, error) {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+
+	var result *types.ZKCountersBundleResponse
+	err := c.callResult(ctx, &result, "zkevm_estimateBundleCounters", txs, bn.StringOrHex())
+	return result, err
+}
@@ -59,14 +59,168 @@ type Config struct {
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64 `mapstructure:"MaxNativeBlockHashBlockRange"`
 
+	// MaxTxPoolContentAccounts is a configuration to set the max number of accounts whose txs
+	// are returned in a single call to txpool_content or txpool_inspect, if zero it means no limit
+	MaxTxPoolContentAccounts uint64 `mapstructure:"MaxTxPoolContentAccounts"`
+
+	// MaxFeeHistoryBlockCount is a configuration to set the max number of blocks that can be
+	// requested in a single call to eth_feeHistory, if zero it means no limit
+	MaxFeeHistoryBlockCount uint64 `mapstructure:"MaxFeeHistoryBlockCount"`
+
+	// MaxFeeHistoryRewardBlockCount is a configuration to set the max number of blocks that can be
+	// requested in a single call to eth_feeHistory with reward percentiles, if zero it means no limit
+	MaxFeeHistoryRewardBlockCount uint64 `mapstructure:"MaxFeeHistoryRewardBlockCount"`
+
 	// EnableHttpLog allows the user to enable or disable the logs related to the HTTP
 	// requests to be captured by the server.
 	EnableHttpLog bool `mapstructure:"EnableHttpLog"`
 
+	// FilterStorage defines where the filters created via HTTP are persisted
+	FilterStorage FilterStorageConfig `mapstructure:"FilterStorage"`
+
+	// RateLimit configures the per method cost and per API key quota rate limiting
+	RateLimit RateLimitConfig `mapstructure:"RateLimit"`
+
+	// Auth configures the credentials required to access the protected namespaces
+	Auth AuthConfig `mapstructure:"Auth"`
+
+	// ResponseCache configures the cache of the responses that refer to virtualized L2 blocks
+	ResponseCache ResponseCacheConfig `mapstructure:"ResponseCache"`
+
+	// GraphQL configuration
+	GraphQL GraphQLConfig `mapstructure:"GraphQL"`
+
 	// ZKCountersLimits defines the ZK Counter limits
 	ZKCountersLimits ZKCountersLimits
 }
 
+// FilterStorageConfig has parameters to config the filter storage
+type FilterStorageConfig struct {
+	// Type defines the filter storage: "memory" keeps the filters in the instance that created them,
+	// "postgres" persists them in the pool database so they are shared by all the instances behind a load balancer
+	Type string `mapstructure:"Type"`
+
+	// FilterTimeout is the time after which a filter that is not polled is removed, if zero filters never expire.
+	// Only used by the "postgres" storage
+	FilterTimeout types.Duration `mapstructure:"FilterTimeout"`
+}
+
+// RateLimitConfig has parameters to config the per method and per API key rate limiting.
+// Each request consumes from the quota of its client as many units as the cost of its method,
+// clients are identified by the API key sent in the X-API-Key header or apikey query param,
+// or by IP when no known API key is provided
+type RateLimitConfig struct {
+	// Enabled defines if the rate limiting is enabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// DefaultMethodCost is the cost of the methods not listed in MethodCosts
+	DefaultMethodCost uint64 `mapstructure:"DefaultMethodCost"`
+
+	// MethodCosts maps a method name to its cost, method names are case insensitive
+	MethodCosts map[string]uint64 `mapstructure:"MethodCosts"`
+
+	// Tiers maps a tier name to its quota, tier names are case insensitive
+	Tiers map[string]RateLimitTierConfig `mapstructure:"Tiers"`
+
+	// DefaultTier is the tier applied by IP to the requests without a known API key
+	DefaultTier string `mapstructure:"DefaultTier"`
+
+	// APIKeys lists the API keys allowed and the tier of each one
+	APIKeys []RateLimitAPIKeyConfig `mapstructure:"APIKeys"`
+}
+
+// RateLimitTierConfig defines the quota of a rate limit tier
+type RateLimitTierConfig struct {
+	// CostPerSecond is the cost units the quota recovers every second
+	CostPerSecond float64 `mapstructure:"CostPerSecond"`
+
+	// Burst is the max cost units that can be consumed at once, it must be greater than
+	// the cost of any method, otherwise that method is always rejected
+	Burst uint64 `mapstructure:"Burst"`
+}
+
+// RateLimitAPIKeyConfig binds an API key to a rate limit tier
+type RateLimitAPIKeyConfig struct {
+	// Key is the API key
+	Key string `mapstructure:"Key"`
+
+	// Tier is the name of the tier applied to the requests with this API key
+	Tier string `mapstructure:"Tier"`
+}
+
+// AuthConfig has parameters to config the access control of the namespaces.
+// The requests to a protected namespace must provide a JWT signed with HS256
+// in the Authorization header as a bearer token, the same as the engine API,
+// or a known API key in the X-API-Key header or apikey query param. Web socket
+// connections are authenticated once, when the connection is established
+type AuthConfig struct {
+	// Enabled defines if the access control is enabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// ProtectedAPIs lists the namespaces that require credentials, the rest are public
+	ProtectedAPIs []string `mapstructure:"ProtectedAPIs"`
+
+	// JWTSecretFile is the path to the file containing the hex encoded 32 bytes secret used
+	// to verify the JWTs, a valid JWT gives access to all the protected namespaces.
+	// If empty, JWTs are not accepted
+	JWTSecretFile string `mapstructure:"JWTSecretFile"`
+
+	// APIKeys lists the API keys allowed and the protected namespaces each one can access
+	APIKeys []AuthAPIKeyConfig `mapstructure:"APIKeys"`
+}
+
+// AuthAPIKeyConfig defines an API key and the protected namespaces it can access
+type AuthAPIKeyConfig struct {
+	// Key is the value of the API key
+	Key string `mapstructure:"Key"`
+
+	// APIs lists the protected namespaces the API key can access, if empty it can access all of them
+	APIs []string `mapstructure:"APIs"`
+}
+
+// ResponseCacheConfig has parameters to config the cache of the responses of
+// eth_getBlockByHash, eth_getBlockByNumber, eth_getTransactionByHash,
+// eth_getTransactionReceipt and debug_traceTransaction. Only the responses that
+// refer to virtualized L2 blocks are cached, since they can only change on a reorg
+type ResponseCacheConfig struct {
+	// Enabled defines if the response cache is enabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// MaxEntries is the max number of responses kept, the least recently used are evicted first
+	MaxEntries int `mapstructure:"MaxEntries"`
+
+	// VirtualizedBlockCheckInterval is the min time between the checks of the state reset counter
+	// and the last virtualized L2 block, the cache is purged when the counter changes or the block moves back
+	VirtualizedBlockCheckInterval types.Duration `mapstructure:"VirtualizedBlockCheckInterval"`
+}
+
+// GraphQLConfig has parameters to config the GraphQL server, which serves the
+// EIP-1767 schema extended with the zkEVM batches, exit roots and ZK counters
+type GraphQLConfig struct {
+	// Enabled defines if the GraphQL server is enabled or disabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the GraphQL queries
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the GraphQL queries
+	Port int `mapstructure:"Port"`
+
+	// MaxBlockRange is the max number of blocks that can be requested in a
+	// single blocks query, if zero it means no limit
+	MaxBlockRange uint64 `mapstructure:"MaxBlockRange"`
+
+	// MaxDepth is the max nesting depth of the fields of a query, if zero it means no limit
+	MaxDepth int `mapstructure:"MaxDepth"`
+
+	// MaxParallelism is the max number of resolvers of a query running in parallel
+	MaxParallelism int `mapstructure:"MaxParallelism"`
+
+	// MaxComplexity is the max number of non trivial fields, the ones loading data,
+	// resolved by a query, if zero it means no limit
+	MaxComplexity uint64 `mapstructure:"MaxComplexity"`
+}
+
 // ZKCountersLimits defines the ZK Counter limits
 type ZKCountersLimits struct {
 	MaxKeccakHashes     uint32
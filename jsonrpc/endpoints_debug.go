@@ -52,6 +52,12 @@ type traceConfig struct {
 	TracerConfig     json.RawMessage `json:"tracerConfig"`
 }
 
+type traceCallConfig struct {
+	traceConfig
+	StateOverrides *types.StateOverride  `json:"stateOverrides"`
+	BlockOverrides *types.BlockOverrides `json:"blockOverrides"`
+}
+
 type traceBlockTransactionResponse struct {
 	Result interface{} `json:"result"`
 }
@@ -69,6 +75,57 @@ func (d *DebugEndpoints) TraceTransaction(hash types.ArgHash, cfg *traceConfig)
 	})
 }
 
+// TraceCall creates a response for debug_traceCall request, executing the
+// call on top of the given block after applying the provided overrides.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtracecall
+func (d *DebugEndpoints) TraceCall(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, cfg *traceCallConfig) (interface{}, types.Error) {
+	return d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if arg == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+
+		traceCfg := &traceCallConfig{traceConfig: *defaultTraceConfig}
+		if cfg != nil {
+			traceCfg = cfg
+		}
+
+		stateOverride, err := traceCfg.StateOverrides.ToStateOverride()
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
+		blockOverride, err := traceCfg.BlockOverrides.ToBlockOverride()
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
+
+		block, respErr := d.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+			gas := types.ArgUint64(block.GasLimit())
+			arg.Gas = &gas
+		}
+
+		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+		sender, tx, err := arg.ToTransaction(ctx, d.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+		}
+
+		blockNumber := block.NumberU64()
+		result, err := d.state.DebugUnsignedTransaction(ctx, tx, sender, &blockNumber, traceCfg.toStateTraceConfig(), stateOverride, blockOverride, dbTx)
+		if err != nil {
+			errorMessage := fmt.Sprintf("failed to get trace: %v", err.Error())
+			return nil, types.NewRPCError(types.DefaultErrorCode, errorMessage)
+		}
+
+		return result.TraceResult, nil
+	})
+}
+
 // TraceBlockByNumber creates a response for debug_traceBlockByNumber request.
 // See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtraceblockbynumber
 func (d *DebugEndpoints) TraceBlockByNumber(number types.BlockNumber, cfg *traceConfig) (interface{}, types.Error) {
@@ -276,15 +333,7 @@ func (d *DebugEndpoints) buildTraceTransaction(ctx context.Context, hash common.
 		traceCfg = defaultTraceConfig
 	}
 
-	stateTraceConfig := state.TraceConfig{
-		DisableStack:     traceCfg.DisableStack,
-		DisableStorage:   traceCfg.DisableStorage,
-		EnableMemory:     traceCfg.EnableMemory,
-		EnableReturnData: traceCfg.EnableReturnData,
-		Tracer:           traceCfg.Tracer,
-		TracerConfig:     traceCfg.TracerConfig,
-	}
-	result, err := d.state.DebugTransaction(ctx, hash, stateTraceConfig, dbTx)
+	result, err := d.state.DebugTransaction(ctx, hash, traceCfg.toStateTraceConfig(), dbTx)
 	if errors.Is(err, state.ErrNotFound) {
 		return RPCErrorResponse(types.DefaultErrorCode, "transaction not found", nil, false)
 	} else if err != nil {
@@ -295,6 +344,54 @@ func (d *DebugEndpoints) buildTraceTransaction(ctx context.Context, hash common.
 	return result.TraceResult, nil
 }
 
+func (d *DebugEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
+	// If no block argument is provided, return the latest block
+	if blockArg == nil {
+		block, err := d.state.GetLastL2Block(ctx, dbTx)
+		if err != nil {
+			return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state")
+		}
+		return block, nil
+	}
+
+	// If we have a block hash, try to get the block by hash
+	if blockArg.IsHash() {
+		block, err := d.state.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, "header for hash not found")
+		} else if err != nil {
+			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get block by hash %v", blockArg.Hash().Hash()))
+		}
+		return block, nil
+	}
+
+	// Otherwise, try to get the block by number
+	blockNum, rpcErr := blockArg.Number().GetNumericBlockNumber(ctx, d.state, d.etherman, dbTx)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	block, err := d.state.GetL2BlockByNumber(ctx, blockNum, dbTx)
+	if errors.Is(err, state.ErrNotFound) || block == nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "header not found")
+	} else if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get block by number %v", blockNum))
+	}
+
+	return block, nil
+}
+
+// toStateTraceConfig converts the trace config to the format expected by the state
+func (cfg *traceConfig) toStateTraceConfig() state.TraceConfig {
+	return state.TraceConfig{
+		DisableStack:     cfg.DisableStack,
+		DisableStorage:   cfg.DisableStorage,
+		EnableMemory:     cfg.EnableMemory,
+		EnableReturnData: cfg.EnableReturnData,
+		Tracer:           cfg.Tracer,
+		TracerConfig:     cfg.TracerConfig,
+	}
+}
+
 // waitTimeout waits for the waitGroup for the specified max timeout.
 // Returns true if waiting timed out.
 func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
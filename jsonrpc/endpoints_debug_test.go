@@ -0,0 +1,181 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCall(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	overriddenAddress := common.HexToAddress("0x3")
+	coinbase := common.HexToAddress("0x4")
+	slot := common.HexToHash("0x5")
+	slotValue := common.HexToHash("0x6")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot, GasLimit: 30000000}))
+	traceResult := json.RawMessage(`{"type":"CALL","from":"0x0000000000000000000000000000000000000001","gasUsed":"0x5208"}`)
+	txArgs := map[string]interface{}{
+		"from": from.String(),
+		"to":   to.String(),
+		"data": "0x01",
+	}
+	blockArg := map[string]interface{}{
+		types.BlockNumberKey: hex.EncodeBig(blockNumOne),
+	}
+
+	type testCase struct {
+		Name           string
+		Params         []interface{}
+		ExpectedResult json.RawMessage
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name: "trace call with call tracer and overrides",
+			Params: []interface{}{txArgs, blockArg, map[string]interface{}{
+				"tracer": "callTracer",
+				"stateOverrides": map[string]interface{}{
+					overriddenAddress.String(): map[string]interface{}{
+						"balance": "0x64",
+						"nonce":   "0x2",
+						"code":    "0x6001",
+						"stateDiff": map[string]interface{}{
+							slot.String(): slotValue.String(),
+						},
+					},
+				},
+				"blockOverrides": map[string]interface{}{
+					"time":     "0x65",
+					"coinbase": coinbase.String(),
+				},
+			}},
+			ExpectedResult: traceResult,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(7), nil).Once()
+
+				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+					return tx != nil && *tx.To() == to && tx.Gas() == block.GasLimit() && tx.Nonce() == 7 && hex.EncodeToHex(tx.Data()) == "0x01"
+				})
+				traceConfigMatchBy := mock.MatchedBy(func(cfg state.TraceConfig) bool {
+					return cfg.IsCallTracer()
+				})
+				stateOverrideMatchBy := mock.MatchedBy(func(so state.StateOverride) bool {
+					account, found := so[overriddenAddress]
+					return found && len(so) == 1 &&
+						account.Balance.Cmp(big.NewInt(100)) == 0 &&
+						*account.Nonce == 2 &&
+						hex.EncodeToHex(*account.Code) == "0x6001" &&
+						account.State == nil &&
+						account.StateDiff[slot] == slotValue
+				})
+				blockOverrideMatchBy := mock.MatchedBy(func(bo *state.BlockOverride) bool {
+					return bo != nil && *bo.Time == 101 && *bo.Coinbase == coinbase
+				})
+				m.State.
+					On("DebugUnsignedTransaction", context.Background(), txMatchBy, from, &blockNumOneUint64, traceConfigMatchBy, stateOverrideMatchBy, blockOverrideMatchBy, m.DbTx).
+					Return(&runtime.ExecutionResult{TraceResult: traceResult}, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "trace call without trace config",
+			Params:         []interface{}{txArgs, blockArg},
+			ExpectedResult: traceResult,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(7), nil).Once()
+				traceConfigMatchBy := mock.MatchedBy(func(cfg state.TraceConfig) bool {
+					return cfg.IsDefaultTracer()
+				})
+				m.State.
+					On("DebugUnsignedTransaction", context.Background(), mock.Anything, from, &blockNumOneUint64, traceConfigMatchBy, state.StateOverride(nil), (*state.BlockOverride)(nil), m.DbTx).
+					Return(&runtime.ExecutionResult{TraceResult: traceResult}, nil).
+					Once()
+			},
+		},
+		{
+			Name: "state and state diff overridden for the same account",
+			Params: []interface{}{txArgs, blockArg, map[string]interface{}{
+				"stateOverrides": map[string]interface{}{
+					overriddenAddress.String(): map[string]interface{}{
+						"state":     map[string]interface{}{slot.String(): slotValue.String()},
+						"stateDiff": map[string]interface{}{slot.String(): slotValue.String()},
+					},
+				},
+			}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "account 0x0000000000000000000000000000000000000003 has both 'state' and 'stateDiff'"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			Name: "unsupported block override",
+			Params: []interface{}{txArgs, blockArg, map[string]interface{}{
+				"blockOverrides": map[string]interface{}{"baseFee": "0x1"},
+			}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "block override of 'baseFee' is not supported"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			Name:          "failed to trace the call",
+			Params:        []interface{}{txArgs, blockArg},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get trace: failed to process the call"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(7), nil).Once()
+				m.State.
+					On("DebugUnsignedTransaction", context.Background(), mock.Anything, from, &blockNumOneUint64, mock.Anything, state.StateOverride(nil), (*state.BlockOverride)(nil), m.DbTx).
+					Return(nil, errors.New("failed to process the call")).
+					Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("debug_traceCall", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+			assert.JSONEq(t, string(tc.ExpectedResult), string(res.Result))
+		})
+	}
+}
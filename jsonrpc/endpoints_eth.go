@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/gasprice"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
@@ -27,6 +28,14 @@ import (
 const (
 	// maxTopics is the max number of topics a log can have
 	maxTopics = 4
+
+	// maxFeeHistoryRewardPercentiles is the max number of reward percentiles
+	// that can be requested in a single call to eth_feeHistory
+	maxFeeHistoryRewardPercentiles = 100
+
+	// accessListTracer is the native tracer used to build the access list of
+	// eth_createAccessList
+	accessListTracer = "accessListTracer"
 )
 
 // EthEndpoints contains implementations for the "eth" RPC endpoints
@@ -44,6 +53,9 @@ type EthEndpoints struct {
 func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.StateInterface, etherman types.EthermanInterface, storage storageInterface) *EthEndpoints {
 	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, storage: storage}
 	s.RegisterNewL2BlockEventHandler(e.onNewL2Block)
+	s.RegisterNewBatchEventHandler(e.onNewBatch)
+	s.RegisterSyncingStatusEventHandler(e.onSyncingStatus)
+	p.RegisterNewPendingTxEventHandler(e.onNewPendingTx)
 
 	return e
 }
@@ -64,13 +76,17 @@ func (e *EthEndpoints) BlockNumber() (interface{}, types.Error) {
 // executed contract and potential error.
 // Note, this function doesn't make any changes in the state/blockchain and is
 // useful to execute view/pure methods and retrieve values.
-func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverrideArg *types.StateOverride) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
 		} else if blockArg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 1", nil, false)
 		}
+		stateOverride, err := stateOverrideArg.ToStateOverride()
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
 		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
 		if respErr != nil {
 			return nil, respErr
@@ -103,7 +119,7 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
-		result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, dbTx)
+		result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, stateOverride, dbTx)
 		if err != nil {
 			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
 			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !(errors.Is(err, runtime.ErrOutOfGas))
@@ -153,17 +169,77 @@ func (e *EthEndpoints) getCoinbaseFromSequencerNode() (interface{}, types.Error)
 	return coinbaseAddress.String(), nil
 }
 
+// CreateAccessList executes the transaction and returns the addresses and
+// storage slots it touches as an EIP-2930 access list, along with the gas used.
+// The transaction will not be added to the blockchain.
+// Since the pool only accepts legacy transactions, the access list is advisory
+// and the gas used is the one of the execution without the access list.
+func (e *EthEndpoints) CreateAccessList(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if arg == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+
+		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+			gas := types.ArgUint64(block.GasLimit())
+			arg.Gas = &gas
+		}
+
+		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+		sender, tx, err := arg.ToTransaction(ctx, e.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+		}
+
+		tracer := accessListTracer
+		traceConfig := state.TraceConfig{Tracer: &tracer}
+		blockNumber := block.NumberU64()
+		result, err := e.state.DebugUnsignedTransaction(ctx, tx, sender, &blockNumber, traceConfig, nil, nil, dbTx)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
+			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !(errors.Is(err, runtime.ErrOutOfGas))
+			return RPCErrorResponse(types.DefaultErrorCode, errMsg, nil, logError)
+		}
+
+		var accessList ethTypes.AccessList
+		if err := json.Unmarshal(result.TraceResult, &accessList); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to read the access list", err, true)
+		}
+
+		res := types.AccessListResult{
+			AccessList: accessList,
+			GasUsed:    types.ArgUint64(result.GasUsed),
+			Advisory:   true,
+			Note:       types.AccessListAdvisoryNote,
+		}
+		if result.Failed() {
+			res.Error = result.Err.Error()
+		}
+		return res, nil
+	})
+}
+
 // EstimateGas generates and returns an estimate of how much gas is necessary to
 // allow the transaction to complete.
 // The transaction will not be added to the blockchain.
 // Note that the estimate may be significantly more than the amount of gas actually
 // used by the transaction, for a variety of reasons including EVM mechanics and
 // node performance.
-func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverrideArg *types.StateOverride) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
 		}
+		stateOverride, err := stateOverrideArg.ToStateOverride()
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
 
 		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
 		if respErr != nil {
@@ -187,7 +263,7 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
-		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, dbTx)
+		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, stateOverride, dbTx)
 		if errors.Is(err, runtime.ErrExecutionReverted) {
 			data := make([]byte, len(returnValue))
 			copy(data, returnValue)
@@ -199,6 +275,143 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 	})
 }
 
+// FeeHistory returns the base fee per gas, the gas used ratio and the rewards
+// at the requested percentiles of the gas used for a range of blocks ending at
+// the newest block provided. L2 blocks have no base fee, so the whole effective
+// gas price paid by each tx is considered its reward.
+// See https://ethereum.github.io/execution-apis/api-documentation/
+func (e *EthEndpoints) FeeHistory(blockCount types.ArgUint64, newestBlock types.BlockNumber, rewardPercentiles *[]float64) (interface{}, types.Error) {
+	var percentiles []float64
+	if rewardPercentiles != nil {
+		percentiles = *rewardPercentiles
+	}
+	if len(percentiles) > maxFeeHistoryRewardPercentiles {
+		errMsg := fmt.Sprintf("reward percentiles are limited to %v values", maxFeeHistoryRewardPercentiles)
+		return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid reward percentile: %v", p), nil, false)
+		}
+		if i > 0 && p < percentiles[i-1] {
+			errMsg := fmt.Sprintf("invalid reward percentile: #%d:%v > #%d:%v", i-1, percentiles[i-1], i, p)
+			return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		}
+	}
+
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if blockCount == 0 {
+			return types.FeeHistory{BaseFeePerGas: []types.ArgBig{}, GasUsedRatio: []float64{}}, nil
+		}
+
+		lastBlockNumber, err := e.state.GetLastL2BlockNumber(ctx, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the last block number from state", err, true)
+		}
+		newestBlockNumber, rpcErr := newestBlock.GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		if newestBlockNumber > lastBlockNumber {
+			errMsg := fmt.Sprintf("request beyond head block: requested %v, head %v", newestBlockNumber, lastBlockNumber)
+			return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		}
+
+		count := uint64(blockCount)
+		if e.cfg.MaxFeeHistoryBlockCount > 0 && count > e.cfg.MaxFeeHistoryBlockCount {
+			count = e.cfg.MaxFeeHistoryBlockCount
+		}
+		// the rewards require loading the receipts of all the txs of the blocks
+		if len(percentiles) > 0 && e.cfg.MaxFeeHistoryRewardBlockCount > 0 && count > e.cfg.MaxFeeHistoryRewardBlockCount {
+			count = e.cfg.MaxFeeHistoryRewardBlockCount
+		}
+		if count > newestBlockNumber+1 {
+			count = newestBlockNumber + 1
+		}
+		oldestBlockNumber := newestBlockNumber + 1 - count
+
+		feeHistory := types.FeeHistory{
+			OldestBlock:   types.ArgUint64(oldestBlockNumber),
+			BaseFeePerGas: make([]types.ArgBig, 0, count+1),
+			GasUsedRatio:  make([]float64, 0, count),
+		}
+		if len(percentiles) > 0 {
+			feeHistory.Reward = make([][]types.ArgBig, 0, count)
+		}
+
+		nextBaseFee := big.NewInt(0)
+		for blockNumber := oldestBlockNumber; blockNumber <= newestBlockNumber; blockNumber++ {
+			l2Block, err := e.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+			}
+
+			baseFee := big.NewInt(0)
+			if l2Block.BaseFee() != nil {
+				baseFee = l2Block.BaseFee()
+			}
+			nextBaseFee = baseFee
+			feeHistory.BaseFeePerGas = append(feeHistory.BaseFeePerGas, types.ArgBig(*baseFee))
+
+			gasUsedRatio := float64(0)
+			if l2Block.GasLimit() > 0 {
+				gasUsedRatio = float64(l2Block.GasUsed()) / float64(l2Block.GasLimit())
+			}
+			feeHistory.GasUsedRatio = append(feeHistory.GasUsedRatio, gasUsedRatio)
+
+			if len(percentiles) == 0 {
+				continue
+			}
+
+			txs := l2Block.Transactions()
+			txsGasAndReward := make([]gasprice.TxGasAndReward, 0, len(txs))
+			if len(txs) == 0 {
+				feeHistory.Reward = append(feeHistory.Reward, blockRewards(gasprice.RewardPercentiles(txsGasAndReward, percentiles)))
+				continue
+			}
+			receipts, _, err := e.state.GetTransactionReceiptsByBlockNumber(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for block %v", blockNumber), err, true)
+			}
+			receiptsByTxHash := make(map[common.Hash]*ethTypes.Receipt, len(receipts))
+			for _, receipt := range receipts {
+				receiptsByTxHash[receipt.TxHash] = receipt
+			}
+			for _, tx := range txs {
+				receipt, found := receiptsByTxHash[tx.Hash()]
+				if !found {
+					return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipt for tx %v", tx.Hash().String()), nil, false)
+				}
+				effectiveGasPrice := tx.GasPrice()
+				if receipt.EffectiveGasPrice != nil {
+					effectiveGasPrice = receipt.EffectiveGasPrice
+				}
+				reward := new(big.Int).Sub(effectiveGasPrice, baseFee)
+				if reward.Sign() < 0 {
+					reward = big.NewInt(0)
+				}
+				txsGasAndReward = append(txsGasAndReward, gasprice.TxGasAndReward{GasUsed: receipt.GasUsed, Reward: reward})
+			}
+
+			feeHistory.Reward = append(feeHistory.Reward, blockRewards(gasprice.RewardPercentiles(txsGasAndReward, percentiles)))
+		}
+		// L2 blocks have no base fee adjustment, so the next block keeps
+		// the base fee of the newest one
+		feeHistory.BaseFeePerGas = append(feeHistory.BaseFeePerGas, types.ArgBig(*nextBaseFee))
+
+		return feeHistory, nil
+	})
+}
+
+// blockRewards converts the rewards of a block to their JSON RPC type
+func blockRewards(rewards []*big.Int) []types.ArgBig {
+	result := make([]types.ArgBig, 0, len(rewards))
+	for _, reward := range rewards {
+		result = append(result, types.ArgBig(*reward))
+	}
+	return result
+}
+
 // GasPrice returns the average gas price based on the last x blocks
 func (e *EthEndpoints) GasPrice() (interface{}, types.Error) {
 	ctx := context.Background()
@@ -871,6 +1084,92 @@ func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, t
 	})
 }
 
+// GetBlockReceipts returns the receipts of all the transactions of the block
+// identified by the provided number or hash, loading them in a single query
+func (e *EthEndpoints) GetBlockReceipts(blockArg types.BlockNumberOrHash, includeExtraInfo *bool) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		var l2Block *state.L2Block
+		var err error
+		if blockArg.IsHash() {
+			l2Block, err = e.state.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
+		} else {
+			blockNumber, rpcErr := blockArg.Number().GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+			if rpcErr != nil {
+				return nil, rpcErr
+			}
+			l2Block, err = e.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+		}
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load block from state", err, true)
+		}
+
+		receipts, l2Hashes, err := e.state.GetTransactionReceiptsByBlockNumber(ctx, l2Block.NumberU64(), dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for block %v", l2Block.NumberU64()), err, true)
+		}
+
+		txs := make(map[common.Hash]*ethTypes.Transaction, len(l2Block.Transactions()))
+		for _, tx := range l2Block.Transactions() {
+			txs[tx.Hash()] = tx
+		}
+
+		res := make([]types.Receipt, 0, len(receipts))
+		for i, r := range receipts {
+			tx, found := txs[r.TxHash]
+			if !found {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't find tx %v in block %v", r.TxHash.String(), l2Block.NumberU64()), nil, true)
+			}
+
+			var l2Hash *common.Hash
+			if includeExtraInfo != nil && *includeExtraInfo {
+				l2Hash = l2Hashes[i]
+			}
+
+			receipt, err := types.NewReceipt(*tx, r, l2Hash)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+			}
+			res = append(res, receipt)
+		}
+
+		return res, nil
+	})
+}
+
+// MaxPriorityFeePerGas returns the priority fee per gas suggested to get a tx
+// included in a block. Since L2 blocks have no base fee, the whole L2 gas price
+// suggested by the gas pricer is considered priority fee
+func (e *EthEndpoints) MaxPriorityFeePerGas() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.getMaxPriorityFeePerGasFromSequencerNode()
+	}
+	gasPrices, err := e.pool.GetGasPrices(context.Background())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get gas prices from pool", err, true)
+	}
+	return hex.EncodeUint64(gasPrices.L2GasPrice), nil
+}
+
+func (e *EthEndpoints) getMaxPriorityFeePerGasFromSequencerNode() (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_maxPriorityFeePerGas")
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get max priority fee per gas from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var maxPriorityFeePerGas types.ArgUint64
+	err = json.Unmarshal(res.Result, &maxPriorityFeePerGas)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read max priority fee per gas from sequencer node", err, true)
+	}
+	return maxPriorityFeePerGas, nil
+}
+
 // NewBlockFilter creates a filter in the node, to notify when
 // a new block arrives. To check if the state has changed,
 // call eth_getFilterChanges.
@@ -920,18 +1219,17 @@ func (e *EthEndpoints) newFilter(ctx context.Context, wsConn *concurrentWsConn,
 // notify when new pending transactions arrive. To check if the
 // state has changed, call eth_getFilterChanges.
 func (e *EthEndpoints) NewPendingTransactionFilter() (interface{}, types.Error) {
-	return e.newPendingTransactionFilter(nil)
+	return e.newPendingTransactionFilter(nil, PendingTxFilter{})
 }
 
 // internal
-func (e *EthEndpoints) newPendingTransactionFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
-	return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
-	// id, err := e.storage.NewPendingTransactionFilter(wsConn)
-	// if err != nil {
-	// 	return rpcErrorResponse(types.DefaultErrorCode, "failed to create new pending transaction filter", err)
-	// }
+func (e *EthEndpoints) newPendingTransactionFilter(wsConn *concurrentWsConn, filter PendingTxFilter) (interface{}, types.Error) {
+	id, err := e.storage.NewPendingTransactionFilter(wsConn, filter)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new pending transaction filter", err, true)
+	}
 
-	// return id, nil
+	return id, nil
 }
 
 // SendRawTransaction has two different ways to handle new transactions:
@@ -944,19 +1242,87 @@ func (e *EthEndpoints) SendRawTransaction(httpRequest *http.Request, input strin
 		if err := checkPolicy(context.Background(), e.pool, input); err != nil {
 			return RPCErrorResponse(types.AccessDeniedCode, err.Error(), nil, false)
 		}
-		ip := ""
-		ips := httpRequest.Header.Get("X-Forwarded-For")
+		return e.tryToAddTxToPool(input, requestIP(httpRequest))
+	}
+}
+
+// SendPrivateTransaction adds a transaction to the pool that is hidden from the pool
+// introspection and the pending transaction filters and subscriptions. It expires if
+// it is not included in an L2 block up to the max block number of the request or, if
+// not set or further, up to Pool.PrivateTxMaxBlocks L2 blocks after the last one.
+func (e *EthEndpoints) SendPrivateTransaction(httpRequest *http.Request, args types.PrivateTransactionArgs) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayPrivateTxToSequencerNode(args)
+	}
+
+	if err := checkPolicy(context.Background(), e.pool, args.Tx); err != nil {
+		return RPCErrorResponse(types.AccessDeniedCode, err.Error(), nil, false)
+	}
 
-		// TODO: this is temporary patch remove this log
-		realIp := httpRequest.Header.Get("X-Real-IP")
-		log.Debugf("X-Forwarded-For: %s, X-Real-IP: %s", ips, realIp)
+	tx, err := hexToTx(args.Tx)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+
+	var maxL2BlockNumber *uint64
+	if args.MaxBlockNumber != nil {
+		n := uint64(*args.MaxBlockNumber)
+		maxL2BlockNumber = &n
+	}
+
+	log.Infof("adding private TX to the pool: %v", tx.Hash().Hex())
+	if err := e.pool.AddPrivateTx(context.Background(), *tx, requestIP(httpRequest), maxL2BlockNumber); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("private TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
+
+// SendBundle adds a bundle of transactions to the pool, which are included by the
+// sequencer consecutively in the same L2 block in the provided order or not at all,
+// failing all of them if any fails or the bundle doesn't fit in the batch.
+func (e *EthEndpoints) SendBundle(httpRequest *http.Request, args types.BundleArgs) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayBundleToSequencerNode(args)
+	}
+
+	txs := make([]ethTypes.Transaction, 0, len(args.Txs))
+	for _, input := range args.Txs {
+		if err := checkPolicy(context.Background(), e.pool, input); err != nil {
+			return RPCErrorResponse(types.AccessDeniedCode, err.Error(), nil, false)
+		}
 
-		if ips != "" {
-			ip = strings.Split(ips, ",")[0]
+		tx, err := hexToTx(input)
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
 		}
+		txs = append(txs, *tx)
+	}
 
-		return e.tryToAddTxToPool(input, ip)
+	log.Infof("adding bundle of %d TXs to the pool", len(txs))
+	bundleHash, err := e.pool.AddBundle(context.Background(), txs, requestIP(httpRequest))
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
 	}
+	log.Infof("bundle added to the pool: %v", bundleHash.Hex())
+
+	return types.BundleResponse{BundleHash: bundleHash}, nil
+}
+
+// requestIP returns the IP of the client that sent the request from the X-Forwarded-For header
+func requestIP(httpRequest *http.Request) string {
+	ip := ""
+	ips := httpRequest.Header.Get("X-Forwarded-For")
+
+	// TODO: this is temporary patch remove this log
+	realIp := httpRequest.Header.Get("X-Real-IP")
+	log.Debugf("X-Forwarded-For: %s, X-Real-IP: %s", ips, realIp)
+
+	if ips != "" {
+		ip = strings.Split(ips, ",")[0]
+	}
+	return ip
 }
 
 func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.Error) {
@@ -974,6 +1340,32 @@ func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.
 	return txHash, nil
 }
 
+func (e *EthEndpoints) relayBundleToSequencerNode(args types.BundleArgs) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_sendBundle", args)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay bundle to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
+func (e *EthEndpoints) relayPrivateTxToSequencerNode(args types.PrivateTransactionArgs) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_sendPrivateTransaction", args)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay private tx to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
 func (e *EthEndpoints) tryToAddTxToPool(input, ip string) (interface{}, types.Error) {
 	tx, err := hexToTx(input)
 	if err != nil {
@@ -1103,27 +1495,47 @@ func (e *EthEndpoints) updateFilterLastPoll(filterID string) types.Error {
 // The node will return a subscription id.
 // For each event that matches the subscription a notification with relevant
 // data is sent together with the subscription id.
-func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilter *LogFilter) (interface{}, types.Error) {
+func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, params *SubscriptionParams) (interface{}, types.Error) {
+	if params == nil {
+		params = &SubscriptionParams{}
+	}
+
 	switch name {
 	case "newHeads":
 		return e.newBlockFilter(wsConn)
 	case "logs":
 		return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 			var lf LogFilter
-			if logFilter != nil {
-				lf = *logFilter
+			if params.LogFilter != nil {
+				lf = *params.LogFilter
 			}
 			return e.newFilter(ctx, wsConn, lf, dbTx)
 		})
 	case "pendingTransactions", "newPendingTransactions":
-		return e.newPendingTransactionFilter(wsConn)
+		return e.newPendingTransactionFilter(wsConn, PendingTxFilter{FullTx: params.FullTx})
 	case "syncing":
-		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
+		return e.newSubscriptionFilter(wsConn, FilterTypeSyncing)
+	case "zkevm_newBatches":
+		return e.newSubscriptionFilter(wsConn, FilterTypeNewBatches)
+	case "zkevm_virtualizedBatches":
+		return e.newSubscriptionFilter(wsConn, FilterTypeVirtualizedBatches)
+	case "zkevm_verifiedBatches":
+		return e.newSubscriptionFilter(wsConn, FilterTypeVerifiedBatches)
 	default:
 		return nil, types.NewRPCError(types.DefaultErrorCode, "invalid filter name")
 	}
 }
 
+// internal
+func (e *EthEndpoints) newSubscriptionFilter(wsConn *concurrentWsConn, t FilterType) (interface{}, types.Error) {
+	id, err := e.storage.NewSubscriptionFilter(wsConn, t)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new subscription filter", err, true)
+	}
+
+	return id, nil
+}
+
 // Unsubscribe uninstalls the filter based on the provided filterID
 func (e *EthEndpoints) Unsubscribe(wsConn *concurrentWsConn, filterID string) (interface{}, types.Error) {
 	return e.UninstallFilter(filterID)
@@ -1219,6 +1631,137 @@ func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockE
 	log.Debugf("[notifyNewLogs] new l2 block event for block %v took %v to send all the messages for log filters", event.Block.NumberU64(), time.Since(start))
 }
 
+// batchEventFilterTypes are the subscription filters notified for each batch event type
+var batchEventFilterTypes = map[state.BatchEventType]FilterType{
+	state.BatchEventClosed:      FilterTypeNewBatches,
+	state.BatchEventVirtualized: FilterTypeVirtualizedBatches,
+	state.BatchEventVerified:    FilterTypeVerifiedBatches,
+}
+
+// onNewBatch is triggered when the state triggers the event for a batch closed, virtualized or verified
+func (e *EthEndpoints) onNewBatch(event state.NewBatchEvent) {
+	log.Debugf("[onNewBatch] new batch event %v detected for batch %v", event.Type, event.Batch.BatchNumber)
+	start := time.Now()
+
+	filters := e.storage.GetAllSubscriptionFiltersWithWSConn(batchEventFilterTypes[event.Type])
+	if len(filters) == 0 {
+		return
+	}
+
+	b, err := e.newBatchNotification(event)
+	if err != nil {
+		log.Errorf("failed to build batch response to subscription: %v", err)
+		return
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		log.Errorf("failed to marshal batch response to subscription: %v", err)
+		return
+	}
+
+	for _, filter := range filters {
+		filter.EnqueueSubscriptionDataToBeSent(data)
+	}
+
+	log.Debugf("[onNewBatch] new batch event %v for batch %v took %v to send all the messages for batch filters", event.Type, event.Batch.BatchNumber, time.Since(start))
+}
+
+// newBatchNotification builds the batch sent to the batch subscriptions, which
+// has the hashes of its blocks and transactions like the newHeads notifications
+func (e *EthEndpoints) newBatchNotification(event state.NewBatchEvent) (*types.Batch, error) {
+	ctx := context.Background()
+
+	ger, err := e.state.GetExitRootByGlobalExitRoot(ctx, event.Batch.GlobalExitRoot, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		ger = &state.GlobalExitRoot{}
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't load full GER: %w", err)
+	}
+
+	blocks, err := e.state.GetL2BlocksByBatchNumber(ctx, event.Batch.BatchNumber, nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load blocks associated to the batch: %w", err)
+	}
+
+	return types.NewBatch(ctx, e.state, &event.Batch, event.VirtualBatch, event.VerifiedBatch, blocks, nil, false, false, ger, nil)
+}
+
+// syncingNotification is the message sent to the syncing subscriptions while
+// the node is syncing, false is sent when it finishes
+type syncingNotification struct {
+	Syncing bool `json:"syncing"`
+	Status  struct {
+		StartingBlock types.ArgUint64 `json:"startingBlock"`
+		CurrentBlock  types.ArgUint64 `json:"currentBlock"`
+		HighestBlock  types.ArgUint64 `json:"highestBlock"`
+	} `json:"status"`
+}
+
+// onSyncingStatus is triggered when the state triggers the event for the node starting or stopping syncing
+func (e *EthEndpoints) onSyncingStatus(event state.SyncingStatusEvent) {
+	log.Debugf("[onSyncingStatus] syncing status event detected, synchronizing: %v", event.SyncingInfo.IsSynchronizing)
+
+	filters := e.storage.GetAllSubscriptionFiltersWithWSConn(FilterTypeSyncing)
+	if len(filters) == 0 {
+		return
+	}
+
+	var notification interface{} = false
+	if event.SyncingInfo.IsSynchronizing {
+		n := syncingNotification{Syncing: true}
+		n.Status.StartingBlock = types.ArgUint64(event.SyncingInfo.InitialSyncingBlock)
+		n.Status.CurrentBlock = types.ArgUint64(event.SyncingInfo.CurrentBlockNumber)
+		n.Status.HighestBlock = types.ArgUint64(event.SyncingInfo.EstimatedHighestBlock)
+		notification = n
+	}
+	data, err := json.Marshal(notification)
+	if err != nil {
+		log.Errorf("failed to marshal syncing response to subscription: %v", err)
+		return
+	}
+
+	for _, filter := range filters {
+		filter.EnqueueSubscriptionDataToBeSent(data)
+	}
+}
+
+// onNewPendingTx is triggered when the pool triggers the event for a new pending tx
+func (e *EthEndpoints) onNewPendingTx(event pool.NewPendingTxEvent) {
+	log.Debugf("[onNewPendingTx] new pending tx event detected for tx %v", event.Tx.Hash().String())
+
+	filters := e.storage.GetAllPendingTxFiltersWithWSConn()
+	if len(filters) == 0 {
+		return
+	}
+
+	hashData, err := json.Marshal(event.Tx.Hash())
+	if err != nil {
+		log.Errorf("failed to marshal pending tx hash response to subscription: %v", err)
+		return
+	}
+	var txData []byte
+
+	for _, filter := range filters {
+		if !filter.Parameters.(PendingTxFilter).FullTx {
+			filter.EnqueueSubscriptionDataToBeSent(hashData)
+			continue
+		}
+
+		if txData == nil {
+			tx, err := types.NewTransaction(event.Tx.Transaction, nil, false, nil)
+			if err != nil {
+				log.Errorf("failed to build pending tx response to subscription: %v", err)
+				return
+			}
+			if txData, err = json.Marshal(tx); err != nil {
+				log.Errorf("failed to marshal pending tx response to subscription: %v", err)
+				return
+			}
+		}
+		filter.EnqueueSubscriptionDataToBeSent(txData)
+	}
+}
+
 // shouldSkipLogFilter checks if the log filter can be skipped while notifying new logs.
 // it checks the log filter information against the block in the event to decide if the
 // information in the event is required by the filter or can be ignored to save resources.
@@ -175,7 +175,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -219,7 +219,7 @@ func TestCall(t *testing.T) {
 				})
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -261,7 +261,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -303,7 +303,7 @@ func TestCall(t *testing.T) {
 				})
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -343,7 +343,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -382,7 +382,7 @@ func TestCall(t *testing.T) {
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -421,7 +421,7 @@ func TestCall(t *testing.T) {
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -485,7 +485,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{Err: errors.New("failed to process unsigned transaction")}, nil).
 					Once()
 			},
@@ -527,11 +527,96 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{Err: runtime.ErrExecutionReverted}, nil).
 					Once()
 			},
 		},
+		{
+			name: "Transaction with state override",
+			params: []interface{}{
+				types.TxArgs{
+					From: state.HexToAddressPtr("0x1"),
+					To:   state.HexToAddressPtr("0x2"),
+					Gas:  types.ArgUint64Ptr(24000),
+					Data: types.ArgBytesPtr([]byte("data")),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000001": map[string]interface{}{"balance": "0x3e8"},
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{
+						"code":  "0x6001",
+						"state": map[string]interface{}{common.HexToHash("0x1").String(): common.HexToHash("0x2").String()},
+					},
+				},
+			},
+			expectedResult: []byte("hello world"),
+			expectedError:  nil,
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				nonce := uint64(7)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				txArgs := testCase.params[0].(types.TxArgs)
+				stateOverrideMatchBy := mock.MatchedBy(func(so state.StateOverride) bool {
+					sender, receiver := so[*txArgs.From], so[*txArgs.To]
+					return len(so) == 2 &&
+						sender.Balance.Cmp(big.NewInt(1000)) == 0 && sender.Code == nil &&
+						hex.EncodeToHex(*receiver.Code) == "0x6001" &&
+						receiver.State[common.HexToHash("0x1")] == common.HexToHash("0x2")
+				})
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				m.State.
+					On("ProcessUnsignedTransaction", context.Background(), mock.Anything, *txArgs.From, nilUint64, true, stateOverrideMatchBy, m.DbTx).
+					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
+					Once()
+			},
+		},
+		{
+			name: "Transaction with state and state diff overridden for the same account",
+			params: []interface{}{
+				types.TxArgs{
+					From: state.HexToAddressPtr("0x1"),
+					To:   state.HexToAddressPtr("0x2"),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{
+						"state":     map[string]interface{}{},
+						"stateDiff": map[string]interface{}{},
+					},
+				},
+			},
+			expectedResult: nil,
+			expectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "account 0x0000000000000000000000000000000000000002 has both 'state' and 'stateDiff'"),
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			name: "Transaction with the balance overridden to zero",
+			params: []interface{}{
+				types.TxArgs{
+					From: state.HexToAddressPtr("0x1"),
+					To:   state.HexToAddressPtr("0x2"),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000001": map[string]interface{}{
+						"balance": "0x0",
+					},
+				},
+			},
+			expectedResult: nil,
+			expectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "account 0x0000000000000000000000000000000000000001 can't override the balance to zero"),
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -633,6 +718,130 @@ func TestCoinbase(t *testing.T) {
 	}
 }
 
+func TestCreateAccessList(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	touched := common.HexToAddress("0x3")
+	slot := common.HexToHash("0x4")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot, GasLimit: 30000000}))
+	traceResult := json.RawMessage(`[{"address":"` + touched.String() + `","storageKeys":["` + slot.String() + `"]}]`)
+	txArgs := map[string]interface{}{
+		"from": from.String(),
+		"to":   to.String(),
+		"data": "0x01",
+	}
+	blockArg := map[string]interface{}{
+		types.BlockNumberKey: hex.EncodeBig(blockNumOne),
+	}
+	traceConfigMatchBy := mock.MatchedBy(func(cfg state.TraceConfig) bool {
+		return cfg.IsAccessListTracer()
+	})
+
+	type testCase struct {
+		Name           string
+		Params         []interface{}
+		ExpectedResult *types.AccessListResult
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:   "access list created",
+			Params: []interface{}{txArgs, blockArg},
+			ExpectedResult: &types.AccessListResult{
+				AccessList: ethTypes.AccessList{{Address: touched, StorageKeys: []common.Hash{slot}}},
+				GasUsed:    types.ArgUint64(25000),
+				Advisory:   true,
+				Note:       types.AccessListAdvisoryNote,
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(7), nil).Once()
+				txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+					return tx != nil && *tx.To() == to && tx.Gas() == block.GasLimit() && tx.Nonce() == 7 && hex.EncodeToHex(tx.Data()) == "0x01"
+				})
+				m.State.
+					On("DebugUnsignedTransaction", context.Background(), txMatchBy, from, &blockNumOneUint64, traceConfigMatchBy, state.StateOverride(nil), (*state.BlockOverride)(nil), m.DbTx).
+					Return(&runtime.ExecutionResult{GasUsed: 25000, TraceResult: traceResult}, nil).
+					Once()
+			},
+		},
+		{
+			Name:   "execution reverted",
+			Params: []interface{}{txArgs, blockArg},
+			ExpectedResult: &types.AccessListResult{
+				AccessList: ethTypes.AccessList{},
+				GasUsed:    types.ArgUint64(22000),
+				Error:      runtime.ErrExecutionReverted.Error(),
+				Advisory:   true,
+				Note:       types.AccessListAdvisoryNote,
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(7), nil).Once()
+				m.State.
+					On("DebugUnsignedTransaction", context.Background(), mock.Anything, from, &blockNumOneUint64, traceConfigMatchBy, state.StateOverride(nil), (*state.BlockOverride)(nil), m.DbTx).
+					Return(&runtime.ExecutionResult{GasUsed: 22000, Err: runtime.ErrExecutionReverted, TraceResult: json.RawMessage(`[]`)}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to execute the call",
+			Params:        []interface{}{txArgs, blockArg},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to execute the unsigned transaction: failed to process the call"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(7), nil).Once()
+				m.State.
+					On("DebugUnsignedTransaction", context.Background(), mock.Anything, from, &blockNumOneUint64, traceConfigMatchBy, state.StateOverride(nil), (*state.BlockOverride)(nil), m.DbTx).
+					Return(nil, errors.New("failed to process the call")).
+					Once()
+			},
+		},
+		{
+			Name:          "missing transaction arguments",
+			Params:        []interface{}{nil, blockArg},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "missing value for required argument 0"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("eth_createAccessList", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result types.AccessListResult
+			require.NoError(t, json.Unmarshal(res.Result, &result))
+			assert.Equal(t, *tc.ExpectedResult, result)
+		})
+	}
+}
+
 func TestEstimateGas(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -689,7 +898,7 @@ func TestEstimateGas(t *testing.T) {
 					Return(nonce, nil).
 					Once()
 				m.State.
-					On("EstimateGas", txMatchBy, *txArgs.From, nilUint64, m.DbTx).
+					On("EstimateGas", txMatchBy, *txArgs.From, nilUint64, state.StateOverride(nil), m.DbTx).
 					Return(*testCase.expectedResult, nil, nil).
 					Once()
 			},
@@ -730,7 +939,41 @@ func TestEstimateGas(t *testing.T) {
 				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, m.DbTx).
+					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, state.StateOverride(nil), m.DbTx).
+					Return(*testCase.expectedResult, nil, nil).
+					Once()
+			},
+		},
+		{
+			name: "Transaction with state override",
+			params: []interface{}{
+				types.TxArgs{
+					To:       state.HexToAddressPtr("0x2"),
+					GasPrice: types.ArgBytesPtr(big.NewInt(0).Bytes()),
+					Value:    types.ArgBytesPtr(big.NewInt(2).Bytes()),
+					Data:     types.ArgBytesPtr([]byte("data")),
+				},
+				nil,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{"code": "0x6001", "nonce": "0x1"},
+				},
+			},
+			expectedResult: state.Ptr(uint64(100)),
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				txArgs := testCase.params[0].(types.TxArgs)
+				stateOverrideMatchBy := mock.MatchedBy(func(so state.StateOverride) bool {
+					account, found := so[*txArgs.To]
+					return found && len(so) == 1 && hex.EncodeToHex(*account.Code) == "0x6001" && *account.Nonce == 1
+				})
+
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
+
+				m.State.
+					On("EstimateGas", mock.Anything, common.HexToAddress(state.DefaultSenderAddress), nilUint64, stateOverrideMatchBy, m.DbTx).
 					Return(*testCase.expectedResult, nil, nil).
 					Once()
 			},
@@ -767,6 +1010,160 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestFeeHistory(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	txA := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(15), nil)
+	txB := ethTypes.NewTransaction(2, common.HexToAddress("0x1"), big.NewInt(1), 63000, big.NewInt(20), nil)
+	receiptA := &ethTypes.Receipt{TxHash: txA.Hash(), GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)}
+	receiptB := &ethTypes.Receipt{TxHash: txB.Hash(), GasUsed: 63000, EffectiveGasPrice: big.NewInt(20)}
+
+	st := trie.NewStackTrie(nil)
+	block9 := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(9), GasLimit: 168000, GasUsed: 84000}), []*ethTypes.Transaction{txA, txB}, nil, []*ethTypes.Receipt{receiptA, receiptB}, st)
+	block10 := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10), GasLimit: 168000}), nil, nil, nil, st)
+
+	type testCase struct {
+		Name           string
+		Params         []interface{}
+		ExpectedResult *types.FeeHistory
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:   "get fee history with reward percentiles",
+			Params: []interface{}{types.ArgUint64(2), latest, []float64{25, 75}},
+			ExpectedResult: &types.FeeHistory{
+				OldestBlock:   types.ArgUint64(9),
+				Reward:        [][]types.ArgBig{{types.ArgBig(*big.NewInt(10)), types.ArgBig(*big.NewInt(20))}, {types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0))}},
+				BaseFeePerGas: []types.ArgBig{types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0))},
+				GasUsedRatio:  []float64{0.5, 0},
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Twice()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(9), m.DbTx).Return(block9, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(10), m.DbTx).Return(block10, nil).Once()
+				m.State.On("GetTransactionReceiptsByBlockNumber", context.Background(), uint64(9), m.DbTx).Return([]*ethTypes.Receipt{receiptA, receiptB}, []*common.Hash{nil, nil}, nil).Once()
+			},
+		},
+		{
+			Name:   "get fee history with reward percentiles and block count over the reward limit",
+			Params: []interface{}{types.ArgUint64(5), latest, []float64{50}},
+			ExpectedResult: &types.FeeHistory{
+				OldestBlock:   types.ArgUint64(9),
+				Reward:        [][]types.ArgBig{{types.ArgBig(*big.NewInt(20))}, {types.ArgBig(*big.NewInt(0))}},
+				BaseFeePerGas: []types.ArgBig{types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0))},
+				GasUsedRatio:  []float64{0.5, 0},
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Twice()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(9), m.DbTx).Return(block9, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(10), m.DbTx).Return(block10, nil).Once()
+				m.State.On("GetTransactionReceiptsByBlockNumber", context.Background(), uint64(9), m.DbTx).Return([]*ethTypes.Receipt{receiptA, receiptB}, []*common.Hash{nil, nil}, nil).Once()
+			},
+		},
+		{
+			Name:   "get fee history without reward percentiles and block count over the chain length",
+			Params: []interface{}{types.ArgUint64(20), "0x1"},
+			ExpectedResult: &types.FeeHistory{
+				OldestBlock:   types.ArgUint64(0),
+				BaseFeePerGas: []types.ArgBig{types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0)), types.ArgBig(*big.NewInt(0))},
+				GasUsedRatio:  []float64{0.5, 0},
+			},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(0), m.DbTx).Return(block9, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(1), m.DbTx).Return(block10, nil).Once()
+			},
+		},
+		{
+			Name:           "get fee history with zero block count",
+			Params:         []interface{}{types.ArgUint64(0), latest},
+			ExpectedResult: &types.FeeHistory{BaseFeePerGas: []types.ArgBig{}, GasUsedRatio: []float64{}},
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			Name:          "reward percentile out of range",
+			Params:        []interface{}{types.ArgUint64(2), latest, []float64{25, 101}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid reward percentile: 101"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "reward percentiles not sorted",
+			Params:        []interface{}{types.ArgUint64(2), latest, []float64{75, 25}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid reward percentile: #0:75 > #1:25"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "newest block beyond head block",
+			Params:        []interface{}{types.ArgUint64(2), "0x14"},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "request beyond head block: requested 20, head 10"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Once()
+			},
+		},
+		{
+			Name:          "failed to get block",
+			Params:        []interface{}{types.ArgUint64(1), latest},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't load block from state by number 10"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Twice()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(10), m.DbTx).Return(nil, errors.New("failed to get block")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("eth_feeHistory", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result types.FeeHistory
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult.OldestBlock, result.OldestBlock)
+			assert.Equal(t, tc.ExpectedResult.GasUsedRatio, result.GasUsedRatio)
+			assert.Equal(t, len(tc.ExpectedResult.BaseFeePerGas), len(result.BaseFeePerGas))
+			for i := range tc.ExpectedResult.BaseFeePerGas {
+				assert.Equal(t, tc.ExpectedResult.BaseFeePerGas[i].Hex(), result.BaseFeePerGas[i].Hex())
+			}
+			require.Equal(t, len(tc.ExpectedResult.Reward), len(result.Reward))
+			for i := range tc.ExpectedResult.Reward {
+				require.Equal(t, len(tc.ExpectedResult.Reward[i]), len(result.Reward[i]))
+				for j := range tc.ExpectedResult.Reward[i] {
+					assert.Equal(t, tc.ExpectedResult.Reward[i][j].Hex(), result.Reward[i][j].Hex())
+				}
+			}
+		})
+	}
+}
+
 func TestGasPrice(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -800,6 +1197,42 @@ func TestGasPrice(t *testing.T) {
 	}
 }
 
+func TestMaxPriorityFeePerGas(t *testing.T) {
+	s, m, c := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	testCases := []struct {
+		name                         string
+		gasPrice                     uint64
+		error                        error
+		expectedMaxPriorityFeePerGas uint64
+	}{
+		{"MaxPriorityFeePerGas with value", 50, nil, 50},
+		{"failed to get gas price", 50, errors.New("failed to get gas price"), 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			m.Pool.
+				On("GetGasPrices", context.Background()).
+				Return(pool.GasPrices{
+					L2GasPrice: testCase.gasPrice,
+					L1GasPrice: testCase.gasPrice,
+				}, testCase.error).
+				Once()
+
+			tip, err := c.SuggestGasTipCap(context.Background())
+			if testCase.error != nil {
+				require.Error(t, err)
+				assert.Equal(t, "failed to get gas prices from pool", err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMaxPriorityFeePerGas, tip.Uint64())
+		})
+	}
+}
+
 func TestGetBalance(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3539,8 +3972,152 @@ func TestGetTransactionReceipt(t *testing.T) {
 	}
 }
 
-func TestSendRawTransactionViaGeth(t *testing.T) {
-	s, m, c := newSequencerMockedServer(t)
+func TestGetBlockReceipts(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	chainID := big.NewInt(1)
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	txs := make([]*ethTypes.Transaction, 0, 2)
+	receipts := make([]*ethTypes.Receipt, 0, 2)
+	for i := 0; i < 2; i++ {
+		tx := ethTypes.NewTransaction(uint64(i), common.HexToAddress("0x111"), big.NewInt(2), 21000, big.NewInt(4), nil)
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		txs = append(txs, signedTx)
+
+		receipt := &ethTypes.Receipt{
+			Type:              signedTx.Type(),
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			BlockNumber:       big.NewInt(2),
+			GasUsed:           21000,
+			TxHash:            signedTx.Hash(),
+			TransactionIndex:  uint(i),
+			Logs:              []*ethTypes.Log{{Topics: []common.Hash{common.HexToHash("0x1")}, Data: []byte{}, TxHash: signedTx.Hash()}},
+			Status:            ethTypes.ReceiptStatusSuccessful,
+			EffectiveGasPrice: big.NewInt(4),
+		}
+		receipt.Bloom = ethTypes.CreateBloom(ethTypes.Receipts{receipt})
+		receipts = append(receipts, receipt)
+	}
+
+	st := trie.NewStackTrie(nil)
+	l2Block := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(2)}), txs, nil, receipts, st)
+	for _, receipt := range receipts {
+		receipt.BlockHash = l2Block.Hash()
+	}
+
+	l2Hashes := make([]*common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		l2Hash := common.BytesToHash(tx.Hash().Bytes()[:16])
+		l2Hashes = append(l2Hashes, &l2Hash)
+	}
+
+	type testCase struct {
+		Name             string
+		Params           []interface{}
+		ExpectedReceipts int
+		ExpectedNil      bool
+		ExpectedL2Hashes bool
+		ExpectedError    *types.RPCError
+		SetupMocks       func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:             "get receipts by block number",
+			Params:           []interface{}{"0x2"},
+			ExpectedReceipts: 2,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(2), m.DbTx).Return(l2Block, nil).Once()
+				m.State.On("GetTransactionReceiptsByBlockNumber", context.Background(), uint64(2), m.DbTx).Return(receipts, l2Hashes, nil).Once()
+			},
+		},
+		{
+			Name:             "get receipts by block hash including extra info",
+			Params:           []interface{}{l2Block.Hash().String(), true},
+			ExpectedReceipts: 2,
+			ExpectedL2Hashes: true,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByHash", context.Background(), l2Block.Hash(), m.DbTx).Return(l2Block, nil).Once()
+				m.State.On("GetTransactionReceiptsByBlockNumber", context.Background(), uint64(2), m.DbTx).Return(receipts, l2Hashes, nil).Once()
+			},
+		},
+		{
+			Name:        "block not found",
+			Params:      []interface{}{"0x3"},
+			ExpectedNil: true,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(3), m.DbTx).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:          "failed to get receipts",
+			Params:        []interface{}{"0x2"},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't load receipts for block 2"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(2), m.DbTx).Return(l2Block, nil).Once()
+				m.State.On("GetTransactionReceiptsByBlockNumber", context.Background(), uint64(2), m.DbTx).Return(nil, nil, errors.New("failed to get receipts")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("eth_getBlockReceipts", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			if tc.ExpectedNil {
+				assert.Equal(t, "null", string(res.Result))
+				return
+			}
+
+			var result []types.Receipt
+			require.NoError(t, json.Unmarshal(res.Result, &result))
+			require.Len(t, result, tc.ExpectedReceipts)
+			for i, receipt := range result {
+				assert.Equal(t, txs[i].Hash(), receipt.TxHash)
+				assert.Equal(t, uint64(i), uint64(receipt.TxIndex))
+				assert.Equal(t, auth.From, receipt.FromAddr)
+				assert.Equal(t, l2Block.Hash(), receipt.BlockHash)
+				assert.Equal(t, receipts[i].Bloom, receipt.LogsBloom)
+				assert.Len(t, receipt.Logs, 1)
+				if tc.ExpectedL2Hashes {
+					require.NotNil(t, receipt.TxL2Hash)
+					assert.Equal(t, common.BytesToHash(txs[i].Hash().Bytes()[:16]), *receipt.TxL2Hash)
+				} else {
+					assert.Nil(t, receipt.TxL2Hash)
+				}
+			}
+		})
+	}
+}
+
+func TestSendRawTransactionViaGeth(t *testing.T) {
+	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
 
 	type testCase struct {
@@ -3702,6 +4279,220 @@ func TestSendRawTransactionJSONRPCCall(t *testing.T) {
 	}
 }
 
+func TestSendPrivateTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+	nonSequencerServer, _, _ := newNonSequencerMockedServer(t, s.ServerURL)
+	defer nonSequencerServer.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := hex.EncodeToHex(txBinary)
+
+	txMatchByHash := mock.MatchedBy(func(poolTx ethTypes.Transaction) bool {
+		return poolTx.Hash() == tx.Hash()
+	})
+	maxL2BlockNumber := uint64(10)
+
+	type testCase struct {
+		Name           string
+		Server         *mockedServer
+		Args           map[string]interface{}
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "Send private TX successfully",
+			Server:         s,
+			Args:           map[string]interface{}{"tx": rawTx},
+			ExpectedResult: state.Ptr(tx.Hash()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddPrivateTx", context.Background(), txMatchByHash, "", (*uint64)(nil)).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Send private TX with max block number successfully",
+			Server:         s,
+			Args:           map[string]interface{}{"tx": rawTx, "maxBlockNumber": hex.EncodeUint64(maxL2BlockNumber)},
+			ExpectedResult: state.Ptr(tx.Hash()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddPrivateTx", context.Background(), txMatchByHash, "", &maxL2BlockNumber).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Send private TX relayed by a non sequencer node",
+			Server:         nonSequencerServer,
+			Args:           map[string]interface{}{"tx": rawTx, "maxBlockNumber": hex.EncodeUint64(maxL2BlockNumber)},
+			ExpectedResult: state.Ptr(tx.Hash()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddPrivateTx", context.Background(), txMatchByHash, "", &maxL2BlockNumber).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			Name:          "Send private TX failed to add to the pool",
+			Server:        s,
+			Args:          map[string]interface{}{"tx": rawTx, "maxBlockNumber": hex.EncodeUint64(maxL2BlockNumber)},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, pool.ErrMaxBlockNumberReached.Error()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddPrivateTx", context.Background(), txMatchByHash, "", &maxL2BlockNumber).
+					Return(pool.ErrMaxBlockNumberReached).
+					Once()
+			},
+		},
+		{
+			Name:          "Send invalid private tx input",
+			Server:        s,
+			Args:          map[string]interface{}{"tx": "0x1234"},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid tx input"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := tc.Server.JSONRPCCall("eth_sendPrivateTransaction", tc.Args)
+			require.NoError(t, err)
+
+			if res.Result != nil || tc.ExpectedResult != nil {
+				var result common.Hash
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
+func TestSendBundle(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+	nonSequencerServer, _, _ := newNonSequencerMockedServer(t, s.ServerURL)
+	defer nonSequencerServer.Stop()
+
+	txs := []*ethTypes.Transaction{
+		ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{}),
+		ethTypes.NewTransaction(2, common.HexToAddress("0x2"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{}),
+	}
+	rawTxs := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		txBinary, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		rawTxs = append(rawTxs, hex.EncodeToHex(txBinary))
+	}
+	bundleHash := common.HexToHash("0xb0")
+
+	txsMatchByHash := mock.MatchedBy(func(poolTxs []ethTypes.Transaction) bool {
+		if len(poolTxs) != len(txs) {
+			return false
+		}
+		for i, poolTx := range poolTxs {
+			if poolTx.Hash() != txs[i].Hash() {
+				return false
+			}
+		}
+		return true
+	})
+
+	type testCase struct {
+		Name           string
+		Server         *mockedServer
+		Args           map[string]interface{}
+		ExpectedResult *types.BundleResponse
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "Send bundle successfully",
+			Server:         s,
+			Args:           map[string]interface{}{"txs": rawTxs},
+			ExpectedResult: &types.BundleResponse{BundleHash: bundleHash},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddBundle", context.Background(), txsMatchByHash, "").
+					Return(bundleHash, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Send bundle relayed by a non sequencer node",
+			Server:         nonSequencerServer,
+			Args:           map[string]interface{}{"txs": rawTxs},
+			ExpectedResult: &types.BundleResponse{BundleHash: bundleHash},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddBundle", context.Background(), txsMatchByHash, "").
+					Return(bundleHash, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "Send bundle failed to add to the pool",
+			Server:        s,
+			Args:          map[string]interface{}{"txs": rawTxs},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, pool.ErrBundleTooLarge.Error()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddBundle", context.Background(), txsMatchByHash, "").
+					Return(common.Hash{}, pool.ErrBundleTooLarge).
+					Once()
+			},
+		},
+		{
+			Name:          "Send bundle with an invalid tx input",
+			Server:        s,
+			Args:          map[string]interface{}{"txs": []string{rawTxs[0], "0x1234"}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid tx input"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := tc.Server.JSONRPCCall("eth_sendBundle", tc.Args)
+			require.NoError(t, err)
+
+			if res.Result != nil || tc.ExpectedResult != nil {
+				var result types.BundleResponse
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
 func TestSendRawTransactionViaGethForNonSequencerNode(t *testing.T) {
 	sequencerServer, sequencerMocks, _ := newSequencerMockedServer(t)
 	defer sequencerServer.Stop()
@@ -4058,33 +4849,27 @@ func TestNewPendingTransactionFilter(t *testing.T) {
 	}
 
 	testCases := []testCase{
-		// {
-		// 	Name:           "New pending transaction filter created successfully",
-		// 	ExpectedResult: "1",
-		// 	ExpectedError:  nil,
-		// 	SetupMocks: func(m *mocksWrapper, tc testCase) {
-		// 		m.Storage.
-		// 			On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
-		// 			Return("1", nil).
-		// 			Once()
-		// 	},
-		// },
-		// {
-		// 	Name:           "failed to create new pending transaction filter",
-		// 	ExpectedResult: "",
-		// 	ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new pending transaction filter"),
-		// 	SetupMocks: func(m *mocksWrapper, tc testCase) {
-		// 		m.Storage.
-		// 			On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{})).
-		// 			Return("", errors.New("failed to add new pending transaction filter")).
-		// 			Once()
-		// 	},
-		// },
-		{
-			Name:           "can't create pending tx filter",
+		{
+			Name:           "New pending transaction filter created successfully",
+			ExpectedResult: "1",
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{}), PendingTxFilter{}).
+					Return("1", nil).
+					Once()
+			},
+		},
+		{
+			Name:           "failed to create new pending transaction filter",
 			ExpectedResult: "",
-			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "not supported yet"),
-			SetupMocks:     func(m *mocksWrapper, tc testCase) {},
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to create new pending transaction filter"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewPendingTransactionFilter", mock.IsType(&concurrentWsConn{}), PendingTxFilter{}).
+					Return("", errors.New("failed to add new pending transaction filter")).
+					Once()
+			},
 		},
 	}
 
@@ -5437,6 +6222,9 @@ func TestSendRawTransactionJSONRPCCallWithPolicyApplied(t *testing.T) {
 	senderDenied := types.NewRPCError(types.AccessDeniedCode, "sender disallowed send_tx by policy")
 	contractDenied := types.NewRPCError(types.AccessDeniedCode, "contract disallowed send_tx by policy")
 	deployDenied := types.NewRPCError(types.AccessDeniedCode, "sender disallowed deploy by policy")
+	callDenied := types.NewRPCError(types.AccessDeniedCode, "contract disallowed call by policy")
+	valueDenied := types.NewRPCError(types.AccessDeniedCode, "sender value exceeds limit by policy")
+	transferSelector := []byte{0xa9, 0x05, 0x9c, 0xbb}
 
 	cfg := getSequencerDefaultConfig()
 	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
@@ -5484,6 +6272,106 @@ func TestSendRawTransactionJSONRPCCallWithPolicyApplied(t *testing.T) {
 					On("CheckPolicy", context.Background(), pool.SendTx, allowed.From).
 					Return(true, nil).
 					Once()
+				m.Pool.
+					On("CheckValuePolicy", context.Background(), allowed.From, big.NewInt(1)).
+					Return(true, nil).
+					Once()
+			},
+		},
+		{
+			Name: "Contract function on call allow list, accepted",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, allowedContract, big.NewInt(0), uint64(1), big.NewInt(1), append(transferSelector, 0x1))
+
+				signedTx, err := allowed.Signer(allowed.From, tx)
+				require.NoError(t, err)
+
+				txBinary, err := signedTx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				expectedHash := signedTx.Hash()
+				tc.ExpectedResult = &expectedHash
+				tc.ExpectedError = nil
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(nil).
+					Once()
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.SendTx, allowedContract).
+					Return(true, nil).
+					Once()
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.SendTx, allowed.From).
+					Return(true, nil).
+					Once()
+				// the tx transfers no value, so the value policy is not checked
+				m.Pool.
+					On("CheckCallPolicy", context.Background(), allowedContract, transferSelector).
+					Return(true, nil).
+					Once()
+			},
+		},
+		{
+			Name: "Contract function not on call allow list, rejected",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, allowedContract, big.NewInt(1), uint64(1), big.NewInt(1), transferSelector)
+
+				signedTx, err := allowed.Signer(allowed.From, tx)
+				require.NoError(t, err)
+
+				txBinary, err := signedTx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = callDenied
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.SendTx, allowedContract).
+					Return(true, nil).
+					Once()
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.SendTx, allowed.From).
+					Return(true, nil).
+					Once()
+				m.Pool.
+					On("CheckCallPolicy", context.Background(), allowedContract, transferSelector).
+					Return(false, nil).
+					Once()
+			},
+		},
+		{
+			Name: "Value over the sender limit, rejected",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, allowedContract, big.NewInt(1000), uint64(1), big.NewInt(1), []byte{})
+
+				signedTx, err := allowed.Signer(allowed.From, tx)
+				require.NoError(t, err)
+
+				txBinary, err := signedTx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = valueDenied
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.SendTx, allowedContract).
+					Return(true, nil).
+					Once()
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.SendTx, allowed.From).
+					Return(true, nil).
+					Once()
+				m.Pool.
+					On("CheckValuePolicy", context.Background(), allowed.From, big.NewInt(1000)).
+					Return(false, nil).
+					Once()
 			},
 		},
 		{
@@ -5598,6 +6486,32 @@ func TestSendRawTransactionJSONRPCCallWithPolicyApplied(t *testing.T) {
 			},
 			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {},
 		},
+		{
+			Name: "Deploy value over the sender limit, rejected",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewContractCreation(1, big.NewInt(1), uint64(1), big.NewInt(1), []byte{0x1})
+
+				signedTx, err := allowed.Signer(allowed.From, tx)
+				require.NoError(t, err)
+
+				txBinary, err := signedTx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = valueDenied
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("CheckPolicy", context.Background(), pool.Deploy, allowed.From).
+					Return(true, nil).
+					Once()
+				m.Pool.
+					On("CheckValuePolicy", context.Background(), allowed.From, big.NewInt(1)).
+					Return(false, nil).
+					Once()
+			},
+		},
 		{
 			Name: "Sender not on deploy allow list, rejected",
 			Prepare: func(t *testing.T, tc *testCase) {
@@ -5651,3 +6565,149 @@ func TestSendRawTransactionJSONRPCCallWithPolicyApplied(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscribeBatchesAndSyncing(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	m.Storage.On("UninstallFilterByWSConn", mock.Anything).Return(nil).Maybe()
+	ethEndpoints := s.Server.handler.serviceMap[APIEth].sv.Interface().(*EthEndpoints)
+
+	wsClient, err := rpc.Dial(s.ServerWebSocketsURL)
+	require.NoError(t, err)
+	defer wsClient.Close()
+
+	subscribe := func(t *testing.T, name string, filterType FilterType, id string) chan json.RawMessage {
+		filter := &Filter{ID: id, Type: filterType, wsQueue: state.NewQueue[[]byte](), wsQueueSignal: sync.NewCond(&sync.Mutex{})}
+		m.Storage.On("NewSubscriptionFilter", mock.Anything, filterType).Run(func(args mock.Arguments) {
+			filter.WsConn = args.Get(0).(*concurrentWsConn)
+			go filter.SendEnqueuedSubscriptionData()
+		}).Return(id, nil).Once()
+		m.Storage.On("GetAllSubscriptionFiltersWithWSConn", filterType).Return([]*Filter{filter})
+
+		ch := make(chan json.RawMessage, 1)
+		_, err := wsClient.EthSubscribe(context.Background(), ch, name)
+		require.NoError(t, err)
+		return ch
+	}
+	receive := func(t *testing.T, ch chan json.RawMessage) json.RawMessage {
+		select {
+		case data := <-ch:
+			return data
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "subscription notification not received")
+			return nil
+		}
+	}
+
+	t.Run("verified batches", func(t *testing.T) {
+		ch := subscribe(t, "zkevm_verifiedBatches", FilterTypeVerifiedBatches, "0x1")
+
+		batch := state.Batch{BatchNumber: 5, GlobalExitRoot: common.HexToHash("0x1"), StateRoot: common.HexToHash("0x2")}
+		virtualTxHash := common.HexToHash("0x3")
+		verifiedTxHash := common.HexToHash("0x4")
+		block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(7)}))
+		m.State.On("GetExitRootByGlobalExitRoot", mock.Anything, batch.GlobalExitRoot, nil).Return(&state.GlobalExitRoot{MainnetExitRoot: common.HexToHash("0x5")}, nil).Once()
+		m.State.On("GetL2BlocksByBatchNumber", mock.Anything, batch.BatchNumber, nil).Return([]state.L2Block{*block}, nil).Once()
+
+		ethEndpoints.onNewBatch(state.NewBatchEvent{
+			Type:          state.BatchEventVerified,
+			Batch:         batch,
+			VirtualBatch:  &state.VirtualBatch{BatchNumber: 5, TxHash: virtualTxHash},
+			VerifiedBatch: &state.VerifiedBatch{BatchNumber: 5, TxHash: verifiedTxHash},
+		})
+
+		var notification types.Batch
+		require.NoError(t, json.Unmarshal(receive(t, ch), &notification))
+		assert.Equal(t, types.ArgUint64(5), notification.Number)
+		assert.Equal(t, batch.StateRoot, notification.StateRoot)
+		assert.Equal(t, common.HexToHash("0x5"), notification.MainnetExitRoot)
+		assert.Equal(t, virtualTxHash, *notification.SendSequencesTxHash)
+		assert.Equal(t, verifiedTxHash, *notification.VerifyBatchTxHash)
+		require.Len(t, notification.Blocks, 1)
+		assert.Equal(t, block.Hash(), *notification.Blocks[0].Hash)
+	})
+
+	t.Run("new batches are not sent to other subscriptions", func(t *testing.T) {
+		m.Storage.On("GetAllSubscriptionFiltersWithWSConn", FilterType(FilterTypeNewBatches)).Return([]*Filter{}).Once()
+		ethEndpoints.onNewBatch(state.NewBatchEvent{Type: state.BatchEventClosed, Batch: state.Batch{BatchNumber: 6}})
+	})
+
+	t.Run("syncing", func(t *testing.T) {
+		ch := subscribe(t, "syncing", FilterTypeSyncing, "0x2")
+
+		ethEndpoints.onSyncingStatus(state.SyncingStatusEvent{SyncingInfo: state.SyncingInfo{
+			InitialSyncingBlock:   1,
+			CurrentBlockNumber:    2,
+			EstimatedHighestBlock: 3,
+			IsSynchronizing:       true,
+		}})
+		assert.JSONEq(t, `{"syncing":true,"status":{"startingBlock":"0x1","currentBlock":"0x2","highestBlock":"0x3"}}`, string(receive(t, ch)))
+
+		ethEndpoints.onSyncingStatus(state.SyncingStatusEvent{SyncingInfo: state.SyncingInfo{IsSynchronizing: false}})
+		assert.Equal(t, "false", string(receive(t, ch)))
+	})
+}
+
+func TestSubscribeNewPendingTransactions(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	m.Storage.On("UninstallFilterByWSConn", mock.Anything).Return(nil).Maybe()
+	ethEndpoints := s.Server.handler.serviceMap[APIEth].sv.Interface().(*EthEndpoints)
+
+	wsClient, err := rpc.Dial(s.ServerWebSocketsURL)
+	require.NoError(t, err)
+	defer wsClient.Close()
+
+	filters := []*Filter{}
+	subscribe := func(t *testing.T, id string, args ...interface{}) chan json.RawMessage {
+		filter := &Filter{ID: id, Type: FilterTypePendingTx, wsQueue: state.NewQueue[[]byte](), wsQueueSignal: sync.NewCond(&sync.Mutex{})}
+		m.Storage.On("NewPendingTransactionFilter", mock.Anything, mock.IsType(PendingTxFilter{})).Run(func(args mock.Arguments) {
+			filter.WsConn = args.Get(0).(*concurrentWsConn)
+			filter.Parameters = args.Get(1).(PendingTxFilter)
+			go filter.SendEnqueuedSubscriptionData()
+		}).Return(id, nil).Once()
+		filters = append(filters, filter)
+
+		ch := make(chan json.RawMessage, 1)
+		_, err := wsClient.EthSubscribe(context.Background(), ch, append([]interface{}{"newPendingTransactions"}, args...)...)
+		require.NoError(t, err)
+		return ch
+	}
+	receive := func(t *testing.T, ch chan json.RawMessage) json.RawMessage {
+		select {
+		case data := <-ch:
+			return data
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "subscription notification not received")
+			return nil
+		}
+	}
+
+	hashesCh := subscribe(t, "0x1")
+	fullTxsCh := subscribe(t, "0x2", true)
+	m.Storage.On("GetAllPendingTxFiltersWithWSConn").Return(filters)
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(0).SetUint64(s.ChainID()))
+	require.NoError(t, err)
+	to := common.HexToAddress("0x1")
+	tx, err := auth.Signer(auth.From, ethTypes.NewTransaction(1, to, big.NewInt(2), 21000, big.NewInt(3), nil))
+	require.NoError(t, err)
+
+	ethEndpoints.onNewPendingTx(pool.NewPendingTxEvent{Tx: *pool.NewTransaction(*tx, "", false)})
+
+	var hash common.Hash
+	require.NoError(t, json.Unmarshal(receive(t, hashesCh), &hash))
+	assert.Equal(t, tx.Hash(), hash)
+
+	var fullTx types.Transaction
+	require.NoError(t, json.Unmarshal(receive(t, fullTxsCh), &fullTx))
+	assert.Equal(t, tx.Hash(), fullTx.Hash)
+	assert.Equal(t, auth.From, fullTx.From)
+	assert.Equal(t, to, *fullTx.To)
+	assert.Equal(t, types.ArgUint64(1), fullTx.Nonce)
+	assert.Nil(t, fullTx.BlockNumber)
+}
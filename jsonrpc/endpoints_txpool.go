@@ -1,18 +1,50 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // TxPoolEndpoints is the txpool jsonrpc endpoint
-type TxPoolEndpoints struct{}
+type TxPoolEndpoints struct {
+	cfg  Config
+	pool types.PoolInterface
+}
+
+// NewTxPoolEndpoints returns TxPoolEndpoints
+func NewTxPoolEndpoints(cfg Config, pool types.PoolInterface) *TxPoolEndpoints {
+	return &TxPoolEndpoints{
+		cfg:  cfg,
+		pool: pool,
+	}
+}
 
 type contentResponse struct {
 	Pending map[common.Address]map[uint64]*txPoolTransaction `json:"pending"`
 	Queued  map[common.Address]map[uint64]*txPoolTransaction `json:"queued"`
 }
 
+type contentFromResponse struct {
+	Pending map[uint64]*txPoolTransaction `json:"pending"`
+	Queued  map[uint64]*txPoolTransaction `json:"queued"`
+}
+
+type inspectResponse struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
+type statusResponse struct {
+	Pending types.ArgUint64 `json:"pending"`
+	Queued  types.ArgUint64 `json:"queued"`
+}
+
 type txPoolTransaction struct {
 	Nonce       types.ArgUint64 `json:"nonce"`
 	GasPrice    types.ArgBig    `json:"gasPrice"`
@@ -27,13 +59,189 @@ type txPoolTransaction struct {
 	TxIndex     interface{}     `json:"transactionIndex"`
 }
 
+func newTxPoolTransaction(tx pool.Transaction, from common.Address) *txPoolTransaction {
+	return &txPoolTransaction{
+		Nonce:    types.ArgUint64(tx.Nonce()),
+		GasPrice: types.ArgBig(*tx.GasPrice()),
+		Gas:      types.ArgUint64(tx.Gas()),
+		To:       tx.To(),
+		Value:    types.ArgBig(*tx.Value()),
+		Input:    tx.Data(),
+		Hash:     tx.Hash(),
+		From:     from,
+	}
+}
+
 // Content creates a response for txpool_content request.
+// The optional offset and limit parameters allow to paginate the
+// response by sender account, sorted by address.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
-func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
+func (e *TxPoolEndpoints) Content(offset, limit *types.ArgUint64) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		var resp contentResponse
+		return e.relayToSequencerNode(&resp, "txpool_content", offset, limit)
+	}
+
+	ctx := context.Background()
+	content, rpcErr := e.getContent(ctx, offset, limit)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
 	resp := contentResponse{
-		Pending: make(map[common.Address]map[uint64]*txPoolTransaction),
-		Queued:  make(map[common.Address]map[uint64]*txPoolTransaction),
+		Pending: make(map[common.Address]map[uint64]*txPoolTransaction, len(content.Pending)),
+		Queued:  make(map[common.Address]map[uint64]*txPoolTransaction, len(content.Queued)),
+	}
+	for from, txs := range content.Pending {
+		resp.Pending[from] = newTxPoolTransactionsByNonce(from, txs)
+	}
+	for from, txs := range content.Queued {
+		resp.Queued[from] = newTxPoolTransactionsByNonce(from, txs)
+	}
+
+	return resp, nil
+}
+
+// ContentFrom creates a response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (e *TxPoolEndpoints) ContentFrom(address types.ArgAddress) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		var resp contentFromResponse
+		return e.relayToSequencerNode(&resp, "txpool_contentFrom", address.Address().String())
+	}
+
+	from := address.Address()
+	content, err := e.pool.GetContentFrom(context.Background(), from)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
+	}
+
+	resp := contentFromResponse{
+		Pending: newTxPoolTransactionsByNonce(from, content.Pending[from]),
+		Queued:  newTxPoolTransactionsByNonce(from, content.Queued[from]),
+	}
+
+	return resp, nil
+}
+
+// Inspect creates a response for txpool_inspect request.
+// The optional offset and limit parameters allow to paginate the
+// response by sender account, sorted by address.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
+func (e *TxPoolEndpoints) Inspect(offset, limit *types.ArgUint64) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		var resp inspectResponse
+		return e.relayToSequencerNode(&resp, "txpool_inspect", offset, limit)
+	}
+
+	ctx := context.Background()
+	content, rpcErr := e.getContent(ctx, offset, limit)
+	if rpcErr != nil {
+		return nil, rpcErr
 	}
 
+	resp := inspectResponse{
+		Pending: make(map[common.Address]map[uint64]string, len(content.Pending)),
+		Queued:  make(map[common.Address]map[uint64]string, len(content.Queued)),
+	}
+	for from, txs := range content.Pending {
+		resp.Pending[from] = inspectTxsByNonce(txs)
+	}
+	for from, txs := range content.Queued {
+		resp.Queued[from] = inspectTxsByNonce(txs)
+	}
+
+	return resp, nil
+}
+
+// Status creates a response for txpool_status request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status.
+func (e *TxPoolEndpoints) Status() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		var resp statusResponse
+		return e.relayToSequencerNode(&resp, "txpool_status")
+	}
+
+	status, err := e.pool.GetStatus(context.Background())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool status", err, true)
+	}
+
+	resp := statusResponse{
+		Pending: types.ArgUint64(status.Pending),
+		Queued:  types.ArgUint64(status.Queued),
+	}
+
+	return resp, nil
+}
+
+func (e *TxPoolEndpoints) getContent(ctx context.Context, offset, limit *types.ArgUint64) (*pool.TxPoolContent, types.Error) {
+	var from, count uint64
+	if offset != nil {
+		from = uint64(*offset)
+	}
+	if limit != nil {
+		count = uint64(*limit)
+	}
+
+	if e.cfg.MaxTxPoolContentAccounts > 0 {
+		if count == 0 {
+			count = e.cfg.MaxTxPoolContentAccounts
+		} else if count > e.cfg.MaxTxPoolContentAccounts {
+			errMsg := fmt.Sprintf("txpool content is limited to %v accounts", e.cfg.MaxTxPoolContentAccounts)
+			return nil, types.NewRPCError(types.InvalidParamsErrorCode, errMsg)
+		}
+	}
+
+	content, err := e.pool.GetContent(ctx, from, count)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
+		return nil, rpcErr
+	}
+
+	return content, nil
+}
+
+func (e *TxPoolEndpoints) relayToSequencerNode(resp interface{}, method string, parameters ...interface{}) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, method, parameters...)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	err = json.Unmarshal(res.Result, resp)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read pool content from sequencer node", err, true)
+	}
 	return resp, nil
 }
+
+func newTxPoolTransactionsByNonce(from common.Address, txs []pool.Transaction) map[uint64]*txPoolTransaction {
+	txsByNonce := make(map[uint64]*txPoolTransaction, len(txs))
+	for _, tx := range txs {
+		// when more than one tx has the same nonce, the first one received is kept
+		if _, found := txsByNonce[tx.Nonce()]; found {
+			continue
+		}
+		txsByNonce[tx.Nonce()] = newTxPoolTransaction(tx, from)
+	}
+	return txsByNonce
+}
+
+func inspectTxsByNonce(txs []pool.Transaction) map[uint64]string {
+	txsByNonce := make(map[uint64]string, len(txs))
+	for _, tx := range txs {
+		if _, found := txsByNonce[tx.Nonce()]; found {
+			continue
+		}
+		if to := tx.To(); to != nil {
+			txsByNonce[tx.Nonce()] = fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		} else {
+			txsByNonce[tx.Nonce()] = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+	}
+	return txsByNonce
+}
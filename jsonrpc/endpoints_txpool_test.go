@@ -0,0 +1,228 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTxPoolTestContent() (*pool.TxPoolContent, common.Address, common.Address) {
+	senderA := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	senderB := common.HexToAddress("0x4d5Cf5032B2a844602278b01199ED191A86c93ff")
+	to := common.HexToAddress("0x1")
+
+	newPoolTx := func(nonce uint64) pool.Transaction {
+		tx := ethTypes.NewTransaction(nonce, to, big.NewInt(10), 21000, big.NewInt(1000000000), nil)
+		return *pool.NewTransaction(*tx, "", false)
+	}
+
+	content := pool.NewTxPoolContent()
+	content.Pending[senderA] = []pool.Transaction{newPoolTx(0), newPoolTx(1)}
+	content.Queued[senderA] = []pool.Transaction{newPoolTx(3)}
+	content.Queued[senderB] = []pool.Transaction{newPoolTx(7)}
+
+	return content, senderA, senderB
+}
+
+func TestTxPoolContent(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	content, senderA, senderB := newTxPoolTestContent()
+
+	type testCase struct {
+		Name          string
+		Params        []interface{}
+		ExpectedError types.Error
+		SetupMocks    func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:   "get content successfully with default limit",
+			Params: []interface{}{},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetContent", context.Background(), uint64(0), s.Config.MaxTxPoolContentAccounts).
+					Return(content, nil).
+					Once()
+			},
+		},
+		{
+			Name:   "get content successfully with offset and limit",
+			Params: []interface{}{types.ArgUint64(10), types.ArgUint64(5)},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetContent", context.Background(), uint64(10), uint64(5)).
+					Return(content, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "limit exceeded",
+			Params:        []interface{}{types.ArgUint64(0), types.ArgUint64(s.Config.MaxTxPoolContentAccounts + 1)},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "txpool content is limited to 1000 accounts"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "failed to get content",
+			Params:        []interface{}{},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get pool content"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetContent", context.Background(), uint64(0), s.Config.MaxTxPoolContentAccounts).
+					Return(nil, errors.New("failed to get content")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("txpool_content", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result contentResponse
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+
+			require.Len(t, result.Pending, 1)
+			require.Len(t, result.Pending[senderA], 2)
+			assert.Equal(t, content.Pending[senderA][1].Hash(), result.Pending[senderA][1].Hash)
+			assert.Equal(t, senderA, result.Pending[senderA][1].From)
+			assert.Nil(t, result.Pending[senderA][1].BlockNumber)
+
+			require.Len(t, result.Queued, 2)
+			assert.Equal(t, content.Queued[senderA][0].Hash(), result.Queued[senderA][3].Hash)
+			assert.Equal(t, content.Queued[senderB][0].Hash(), result.Queued[senderB][7].Hash)
+		})
+	}
+}
+
+func TestTxPoolContentFrom(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	content, senderA, _ := newTxPoolTestContent()
+	m.Pool.
+		On("GetContentFrom", context.Background(), senderA).
+		Return(content, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("txpool_contentFrom", senderA.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result contentFromResponse
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	require.Len(t, result.Pending, 2)
+	assert.Equal(t, content.Pending[senderA][0].Hash(), result.Pending[0].Hash)
+	assert.Equal(t, content.Pending[senderA][1].Hash(), result.Pending[1].Hash)
+	require.Len(t, result.Queued, 1)
+	assert.Equal(t, content.Queued[senderA][0].Hash(), result.Queued[3].Hash)
+}
+
+func TestTxPoolInspect(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	content, senderA, senderB := newTxPoolTestContent()
+	m.Pool.
+		On("GetContent", context.Background(), uint64(0), s.Config.MaxTxPoolContentAccounts).
+		Return(content, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("txpool_inspect")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result inspectResponse
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	expected := "0x0000000000000000000000000000000000000001: 10 wei + 21000 gas × 1000000000 wei"
+	assert.Equal(t, expected, result.Pending[senderA][0])
+	assert.Equal(t, expected, result.Pending[senderA][1])
+	assert.Equal(t, expected, result.Queued[senderA][3])
+	assert.Equal(t, expected, result.Queued[senderB][7])
+}
+
+func TestTxPoolStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		ExpectedResult statusResponse
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "get status successfully",
+			ExpectedResult: statusResponse{Pending: 2, Queued: 5},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetStatus", context.Background()).
+					Return(pool.TxPoolStatus{Pending: 2, Queued: 5}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get status",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get pool status"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetStatus", context.Background()).
+					Return(pool.TxPoolStatus{}, errors.New("failed to get status")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("txpool_status")
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result statusResponse
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
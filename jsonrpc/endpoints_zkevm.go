@@ -451,6 +451,81 @@ func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Has
 	return tx, nil
 }
 
+// GetTransactionStatus returns the status of the tx in its lifecycle, from the
+// pool until its L2 block is verified, including the reason why it was
+// discarded by the sequencer if so
+func (z *ZKEVMEndpoints) GetTransactionStatus(hash types.ArgHash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		receipt, err := z.state.GetTransactionReceipt(ctx, hash.Hash(), dbTx)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction receipt from state", err, true)
+		}
+		if receipt != nil {
+			blockNumber := receipt.BlockNumber.Uint64()
+			batchNumber, err := z.state.BatchNumberByL2BlockNumber(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get batch number from block number", err, true)
+			}
+
+			status := types.TransactionStatusMined
+			verified, err := z.state.IsL2BlockConsolidated(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to check if the block is consolidated", err, true)
+			}
+			if verified {
+				status = types.TransactionStatusVerified
+			} else {
+				virtualized, err := z.state.IsL2BlockVirtualized(ctx, blockNumber, dbTx)
+				if err != nil {
+					return RPCErrorResponse(types.DefaultErrorCode, "failed to check if the block is virtualized", err, true)
+				}
+				if virtualized {
+					status = types.TransactionStatusVirtualized
+				}
+			}
+
+			return types.TransactionStatus{
+				Hash:        hash.Hash(),
+				Status:      status,
+				BlockNumber: state.Ptr(types.ArgUint64(blockNumber)),
+				BlockHash:   state.Ptr(receipt.BlockHash),
+				BatchNumber: state.Ptr(types.ArgUint64(batchNumber)),
+			}, nil
+		}
+
+		// if the tx does not exist in the state, look for it in the pool
+		if z.cfg.SequencerNodeURI != "" {
+			return z.getTransactionStatusFromSequencerNode(hash.Hash())
+		}
+		poolTx, err := z.pool.GetTransactionByHash(ctx, hash.Hash())
+		if errors.Is(err, pool.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by hash from pool", err, true)
+		}
+
+		return types.NewPoolTransactionStatus(*poolTx), nil
+	})
+}
+
+func (z *ZKEVMEndpoints) getTransactionStatusFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getTransactionStatus", hash.String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx status from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var status *types.TransactionStatus
+	err = json.Unmarshal(res.Result, &status)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx status from sequencer node", err, true)
+	}
+	return status, nil
+}
+
 // GetExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
 func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interface{}, types.Error) {
 	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -520,7 +595,7 @@ func (z *ZKEVMEndpoints) internalEstimateGasPriceAndFee(ctx context.Context, arg
 		return nil, nil, types.NewRPCError(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction")
 	}
 
-	gasEstimation, returnValue, err := z.state.EstimateGas(tx, sender, blockToProcess, dbTx)
+	gasEstimation, returnValue, err := z.state.EstimateGas(tx, sender, blockToProcess, nil, dbTx)
 	if errors.Is(err, runtime.ErrExecutionReverted) {
 		data := make([]byte, len(returnValue))
 		copy(data, returnValue)
@@ -616,31 +691,209 @@ func (z *ZKEVMEndpoints) EstimateCounters(arg *types.TxArgs, blockArg *types.Blo
 
 		var revert *types.RevertInfo
 		if len(processBatchResponse.BlockResponses) > 0 && len(processBatchResponse.BlockResponses[0].TransactionResponses) > 0 {
-			txResponse := processBatchResponse.BlockResponses[0].TransactionResponses[0]
-			err = txResponse.RomError
-			if errors.Is(err, runtime.ErrExecutionReverted) {
-				returnValue := make([]byte, len(txResponse.ReturnValue))
-				copy(returnValue, txResponse.ReturnValue)
-				err := state.ConstructErrorFromRevert(err, returnValue)
-				revert = &types.RevertInfo{
-					Message: err.Error(),
-					Data:    state.Ptr(types.ArgBytes(returnValue)),
+			revert = newRevertInfo(processBatchResponse.BlockResponses[0].TransactionResponses[0])
+		}
+
+		limits := z.zkCountersLimits(state.MaxTxGasLimit)
+		return types.NewZKCountersResponse(processBatchResponse.UsedZkCounters, limits, revert, oocErr), nil
+	})
+}
+
+// EstimateBundleCounters returns the zk counters used by an ordered bundle of
+// txs executed one after the other in a single L2 block on top of the state of
+// the given block, against the limits of a batch.
+// As the executor only reports the counters of the whole execution, the bundle
+// is executed once per tx, adding the txs one by one, to get the cumulative
+// counters until each tx and the counters of the tx as their difference with
+// the previous ones, so the executions are limited by the pool bundle size. The
+// execution stops at the first tx that makes the cumulative counters overflow
+// the limits, or runs out of counters in the executor. The executor runs the unsigned txs with a single sender, so the
+// txs without sender are sent by the sender of the bundle, the first one
+// provided, and the txs with a different one are rejected.
+func (z *ZKEVMEndpoints) EstimateBundleCounters(args []*types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if len(args) == 0 {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+		if maxBundleTxs := z.pool.MaxBundleTxs(); maxBundleTxs == 0 {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, pool.ErrBundlesDisabled.Error(), nil, false)
+		} else if uint64(len(args)) > maxBundleTxs {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("%v, max number of transactions allowed is %d", pool.ErrBundleTooLarge.Error(), maxBundleTxs), nil, false)
+		}
+
+		block, respErr := z.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		var blockToProcess *uint64
+		if blockArg != nil {
+			blockNumArg := blockArg.Number()
+			if blockNumArg != nil && (*blockArg.Number() == types.LatestBlockNumber || *blockArg.Number() == types.PendingBlockNumber) {
+				blockToProcess = nil
+			} else {
+				n := block.NumberU64()
+				blockToProcess = &n
+			}
+		}
+
+		sender := common.HexToAddress(state.DefaultSenderAddress)
+		for _, arg := range args {
+			if arg != nil && arg.From != nil && *arg.From != state.ZeroAddress {
+				sender = *arg.From
+				break
+			}
+		}
+		txs := make([]*ethTypes.Transaction, 0, len(args))
+		for i, arg := range args {
+			if arg == nil {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("missing transaction %d of the bundle", i), nil, false)
+			}
+			txSender, tx, err := arg.ToTransaction(ctx, z.state, z.cfg.MaxCumulativeGasUsed, block.Root(), sender, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to convert arguments of transaction %d into an unsigned transaction", i), err, false)
+			}
+			if txSender != sender {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, "all the transactions of the bundle must have the same sender", nil, false)
+			}
+			txs = append(txs, tx)
+		}
+
+		limits := z.zkCountersLimits(z.cfg.MaxCumulativeGasUsed)
+		maxCounters := state.ZKCounters{
+			GasUsed:          z.cfg.MaxCumulativeGasUsed,
+			KeccakHashes:     z.cfg.ZKCountersLimits.MaxKeccakHashes,
+			PoseidonHashes:   z.cfg.ZKCountersLimits.MaxPoseidonHashes,
+			PoseidonPaddings: z.cfg.ZKCountersLimits.MaxPoseidonPaddings,
+			MemAligns:        z.cfg.ZKCountersLimits.MaxMemAligns,
+			Arithmetics:      z.cfg.ZKCountersLimits.MaxArithmetics,
+			Binaries:         z.cfg.ZKCountersLimits.MaxBinaries,
+			Steps:            z.cfg.ZKCountersLimits.MaxSteps,
+			Sha256Hashes_V2:  z.cfg.ZKCountersLimits.MaxSHA256Hashes,
+		}
+
+		response := types.ZKCountersBundleResponse{
+			Transactions:   make([]types.ZKCountersBundleTxResponse, 0, len(txs)),
+			CountersLimits: limits,
+		}
+		var previousCounters state.ZKCounters
+		for i := range txs {
+			var oocErr error
+			processBatchResponse, err := z.state.PreProcessUnsignedTransactions(ctx, txs[:i+1], sender, blockToProcess, dbTx)
+			if err != nil {
+				if executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && processBatchResponse != nil {
+					oocErr = err
+				} else {
+					errMsg := fmt.Sprintf("failed to estimate counters of transaction %d: %v", i, err.Error())
+					return nil, types.NewRPCError(types.DefaultErrorCode, errMsg)
 				}
 			}
+
+			cumulativeCounters := processBatchResponse.UsedZkCounters
+			txResponse := types.ZKCountersBundleTxResponse{
+				CountersUsed:           types.NewZKCounters(subZKCounters(cumulativeCounters, previousCounters)),
+				CumulativeCountersUsed: types.NewZKCounters(cumulativeCounters),
+			}
+			if len(processBatchResponse.BlockResponses) > 0 && len(processBatchResponse.BlockResponses[0].TransactionResponses) > i {
+				txResponse.Revert = newRevertInfo(processBatchResponse.BlockResponses[0].TransactionResponses[i])
+			}
+			response.Transactions = append(response.Transactions, txResponse)
+			response.CountersUsed = txResponse.CumulativeCountersUsed
+			previousCounters = cumulativeCounters
+
+			// the limits can be exceeded without the executor running out of counters
+			if oocErr == nil {
+				if fits, counterName := maxCounters.Fits(cumulativeCounters); !fits {
+					oocErr = fmt.Errorf("%s counter exceeds the limit", counterName)
+				}
+			}
+			if oocErr != nil {
+				oocErrMsg := oocErr.Error()
+				response.OOCError = &oocErrMsg
+				response.FirstOverflowIndex = state.Ptr(types.ArgUint64(i))
+				break
+			}
+		}
+
+		return response, nil
+	})
+}
+
+// zkCountersLimits returns the configured zk counters limits with the provided gas limit
+func (z *ZKEVMEndpoints) zkCountersLimits(maxGasUsed uint64) types.ZKCountersLimits {
+	return types.ZKCountersLimits{
+		MaxGasUsed:          types.ArgUint64(maxGasUsed),
+		MaxKeccakHashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxKeccakHashes),
+		MaxPoseidonHashes:   types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonHashes),
+		MaxPoseidonPaddings: types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonPaddings),
+		MaxMemAligns:        types.ArgUint64(z.cfg.ZKCountersLimits.MaxMemAligns),
+		MaxArithmetics:      types.ArgUint64(z.cfg.ZKCountersLimits.MaxArithmetics),
+		MaxBinaries:         types.ArgUint64(z.cfg.ZKCountersLimits.MaxBinaries),
+		MaxSteps:            types.ArgUint64(z.cfg.ZKCountersLimits.MaxSteps),
+		MaxSHA256Hashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxSHA256Hashes),
+	}
+}
+
+// newRevertInfo returns the revert info of the tx if it was reverted
+func newRevertInfo(txResponse *state.ProcessTransactionResponse) *types.RevertInfo {
+	if !errors.Is(txResponse.RomError, runtime.ErrExecutionReverted) {
+		return nil
+	}
+	returnValue := make([]byte, len(txResponse.ReturnValue))
+	copy(returnValue, txResponse.ReturnValue)
+	err := state.ConstructErrorFromRevert(txResponse.RomError, returnValue)
+	return &types.RevertInfo{
+		Message: err.Error(),
+		Data:    state.Ptr(types.ArgBytes(returnValue)),
+	}
+}
+
+// subZKCounters returns the difference between the counters, flooring each one to zero
+func subZKCounters(a, b state.ZKCounters) state.ZKCounters {
+	sub := func(x, y uint32) uint32 {
+		if y > x {
+			return 0
+		}
+		return x - y
+	}
+	gasUsed := uint64(0)
+	if a.GasUsed > b.GasUsed {
+		gasUsed = a.GasUsed - b.GasUsed
+	}
+	return state.ZKCounters{
+		GasUsed:          gasUsed,
+		KeccakHashes:     sub(a.KeccakHashes, b.KeccakHashes),
+		PoseidonHashes:   sub(a.PoseidonHashes, b.PoseidonHashes),
+		PoseidonPaddings: sub(a.PoseidonPaddings, b.PoseidonPaddings),
+		MemAligns:        sub(a.MemAligns, b.MemAligns),
+		Arithmetics:      sub(a.Arithmetics, b.Arithmetics),
+		Binaries:         sub(a.Binaries, b.Binaries),
+		Steps:            sub(a.Steps, b.Steps),
+		Sha256Hashes_V2:  sub(a.Sha256Hashes_V2, b.Sha256Hashes_V2),
+	}
+}
+
+// GetProof returns the merkle tree proofs of the balance, nonce, code hash and
+// the provided storage keys of an account at the given block, which can be
+// verified against the block state root with merkletree.VerifyAccountProof
+func (z *ZKEVMEndpoints) GetProof(address types.ArgAddress, storageKeys []types.ArgHash, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		block, respErr := z.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
 		}
 
-		limits := types.ZKCountersLimits{
-			MaxGasUsed:          types.ArgUint64(state.MaxTxGasLimit),
-			MaxKeccakHashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxKeccakHashes),
-			MaxPoseidonHashes:   types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonHashes),
-			MaxPoseidonPaddings: types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonPaddings),
-			MaxMemAligns:        types.ArgUint64(z.cfg.ZKCountersLimits.MaxMemAligns),
-			MaxArithmetics:      types.ArgUint64(z.cfg.ZKCountersLimits.MaxArithmetics),
-			MaxBinaries:         types.ArgUint64(z.cfg.ZKCountersLimits.MaxBinaries),
-			MaxSteps:            types.ArgUint64(z.cfg.ZKCountersLimits.MaxSteps),
-			MaxSHA256Hashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxSHA256Hashes),
+		positions := make([]*big.Int, 0, len(storageKeys))
+		for _, storageKey := range storageKeys {
+			positions = append(positions, storageKey.Hash().Big())
 		}
-		return types.NewZKCountersResponse(processBatchResponse.UsedZkCounters, limits, revert, oocErr), nil
+
+		proof, err := z.state.GetAccountProof(ctx, address.Address(), positions, block.Root())
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get account proof from state", err, true)
+		}
+
+		return types.NewAccountProof(block.Root(), proof), nil
 	})
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 	"testing"
@@ -13,8 +14,10 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/test/operations"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -2705,3 +2708,433 @@ func TestGetLatestGlobalExitRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProof(t *testing.T) {
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	storageKey := common.HexToHash("0x1")
+	stateRoot := common.HexToHash("0x2")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10), Root: stateRoot}))
+
+	node := func(v uint64) []uint64 {
+		return []uint64{v, v + 1, v + 2, v + 3, v + 4, v + 5, v + 6, v + 7, 1, 0, 0, 0}
+	}
+	proof := &merkletree.AccountProof{
+		Address:  address,
+		Balance:  &merkletree.ValueProof{Value: big.NewInt(1000), Siblings: [][]uint64{node(1), node(2)}},
+		Nonce:    &merkletree.ValueProof{Value: big.NewInt(3), Siblings: [][]uint64{node(3)}},
+		CodeHash: &merkletree.ValueProof{Value: big.NewInt(0), Siblings: [][]uint64{}},
+		Storage: []merkletree.StorageProof{
+			{Position: storageKey.Big(), Proof: &merkletree.ValueProof{Value: big.NewInt(5), Siblings: [][]uint64{node(4)}}},
+		},
+	}
+
+	type testCase struct {
+		Name          string
+		ExpectedError types.Error
+		SetupMocks    func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name: "get proof successfully",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(10), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetAccountProof", context.Background(), address, []*big.Int{storageKey.Big()}, stateRoot).Return(proof, nil).Once()
+			},
+		},
+		{
+			Name:          "failed to get proof",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get account proof from state"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), uint64(10), m.DbTx).Return(block, nil).Once()
+				m.State.On("GetAccountProof", context.Background(), address, []*big.Int{storageKey.Big()}, stateRoot).Return(nil, errors.New("failed to get proof")).Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			result, err := zkEVMClient.GetProof(context.Background(), address, []common.Hash{storageKey}, nil)
+			if tc.ExpectedError != nil {
+				require.Error(t, err)
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, address, result.Address)
+			assert.Equal(t, stateRoot, result.StateRoot)
+			assert.Equal(t, uint64(3), uint64(result.Nonce))
+			require.Len(t, result.StorageProof, 1)
+			assert.Equal(t, storageKey, result.StorageProof[0].Key)
+
+			mtProof, err := result.MerkleTreeProof()
+			require.NoError(t, err)
+			assert.Equal(t, proof.Balance.Siblings, mtProof.Balance.Siblings)
+			assert.Equal(t, 0, proof.Balance.Value.Cmp(mtProof.Balance.Value))
+			assert.Equal(t, proof.Nonce.Siblings, mtProof.Nonce.Siblings)
+			assert.Equal(t, proof.CodeHash.Siblings, mtProof.CodeHash.Siblings)
+			assert.Equal(t, 0, proof.CodeHash.Value.Cmp(mtProof.CodeHash.Value))
+			assert.Equal(t, proof.Storage[0].Proof.Siblings, mtProof.Storage[0].Proof.Siblings)
+			assert.Equal(t, 0, proof.Storage[0].Position.Cmp(mtProof.Storage[0].Position))
+			assert.Equal(t, 0, proof.Storage[0].Proof.Value.Cmp(mtProof.Storage[0].Proof.Value))
+		})
+	}
+}
+
+func TestEstimateBundleCounters(t *testing.T) {
+	sender := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	otherSender := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	to := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	stateRoot := common.HexToHash("0x2")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10), Root: stateRoot}))
+
+	txArgs := func(from *common.Address) types.TxArgs {
+		return types.TxArgs{From: from, To: &to}
+	}
+	txsLen := func(n int) interface{} {
+		return mock.MatchedBy(func(txs []*ethTypes.Transaction) bool { return len(txs) == n })
+	}
+	processBatchResponse := func(steps uint32, txResponses ...*state.ProcessTransactionResponse) *state.ProcessBatchResponse {
+		return &state.ProcessBatchResponse{
+			UsedZkCounters: state.ZKCounters{GasUsed: uint64(21000 * len(txResponses)), Steps: steps},
+			BlockResponses: []*state.ProcessBlockResponse{{TransactionResponses: txResponses}},
+		}
+	}
+	revertedTx := &state.ProcessTransactionResponse{RomError: runtime.ErrExecutionReverted}
+
+	type testCase struct {
+		Name                string
+		Txs                 []types.TxArgs
+		ExpectedError       types.Error
+		ExpectedSteps       []uint64
+		ExpectedCumulative  []uint64
+		ExpectedReverted    []bool
+		ExpectedOverflowIdx *uint64
+		ExpectedOOCError    string
+		SetupMocks          func(m *mocksWrapper)
+	}
+
+	setupBlockMocks := func(m *mocksWrapper, nonceCalls int) {
+		m.Pool.On("MaxBundleTxs").Return(uint64(3)).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(uint64(10), nil).Once()
+		m.State.On("GetL2BlockByNumber", context.Background(), uint64(10), m.DbTx).Return(block, nil).Once()
+		m.State.On("GetNonce", context.Background(), mock.Anything, stateRoot).Return(uint64(0), nil).Times(nonceCalls)
+	}
+
+	testCases := []testCase{
+		{
+			Name:               "estimate counters of the bundle successfully",
+			Txs:                []types.TxArgs{txArgs(&sender), txArgs(&sender)},
+			ExpectedSteps:      []uint64{100, 150},
+			ExpectedCumulative: []uint64{100, 250},
+			ExpectedReverted:   []bool{false, true},
+			SetupMocks: func(m *mocksWrapper) {
+				setupBlockMocks(m, 2)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(1), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(100, &state.ProcessTransactionResponse{}), nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(2), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(250, &state.ProcessTransactionResponse{}, revertedTx), nil).Once()
+			},
+		},
+		{
+			Name:               "txs without sender are sent by the sender of the bundle",
+			Txs:                []types.TxArgs{txArgs(nil), txArgs(&sender)},
+			ExpectedSteps:      []uint64{100, 100},
+			ExpectedCumulative: []uint64{100, 200},
+			ExpectedReverted:   []bool{false, false},
+			SetupMocks: func(m *mocksWrapper) {
+				setupBlockMocks(m, 1)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(1), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(100, &state.ProcessTransactionResponse{}), nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(2), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(200, &state.ProcessTransactionResponse{}, &state.ProcessTransactionResponse{}), nil).Once()
+			},
+		},
+		{
+			Name:                "stop at the first tx overflowing the limits without running out of counters in the executor",
+			Txs:                 []types.TxArgs{txArgs(&sender), txArgs(&sender), txArgs(&sender)},
+			ExpectedSteps:       []uint64{100, 900},
+			ExpectedCumulative:  []uint64{100, 1000},
+			ExpectedReverted:    []bool{false, false},
+			ExpectedOverflowIdx: state.Ptr(uint64(1)),
+			ExpectedOOCError:    "UsedSteps counter exceeds the limit",
+			SetupMocks: func(m *mocksWrapper) {
+				setupBlockMocks(m, 3)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(1), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(100, &state.ProcessTransactionResponse{}), nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(2), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(1000, &state.ProcessTransactionResponse{}, &state.ProcessTransactionResponse{}), nil).Once()
+			},
+		},
+		{
+			Name:                "stop at the first tx running out of counters in the executor",
+			Txs:                 []types.TxArgs{txArgs(&sender), txArgs(&sender), txArgs(&sender)},
+			ExpectedSteps:       []uint64{100, 200},
+			ExpectedCumulative:  []uint64{100, 300},
+			ExpectedReverted:    []bool{false, false},
+			ExpectedOverflowIdx: state.Ptr(uint64(1)),
+			ExpectedOOCError:    runtime.ErrOutOfCountersStep.Error(),
+			SetupMocks: func(m *mocksWrapper) {
+				setupBlockMocks(m, 3)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(1), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(100, &state.ProcessTransactionResponse{}), nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(2), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(300, &state.ProcessTransactionResponse{}), runtime.ErrOutOfCountersStep).Once()
+			},
+		},
+		{
+			Name:          "txs of different senders",
+			Txs:           []types.TxArgs{txArgs(&sender), txArgs(&otherSender)},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "all the transactions of the bundle must have the same sender"),
+			SetupMocks: func(m *mocksWrapper) {
+				setupBlockMocks(m, 2)
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+			},
+		},
+		{
+			Name:          "bundle too large",
+			Txs:           []types.TxArgs{txArgs(&sender), txArgs(&sender), txArgs(&sender), txArgs(&sender)},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "bundle has too many transactions, max number of transactions allowed is 3"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.On("MaxBundleTxs").Return(uint64(3)).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+			},
+		},
+		{
+			Name:          "failed to process the bundle",
+			Txs:           []types.TxArgs{txArgs(&sender), txArgs(&sender)},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to estimate counters of transaction 1: failed to process"),
+			SetupMocks: func(m *mocksWrapper) {
+				setupBlockMocks(m, 2)
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(1), sender, (*uint64)(nil), m.DbTx).
+					Return(processBatchResponse(100, &state.ProcessTransactionResponse{}), nil).Once()
+				m.State.On("PreProcessUnsignedTransactions", context.Background(), txsLen(2), sender, (*uint64)(nil), m.DbTx).
+					Return(nil, errors.New("failed to process")).Once()
+			},
+		},
+	}
+
+	cfg := getSequencerDefaultConfig()
+	cfg.ZKCountersLimits = ZKCountersLimits{
+		MaxKeccakHashes:     math.MaxUint32,
+		MaxPoseidonHashes:   math.MaxUint32,
+		MaxPoseidonPaddings: math.MaxUint32,
+		MaxMemAligns:        math.MaxUint32,
+		MaxArithmetics:      math.MaxUint32,
+		MaxBinaries:         math.MaxUint32,
+		MaxSteps:            500,
+		MaxSHA256Hashes:     math.MaxUint32,
+	}
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			result, err := zkEVMClient.EstimateBundleCounters(context.Background(), tc.Txs, nil)
+			if tc.ExpectedError != nil {
+				require.Error(t, err)
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, result.Transactions, len(tc.ExpectedSteps))
+			for i, txCounters := range result.Transactions {
+				assert.Equal(t, tc.ExpectedSteps[i], uint64(txCounters.CountersUsed.UsedSteps))
+				assert.Equal(t, tc.ExpectedCumulative[i], uint64(txCounters.CumulativeCountersUsed.UsedSteps))
+				assert.Equal(t, tc.ExpectedReverted[i], txCounters.Revert != nil)
+			}
+			assert.Equal(t, tc.ExpectedCumulative[len(tc.ExpectedCumulative)-1], uint64(result.CountersUsed.UsedSteps))
+			assert.Equal(t, uint64(500), uint64(result.CountersLimits.MaxSteps))
+			assert.Equal(t, cfg.MaxCumulativeGasUsed, uint64(result.CountersLimits.MaxGasUsed))
+
+			if tc.ExpectedOverflowIdx == nil {
+				assert.Nil(t, result.FirstOverflowIndex)
+			} else {
+				require.NotNil(t, result.FirstOverflowIndex)
+				assert.Equal(t, *tc.ExpectedOverflowIdx, uint64(*result.FirstOverflowIndex))
+			}
+			if tc.ExpectedOOCError == "" {
+				assert.Nil(t, result.OOCError)
+			} else {
+				require.NotNil(t, result.OOCError)
+				assert.Equal(t, tc.ExpectedOOCError, *result.OOCError)
+			}
+		})
+	}
+}
+
+func TestGetTransactionStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	hash := tx.Hash()
+	blockHash := common.HexToHash("0x2")
+	receipt := &ethTypes.Receipt{TxHash: hash, BlockNumber: big.NewInt(5), BlockHash: blockHash}
+	expiredReason := "transaction expired"
+	invalidReason := "not enough step counters to continue the execution"
+
+	type testCase struct {
+		Name           string
+		ExpectedResult *types.TransactionStatus
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	setupStateMocks := func(m *mocksWrapper, consolidated, virtualized bool) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetTransactionReceipt", context.Background(), hash, m.DbTx).Return(receipt, nil).Once()
+		m.State.On("BatchNumberByL2BlockNumber", context.Background(), uint64(5), m.DbTx).Return(uint64(3), nil).Once()
+		m.State.On("IsL2BlockConsolidated", context.Background(), uint64(5), m.DbTx).Return(consolidated, nil).Once()
+		if !consolidated {
+			m.State.On("IsL2BlockVirtualized", context.Background(), uint64(5), m.DbTx).Return(virtualized, nil).Once()
+		}
+	}
+	setupPoolMocks := func(m *mocksWrapper, poolTx *pool.Transaction, err error) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetTransactionReceipt", context.Background(), hash, m.DbTx).Return(nil, state.ErrNotFound).Once()
+		m.Pool.On("GetTransactionByHash", context.Background(), hash).Return(poolTx, err).Once()
+	}
+	minedStatus := func(status string) *types.TransactionStatus {
+		return &types.TransactionStatus{
+			Hash:        hash,
+			Status:      status,
+			BlockNumber: state.Ptr(types.ArgUint64(5)),
+			BlockHash:   &blockHash,
+			BatchNumber: state.Ptr(types.ArgUint64(3)),
+		}
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "tx verified",
+			ExpectedResult: minedStatus(types.TransactionStatusVerified),
+			SetupMocks:     func(m *mocksWrapper) { setupStateMocks(m, true, true) },
+		},
+		{
+			Name:           "tx virtualized",
+			ExpectedResult: minedStatus(types.TransactionStatusVirtualized),
+			SetupMocks:     func(m *mocksWrapper) { setupStateMocks(m, false, true) },
+		},
+		{
+			Name:           "tx mined",
+			ExpectedResult: minedStatus(types.TransactionStatusMined),
+			SetupMocks:     func(m *mocksWrapper) { setupStateMocks(m, false, false) },
+		},
+		{
+			Name:           "tx pending in the pool",
+			ExpectedResult: &types.TransactionStatus{Hash: hash, Status: types.TransactionStatusPending},
+			SetupMocks: func(m *mocksWrapper) {
+				setupPoolMocks(m, &pool.Transaction{Transaction: *tx, Status: pool.TxStatusPending}, nil)
+			},
+		},
+		{
+			Name:           "tx loaded by the sequencer",
+			ExpectedResult: &types.TransactionStatus{Hash: hash, Status: types.TransactionStatusWIP},
+			SetupMocks: func(m *mocksWrapper) {
+				setupPoolMocks(m, &pool.Transaction{Transaction: *tx, Status: pool.TxStatusPending, IsWIP: true}, nil)
+			},
+		},
+		{
+			Name:           "tx selected by the sequencer",
+			ExpectedResult: &types.TransactionStatus{Hash: hash, Status: types.TransactionStatusSelected},
+			SetupMocks: func(m *mocksWrapper) {
+				setupPoolMocks(m, &pool.Transaction{Transaction: *tx, Status: pool.TxStatusSelected}, nil)
+			},
+		},
+		{
+			Name:           "tx expired",
+			ExpectedResult: &types.TransactionStatus{Hash: hash, Status: types.TransactionStatusFailed, FailedReason: &expiredReason},
+			SetupMocks: func(m *mocksWrapper) {
+				setupPoolMocks(m, &pool.Transaction{Transaction: *tx, Status: pool.TxStatusFailed, FailedReason: &expiredReason}, nil)
+			},
+		},
+		{
+			Name:           "tx invalid",
+			ExpectedResult: &types.TransactionStatus{Hash: hash, Status: types.TransactionStatusInvalid, FailedReason: &invalidReason},
+			SetupMocks: func(m *mocksWrapper) {
+				setupPoolMocks(m, &pool.Transaction{Transaction: *tx, Status: pool.TxStatusInvalid, FailedReason: &invalidReason}, nil)
+			},
+		},
+		{
+			Name:           "tx not found",
+			ExpectedResult: nil,
+			SetupMocks: func(m *mocksWrapper) {
+				setupPoolMocks(m, nil, pool.ErrNotFound)
+			},
+		},
+		{
+			Name:          "failed to load the receipt",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to load transaction receipt from state"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetTransactionReceipt", context.Background(), hash, m.DbTx).Return(nil, errors.New("failed to load receipt")).Once()
+			},
+		},
+		{
+			Name:          "failed to load the tx from the pool",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to load transaction by hash from pool"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetTransactionReceipt", context.Background(), hash, m.DbTx).Return(nil, state.ErrNotFound).Once()
+				m.Pool.On("GetTransactionByHash", context.Background(), hash).Return(nil, errors.New("failed to load tx")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			result, err := zkEVMClient.TransactionStatus(context.Background(), hash)
+			if tc.ExpectedError != nil {
+				require.Error(t, err)
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,1159 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+)
+
+const (
+	// graphQLRateLimitMethod is the method used to rate limit the GraphQL queries
+	graphQLRateLimitMethod = "graphql"
+	// graphQLFieldRateLimitMethod is the method used to rate limit every non
+	// trivial field resolved by a GraphQL query
+	graphQLFieldRateLimitMethod = "graphql_field"
+)
+
+// graphQLHTTPRequestKey is the context key of the HTTP request of a GraphQL query
+type graphQLHTTPRequestKey struct{}
+
+// graphQLQueryCostKey is the context key of the cost of a GraphQL query
+type graphQLQueryCostKey struct{}
+
+// newGraphQLSchema parses the GraphQL schema with the resolvers backed by the
+// eth and zkevm endpoints registered in the handler
+func newGraphQLSchema(cfg GraphQLConfig, handler *Handler) (*graphql.Schema, error) {
+	ethService, ethFound := handler.serviceMap[APIEth]
+	zkevmService, zkevmFound := handler.serviceMap[APIZKEVM]
+	if !ethFound || !zkevmFound {
+		return nil, fmt.Errorf("the GraphQL server requires the %v and %v APIs", APIEth, APIZKEVM)
+	}
+
+	resolver := &graphQLResolver{
+		cfg:   cfg,
+		eth:   ethService.sv.Interface().(*EthEndpoints),
+		zkevm: zkevmService.sv.Interface().(*ZKEVMEndpoints),
+	}
+	opts := []graphql.SchemaOpt{graphql.Tracer(graphQLCostTracer{})}
+	if cfg.MaxDepth > 0 {
+		opts = append(opts, graphql.MaxDepth(cfg.MaxDepth))
+	}
+	if cfg.MaxParallelism > 0 {
+		opts = append(opts, graphql.MaxParallelism(cfg.MaxParallelism))
+	}
+	return graphql.ParseSchema(graphQLSchema, resolver, opts...)
+}
+
+// handleGraphQL executes the GraphQL query of the request
+func (s *Server) handleGraphQL(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key")
+
+	if req.Method == http.MethodOptions {
+		return
+	}
+
+	if code, err := validateRequest(req); err != nil {
+		handleInvalidRequest(w, err, code)
+		return
+	}
+
+	// the schema exposes the data of the eth and zkevm namespaces
+	grant := s.authenticate(req)
+	for _, api := range []string{APIEth, APIZKEVM} {
+		if rpcErr := s.checkAuth(grant, api+"_graphql"); rpcErr != nil {
+			handleInvalidRequest(w, rpcErr, http.StatusUnauthorized)
+			return
+		}
+	}
+	if rpcErr := s.checkRateLimit(w, req, graphQLRateLimitMethod); rpcErr != nil {
+		handleInvalidRequest(w, rpcErr, http.StatusTooManyRequests)
+		return
+	}
+
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestContentLength)).Decode(&params); err != nil {
+		handleInvalidRequest(w, fmt.Errorf("invalid GraphQL request body"), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(req.Context(), graphQLHTTPRequestKey{}, req))
+	defer cancel()
+	cost := &graphQLQueryCost{maxComplexity: s.config.GraphQL.MaxComplexity, cancel: cancel}
+	if s.rateLimiter != nil {
+		cost.charge = func() types.Error {
+			return s.checkRateLimit(nil, req, graphQLFieldRateLimitMethod)
+		}
+	}
+	ctx = context.WithValue(ctx, graphQLQueryCostKey{}, cost)
+	response := s.graphQLSchema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+
+	statusCode := http.StatusBadRequest
+	// the query is aborted when it exceeds its complexity or the rate limit
+	if costErr := cost.error(); costErr != nil {
+		response = &graphql.Response{Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("%v", costErr)}}
+		if costErr.ErrorCode() == types.LimitExceededErrorCode {
+			statusCode = http.StatusTooManyRequests
+		}
+	}
+
+	respBytes, err := json.Marshal(response)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	if len(response.Errors) > 0 {
+		w.WriteHeader(statusCode)
+	}
+	if _, err := w.Write(respBytes); err != nil {
+		handleError(w, err)
+	}
+}
+
+// graphQLQueryCost tracks the cost of a GraphQL query, made of the non trivial
+// fields it resolves, which are the ones loading data from the endpoints
+type graphQLQueryCost struct {
+	maxComplexity uint64
+	// charge consumes the cost of a field from the rate limit of the client
+	charge func() types.Error
+	cancel context.CancelFunc
+
+	fields uint64
+	err    types.Error
+	mutex  sync.Mutex
+}
+
+// addField adds a resolved field to the cost of the query, which is cancelled
+// if it exceeds its complexity or the rate limit
+func (c *graphQLQueryCost) addField() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	c.fields++
+	if c.maxComplexity > 0 && c.fields > c.maxComplexity {
+		c.err = types.NewRPCError(types.InvalidParamsErrorCode, "query too complex, max number of fields resolved allowed is %d", c.maxComplexity)
+	} else if c.charge != nil {
+		c.err = c.charge()
+	}
+	if c.err != nil {
+		c.cancel()
+	}
+}
+
+// error returns the reason why the query was cancelled, if any
+func (c *graphQLQueryCost) error() types.Error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// graphQLCostTracer adds every non trivial field resolved by a query to its
+// cost, the resolvers of the query are not executed once it is cancelled
+type graphQLCostTracer struct{}
+
+// TraceQuery implements the trace.Tracer interface
+func (graphQLCostTracer) TraceQuery(ctx context.Context, _ string, _ string, _ map[string]interface{}, _ map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	return ctx, func([]*gqlerrors.QueryError) {}
+}
+
+// TraceField implements the trace.Tracer interface
+func (graphQLCostTracer) TraceField(ctx context.Context, _, _, _ string, trivial bool, _ map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	if cost, ok := ctx.Value(graphQLQueryCostKey{}).(*graphQLQueryCost); ok && !trivial {
+		cost.addField()
+	}
+	return ctx, func(*gqlerrors.QueryError) {}
+}
+
+// decodeEndpointResult converts the result of an endpoint into the provided
+// type, nil is returned when the endpoint doesn't find the requested data
+func decodeEndpointResult[T any](result interface{}, rpcErr types.Error) (*T, error) {
+	if rpcErr != nil {
+		return nil, rpcErr
+	} else if result == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var value *T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// graphQLResolver resolves the root queries and mutations
+type graphQLResolver struct {
+	cfg   GraphQLConfig
+	eth   *EthEndpoints
+	zkevm *ZKEVMEndpoints
+}
+
+func (r *graphQLResolver) latestBlockNumber() (uint64, error) {
+	number, err := decodeEndpointResult[hexutil.Uint64](r.eth.BlockNumber())
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*number), nil
+}
+
+func (r *graphQLResolver) blockByNumber(number types.BlockNumber) (*graphQLBlock, error) {
+	includeExtraInfo := true
+	block, err := decodeEndpointResult[types.Block](r.eth.GetBlockByNumber(number, true, &includeExtraInfo))
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &graphQLBlock{r: r, block: block}, nil
+}
+
+func (r *graphQLResolver) blockByHash(hash common.Hash) (*graphQLBlock, error) {
+	includeExtraInfo := true
+	block, err := decodeEndpointResult[types.Block](r.eth.GetBlockByHash(types.ArgHash(hash), true, &includeExtraInfo))
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &graphQLBlock{r: r, block: block}, nil
+}
+
+func (r *graphQLResolver) batchByNumber(number types.BatchNumber) (*graphQLBatch, error) {
+	batch, err := decodeEndpointResult[types.Batch](r.zkevm.GetBatchByNumber(number, true))
+	if err != nil || batch == nil {
+		return nil, err
+	}
+	return &graphQLBatch{r: r, batch: batch}, nil
+}
+
+func (r *graphQLResolver) account(address common.Address, blockNumber uint64) *graphQLAccount {
+	block := types.BlockNumberOrHash{}
+	block.SetNumber(types.BlockNumber(blockNumber))
+	return &graphQLAccount{r: r, address: address, block: block}
+}
+
+func (r *graphQLResolver) logs(filter LogFilter) ([]*graphQLLog, error) {
+	logs, err := decodeEndpointResult[[]types.Log](r.eth.GetLogs(filter))
+	if err != nil || logs == nil {
+		return nil, err
+	}
+	res := make([]*graphQLLog, 0, len(*logs))
+	for _, l := range *logs {
+		res = append(res, &graphQLLog{r: r, log: l})
+	}
+	return res, nil
+}
+
+// Block returns a block by number or hash, the latest one if none is provided
+func (r *graphQLResolver) Block(args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*graphQLBlock, error) {
+	if args.Number != nil && args.Hash != nil {
+		return nil, errors.New("only one of number or hash must be provided")
+	} else if args.Hash != nil {
+		return r.blockByHash(*args.Hash)
+	} else if args.Number != nil {
+		return r.blockByNumber(types.BlockNumber(*args.Number))
+	}
+	return r.blockByNumber(types.LatestBlockNumber)
+}
+
+// Blocks returns the blocks in a range, until the latest one if to is not provided
+func (r *graphQLResolver) Blocks(args struct {
+	From hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*graphQLBlock, error) {
+	from := uint64(args.From)
+	var to uint64
+	if args.To != nil {
+		to = uint64(*args.To)
+	} else {
+		latest, err := r.latestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		to = latest
+	}
+	if from > to {
+		return []*graphQLBlock{}, nil
+	} else if r.cfg.MaxBlockRange > 0 && to-from >= r.cfg.MaxBlockRange {
+		return nil, fmt.Errorf("block range too large, max range allowed is %v", r.cfg.MaxBlockRange)
+	}
+
+	blocks := make([]*graphQLBlock, 0, to-from+1)
+	for number := from; number <= to; number++ {
+		block, err := r.blockByNumber(types.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		} else if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// Transaction returns a transaction by hash
+func (r *graphQLResolver) Transaction(args struct{ Hash common.Hash }) (*graphQLTransaction, error) {
+	t := &graphQLTransaction{r: r, hash: args.Hash}
+	tx, err := t.resolve()
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Logs returns the logs matching the filter
+func (r *graphQLResolver) Logs(args struct{ Filter graphQLFilterCriteria }) ([]*graphQLLog, error) {
+	filter := LogFilter{Addresses: args.Filter.addresses(), Topics: args.Filter.topics()}
+	if args.Filter.FromBlock != nil {
+		fromBlock := types.BlockNumber(*args.Filter.FromBlock)
+		filter.FromBlock = &fromBlock
+	}
+	if args.Filter.ToBlock != nil {
+		toBlock := types.BlockNumber(*args.Filter.ToBlock)
+		filter.ToBlock = &toBlock
+	}
+	return r.logs(filter)
+}
+
+// GasPrice returns the suggested gas price
+func (r *graphQLResolver) GasPrice() (hexutil.Big, error) {
+	gasPrice, err := decodeEndpointResult[hexutil.Big](r.eth.GasPrice())
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *gasPrice, nil
+}
+
+// ChainID returns the chain id of the network
+func (r *graphQLResolver) ChainID() (hexutil.Big, error) {
+	chainID, err := decodeEndpointResult[hexutil.Big](r.eth.ChainId())
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *chainID, nil
+}
+
+// Batch returns a batch by number, the latest one if not provided
+func (r *graphQLResolver) Batch(args struct{ Number *hexutil.Uint64 }) (*graphQLBatch, error) {
+	if args.Number != nil {
+		return r.batchByNumber(types.BatchNumber(*args.Number))
+	}
+	return r.batchByNumber(types.LatestBatchNumber)
+}
+
+// BatchNumber returns the latest trusted batch number
+func (r *graphQLResolver) BatchNumber() (hexutil.Uint64, error) {
+	number, err := decodeEndpointResult[hexutil.Uint64](r.zkevm.BatchNumber())
+	if err != nil {
+		return 0, err
+	}
+	return *number, nil
+}
+
+// VirtualBatchNumber returns the latest virtualized batch number
+func (r *graphQLResolver) VirtualBatchNumber() (hexutil.Uint64, error) {
+	number, err := decodeEndpointResult[hexutil.Uint64](r.zkevm.VirtualBatchNumber())
+	if err != nil {
+		return 0, err
+	}
+	return *number, nil
+}
+
+// VerifiedBatchNumber returns the latest verified batch number
+func (r *graphQLResolver) VerifiedBatchNumber() (hexutil.Uint64, error) {
+	number, err := decodeEndpointResult[hexutil.Uint64](r.zkevm.VerifiedBatchNumber())
+	if err != nil {
+		return 0, err
+	}
+	return *number, nil
+}
+
+// LatestGlobalExitRoot returns the last global exit root used by l2
+func (r *graphQLResolver) LatestGlobalExitRoot() (common.Hash, error) {
+	ger, err := decodeEndpointResult[common.Hash](r.zkevm.GetLatestGlobalExitRoot())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return *ger, nil
+}
+
+// ExitRootsByGER returns the exit roots of the provided global exit root
+func (r *graphQLResolver) ExitRootsByGER(args struct{ GlobalExitRoot common.Hash }) (*graphQLExitRoots, error) {
+	exitRoots, err := decodeEndpointResult[types.ExitRoots](r.zkevm.GetExitRootsByGER(args.GlobalExitRoot))
+	if err != nil || exitRoots == nil {
+		return nil, err
+	}
+	return &graphQLExitRoots{exitRoots: *exitRoots}, nil
+}
+
+// SendRawTransaction sends an RLP encoded transaction to the pool
+func (r *graphQLResolver) SendRawTransaction(ctx context.Context, args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	httpRequest, _ := ctx.Value(graphQLHTTPRequestKey{}).(*http.Request)
+	hash, err := decodeEndpointResult[common.Hash](r.eth.SendRawTransaction(httpRequest, hex.EncodeToHex(args.Data)))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return *hash, nil
+}
+
+// graphQLFilterCriteria are the log filter params of a GraphQL query
+type graphQLFilterCriteria struct {
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+	Addresses *[]common.Address
+	Topics    *[][]common.Hash
+}
+
+func (f graphQLFilterCriteria) addresses() []common.Address {
+	if f.Addresses == nil {
+		return nil
+	}
+	return *f.Addresses
+}
+
+func (f graphQLFilterCriteria) topics() [][]common.Hash {
+	if f.Topics == nil {
+		return nil
+	}
+	return *f.Topics
+}
+
+// graphQLAccount resolves an account at a block
+type graphQLAccount struct {
+	r       *graphQLResolver
+	address common.Address
+	block   types.BlockNumberOrHash
+}
+
+// Address returns the address of the account
+func (a *graphQLAccount) Address() common.Address {
+	return a.address
+}
+
+// Balance returns the balance of the account
+func (a *graphQLAccount) Balance() (hexutil.Big, error) {
+	balance, err := decodeEndpointResult[hexutil.Big](a.r.eth.GetBalance(types.ArgAddress(a.address), &a.block))
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *balance, nil
+}
+
+// TransactionCount returns the nonce of the account
+func (a *graphQLAccount) TransactionCount() (hexutil.Uint64, error) {
+	nonce, err := decodeEndpointResult[hexutil.Uint64](a.r.eth.GetTransactionCount(types.ArgAddress(a.address), &a.block))
+	if err != nil {
+		return 0, err
+	}
+	return *nonce, nil
+}
+
+// Code returns the code of the account
+func (a *graphQLAccount) Code() (hexutil.Bytes, error) {
+	code, err := decodeEndpointResult[hexutil.Bytes](a.r.eth.GetCode(types.ArgAddress(a.address), &a.block))
+	if err != nil {
+		return nil, err
+	}
+	return *code, nil
+}
+
+// Storage returns the value of a storage slot of the account
+func (a *graphQLAccount) Storage(args struct{ Slot common.Hash }) (common.Hash, error) {
+	value, err := decodeEndpointResult[common.Hash](a.r.eth.GetStorageAt(types.ArgAddress(a.address), args.Slot.String(), &a.block))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return *value, nil
+}
+
+// graphQLLog resolves a log
+type graphQLLog struct {
+	r   *graphQLResolver
+	log types.Log
+}
+
+// Index returns the index of the log in the block
+func (l *graphQLLog) Index() hexutil.Uint64 {
+	return hexutil.Uint64(l.log.LogIndex)
+}
+
+// Account returns the contract account which generated the log
+func (l *graphQLLog) Account() *graphQLAccount {
+	return l.r.account(l.log.Address, uint64(l.log.BlockNumber))
+}
+
+// Topics returns the topics of the log
+func (l *graphQLLog) Topics() []common.Hash {
+	return l.log.Topics
+}
+
+// Data returns the data of the log
+func (l *graphQLLog) Data() hexutil.Bytes {
+	return hexutil.Bytes(l.log.Data)
+}
+
+// Transaction returns the transaction which generated the log
+func (l *graphQLLog) Transaction() *graphQLTransaction {
+	return &graphQLTransaction{r: l.r, hash: l.log.TxHash}
+}
+
+// graphQLTransaction resolves a transaction, which is loaded by hash when
+// it is not provided
+type graphQLTransaction struct {
+	r    *graphQLResolver
+	hash common.Hash
+
+	tx      *types.Transaction
+	receipt *types.Receipt
+	mutex   sync.Mutex
+}
+
+func (t *graphQLTransaction) resolve() (*types.Transaction, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.tx != nil {
+		return t.tx, nil
+	}
+	includeExtraInfo := true
+	tx, err := decodeEndpointResult[types.Transaction](t.r.eth.GetTransactionByHash(types.ArgHash(t.hash), &includeExtraInfo))
+	if err != nil {
+		return nil, err
+	}
+	t.tx = tx
+	return tx, nil
+}
+
+func (t *graphQLTransaction) mustResolve() (*types.Transaction, error) {
+	tx, err := t.resolve()
+	if err != nil {
+		return nil, err
+	} else if tx == nil {
+		return nil, fmt.Errorf("transaction %v not found", t.hash.String())
+	}
+	return tx, nil
+}
+
+func (t *graphQLTransaction) resolveReceipt() (*types.Receipt, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.receipt != nil {
+		return t.receipt, nil
+	}
+	receipt, err := decodeEndpointResult[types.Receipt](t.r.eth.GetTransactionReceipt(types.ArgHash(t.hash)))
+	if err != nil {
+		return nil, err
+	}
+	t.receipt = receipt
+	return receipt, nil
+}
+
+// Hash returns the hash of the transaction
+func (t *graphQLTransaction) Hash() common.Hash {
+	return t.hash
+}
+
+// L2Hash returns the hash of the transaction computed by the zkEVM
+func (t *graphQLTransaction) L2Hash() (*common.Hash, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return nil, err
+	}
+	return tx.L2Hash, nil
+}
+
+// Nonce returns the nonce of the transaction
+func (t *graphQLTransaction) Nonce() (hexutil.Uint64, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce), nil
+}
+
+// Index returns the index of the transaction in the block
+func (t *graphQLTransaction) Index() (*hexutil.Uint64, error) {
+	tx, err := t.mustResolve()
+	if err != nil || tx.TxIndex == nil {
+		return nil, err
+	}
+	index := hexutil.Uint64(*tx.TxIndex)
+	return &index, nil
+}
+
+// From returns the account that sent the transaction
+func (t *graphQLTransaction) From() (*graphQLAccount, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return nil, err
+	}
+	return t.r.account(tx.From, t.accountBlockNumber(tx)), nil
+}
+
+// To returns the account the transaction was sent to
+func (t *graphQLTransaction) To() (*graphQLAccount, error) {
+	tx, err := t.mustResolve()
+	if err != nil || tx.To == nil {
+		return nil, err
+	}
+	return t.r.account(*tx.To, t.accountBlockNumber(tx)), nil
+}
+
+// accountBlockNumber returns the block used to resolve the accounts of the
+// transaction, the latest one if it is not mined yet
+func (t *graphQLTransaction) accountBlockNumber(tx *types.Transaction) uint64 {
+	if tx.BlockNumber == nil {
+		latest, err := t.r.latestBlockNumber()
+		if err != nil {
+			return 0
+		}
+		return latest
+	}
+	return uint64(*tx.BlockNumber)
+}
+
+// Value returns the value sent with the transaction
+func (t *graphQLTransaction) Value() (hexutil.Big, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.Value), nil
+}
+
+// GasPrice returns the gas price of the transaction
+func (t *graphQLTransaction) GasPrice() (hexutil.Big, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.GasPrice), nil
+}
+
+// Gas returns the gas limit of the transaction
+func (t *graphQLTransaction) Gas() (hexutil.Uint64, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Gas), nil
+}
+
+// InputData returns the data of the transaction
+func (t *graphQLTransaction) InputData() (hexutil.Bytes, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(tx.Input), nil
+}
+
+// Block returns the block the transaction was mined in
+func (t *graphQLTransaction) Block() (*graphQLBlock, error) {
+	tx, err := t.mustResolve()
+	if err != nil || tx.BlockHash == nil {
+		return nil, err
+	}
+	return t.r.blockByHash(*tx.BlockHash)
+}
+
+// Status returns the status of the transaction
+func (t *graphQLTransaction) Status() (*hexutil.Uint64, error) {
+	receipt, err := t.resolveReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(receipt.Status)
+	return &status, nil
+}
+
+// GasUsed returns the gas used by the transaction
+func (t *graphQLTransaction) GasUsed() (*hexutil.Uint64, error) {
+	receipt, err := t.resolveReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	gasUsed := hexutil.Uint64(receipt.GasUsed)
+	return &gasUsed, nil
+}
+
+// CumulativeGasUsed returns the gas used in the block up to and including the transaction
+func (t *graphQLTransaction) CumulativeGasUsed() (*hexutil.Uint64, error) {
+	receipt, err := t.resolveReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	cumulativeGasUsed := hexutil.Uint64(receipt.CumulativeGasUsed)
+	return &cumulativeGasUsed, nil
+}
+
+// EffectiveGasPrice returns the gas price paid by the transaction
+func (t *graphQLTransaction) EffectiveGasPrice() (*hexutil.Big, error) {
+	receipt, err := t.resolveReceipt()
+	if err != nil || receipt == nil || receipt.EffectiveGasPrice == nil {
+		return nil, err
+	}
+	effectiveGasPrice := hexutil.Big(*receipt.EffectiveGasPrice)
+	return &effectiveGasPrice, nil
+}
+
+// CreatedContract returns the account created by the transaction
+func (t *graphQLTransaction) CreatedContract() (*graphQLAccount, error) {
+	receipt, err := t.resolveReceipt()
+	if err != nil || receipt == nil || receipt.ContractAddress == nil {
+		return nil, err
+	}
+	return t.r.account(*receipt.ContractAddress, uint64(receipt.BlockNumber)), nil
+}
+
+// Logs returns the logs emitted by the transaction
+func (t *graphQLTransaction) Logs() (*[]*graphQLLog, error) {
+	receipt, err := t.resolveReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	logs := make([]*graphQLLog, 0, len(receipt.Logs))
+	for _, l := range receipt.Logs {
+		logs = append(logs, &graphQLLog{r: t.r, log: types.NewLog(*l)})
+	}
+	return &logs, nil
+}
+
+// R returns the r value of the signature of the transaction
+func (t *graphQLTransaction) R() (hexutil.Big, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.R), nil
+}
+
+// S returns the s value of the signature of the transaction
+func (t *graphQLTransaction) S() (hexutil.Big, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.S), nil
+}
+
+// V returns the v value of the signature of the transaction
+func (t *graphQLTransaction) V() (hexutil.Big, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.V), nil
+}
+
+// Type returns the type of the transaction
+func (t *graphQLTransaction) Type() (hexutil.Uint64, error) {
+	tx, err := t.mustResolve()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Type), nil
+}
+
+// graphQLBlock resolves a block
+type graphQLBlock struct {
+	r     *graphQLResolver
+	block *types.Block
+}
+
+func (b *graphQLBlock) hash() common.Hash {
+	if b.block.Hash == nil {
+		return common.Hash{}
+	}
+	return *b.block.Hash
+}
+
+// Number returns the number of the block
+func (b *graphQLBlock) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.Number)
+}
+
+// Hash returns the hash of the block
+func (b *graphQLBlock) Hash() common.Hash {
+	return b.hash()
+}
+
+// Parent returns the parent of the block
+func (b *graphQLBlock) Parent() (*graphQLBlock, error) {
+	if b.block.Number == 0 {
+		return nil, nil
+	}
+	return b.r.blockByHash(b.block.ParentHash)
+}
+
+// Nonce returns the nonce of the block
+func (b *graphQLBlock) Nonce() hexutil.Bytes {
+	if b.block.Nonce == nil {
+		return hexutil.Bytes{}
+	}
+	return hexutil.Bytes(*b.block.Nonce)
+}
+
+// TransactionsRoot returns the transactions root of the block
+func (b *graphQLBlock) TransactionsRoot() common.Hash {
+	return b.block.TxRoot
+}
+
+// TransactionCount returns the number of transactions of the block
+func (b *graphQLBlock) TransactionCount() hexutil.Uint64 {
+	return hexutil.Uint64(len(b.block.Transactions))
+}
+
+// StateRoot returns the state root of the block
+func (b *graphQLBlock) StateRoot() common.Hash {
+	return b.block.StateRoot
+}
+
+// ReceiptsRoot returns the receipts root of the block
+func (b *graphQLBlock) ReceiptsRoot() common.Hash {
+	return b.block.ReceiptsRoot
+}
+
+// Miner returns the coinbase account of the block
+func (b *graphQLBlock) Miner() *graphQLAccount {
+	var miner common.Address
+	if b.block.Miner != nil {
+		miner = *b.block.Miner
+	}
+	return b.r.account(miner, uint64(b.block.Number))
+}
+
+// ExtraData returns the extra data of the block
+func (b *graphQLBlock) ExtraData() hexutil.Bytes {
+	return hexutil.Bytes(b.block.ExtraData)
+}
+
+// GasLimit returns the gas limit of the block
+func (b *graphQLBlock) GasLimit() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.GasLimit)
+}
+
+// GasUsed returns the gas used by the block
+func (b *graphQLBlock) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.GasUsed)
+}
+
+// Timestamp returns the timestamp of the block
+func (b *graphQLBlock) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.Timestamp)
+}
+
+// LogsBloom returns the logs bloom of the block
+func (b *graphQLBlock) LogsBloom() hexutil.Bytes {
+	return hexutil.Bytes(b.block.LogsBloom.Bytes())
+}
+
+// MixHash returns the mix hash of the block
+func (b *graphQLBlock) MixHash() common.Hash {
+	return b.block.MixHash
+}
+
+// Difficulty returns the difficulty of the block
+func (b *graphQLBlock) Difficulty() hexutil.Big {
+	return hexutil.Big(*big.NewInt(0).SetUint64(uint64(b.block.Difficulty)))
+}
+
+// Transactions returns the transactions of the block
+func (b *graphQLBlock) Transactions() []*graphQLTransaction {
+	txs := make([]*graphQLTransaction, 0, len(b.block.Transactions))
+	for _, tx := range b.block.Transactions {
+		txs = append(txs, newGraphQLTransaction(b.r, tx))
+	}
+	return txs
+}
+
+// TransactionAt returns the transaction of the block at the provided index
+func (b *graphQLBlock) TransactionAt(args struct{ Index hexutil.Uint64 }) *graphQLTransaction {
+	if uint64(args.Index) >= uint64(len(b.block.Transactions)) {
+		return nil
+	}
+	return newGraphQLTransaction(b.r, b.block.Transactions[args.Index])
+}
+
+// Logs returns the logs of the block matching the filter
+func (b *graphQLBlock) Logs(args struct{ Filter graphQLFilterCriteria }) ([]*graphQLLog, error) {
+	hash := b.hash()
+	return b.r.logs(LogFilter{BlockHash: &hash, Addresses: args.Filter.addresses(), Topics: args.Filter.topics()})
+}
+
+// Account returns an account at the block
+func (b *graphQLBlock) Account(args struct{ Address common.Address }) *graphQLAccount {
+	return b.r.account(args.Address, uint64(b.block.Number))
+}
+
+// GlobalExitRoot returns the global exit root used by the block
+func (b *graphQLBlock) GlobalExitRoot() *common.Hash {
+	return b.block.GlobalExitRoot
+}
+
+// BlockInfoRoot returns the root of the block info tree of the block
+func (b *graphQLBlock) BlockInfoRoot() *common.Hash {
+	return b.block.BlockInfoRoot
+}
+
+// Batch returns the batch the block belongs to
+func (b *graphQLBlock) Batch() (*graphQLBatch, error) {
+	batchNumber, err := decodeEndpointResult[hexutil.Uint64](b.r.zkevm.BatchNumberByBlockNumber(b.block.Number))
+	if err != nil || batchNumber == nil {
+		return nil, err
+	}
+	return b.r.batchByNumber(types.BatchNumber(*batchNumber))
+}
+
+// Virtualized returns true if the batch of the block has been sequenced on L1
+func (b *graphQLBlock) Virtualized() (bool, error) {
+	virtualized, err := decodeEndpointResult[bool](b.r.zkevm.IsBlockVirtualized(b.block.Number))
+	if err != nil {
+		return false, err
+	}
+	return *virtualized, nil
+}
+
+// Consolidated returns true if the batch of the block has been verified on L1
+func (b *graphQLBlock) Consolidated() (bool, error) {
+	consolidated, err := decodeEndpointResult[bool](b.r.zkevm.IsBlockConsolidated(b.block.Number))
+	if err != nil {
+		return false, err
+	}
+	return *consolidated, nil
+}
+
+func newGraphQLTransaction(r *graphQLResolver, tx types.TransactionOrHash) *graphQLTransaction {
+	if tx.Tx != nil {
+		return &graphQLTransaction{r: r, hash: tx.Tx.Hash, tx: tx.Tx}
+	}
+	return &graphQLTransaction{r: r, hash: *tx.Hash}
+}
+
+// graphQLBatch resolves a batch
+type graphQLBatch struct {
+	r     *graphQLResolver
+	batch *types.Batch
+}
+
+// Number returns the number of the batch
+func (b *graphQLBatch) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.batch.Number)
+}
+
+// ForcedBatchNumber returns the number of the forced batch
+func (b *graphQLBatch) ForcedBatchNumber() *hexutil.Uint64 {
+	if b.batch.ForcedBatchNumber == nil {
+		return nil
+	}
+	number := hexutil.Uint64(*b.batch.ForcedBatchNumber)
+	return &number
+}
+
+// Coinbase returns the coinbase of the batch
+func (b *graphQLBatch) Coinbase() common.Address {
+	return b.batch.Coinbase
+}
+
+// StateRoot returns the state root of the batch
+func (b *graphQLBatch) StateRoot() common.Hash {
+	return b.batch.StateRoot
+}
+
+// GlobalExitRoot returns the global exit root of the batch
+func (b *graphQLBatch) GlobalExitRoot() common.Hash {
+	return b.batch.GlobalExitRoot
+}
+
+// MainnetExitRoot returns the mainnet exit root of the batch
+func (b *graphQLBatch) MainnetExitRoot() common.Hash {
+	return b.batch.MainnetExitRoot
+}
+
+// RollupExitRoot returns the rollup exit root of the batch
+func (b *graphQLBatch) RollupExitRoot() common.Hash {
+	return b.batch.RollupExitRoot
+}
+
+// LocalExitRoot returns the local exit root of the batch
+func (b *graphQLBatch) LocalExitRoot() common.Hash {
+	return b.batch.LocalExitRoot
+}
+
+// AccInputHash returns the accumulated input hash of the batch
+func (b *graphQLBatch) AccInputHash() common.Hash {
+	return b.batch.AccInputHash
+}
+
+// Timestamp returns the timestamp of the batch
+func (b *graphQLBatch) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.batch.Timestamp)
+}
+
+// SendSequencesTxHash returns the L1 transaction that virtualized the batch
+func (b *graphQLBatch) SendSequencesTxHash() *common.Hash {
+	return b.batch.SendSequencesTxHash
+}
+
+// VerifyBatchTxHash returns the L1 transaction that verified the batch
+func (b *graphQLBatch) VerifyBatchTxHash() *common.Hash {
+	return b.batch.VerifyBatchTxHash
+}
+
+// Closed returns true if the batch is closed
+func (b *graphQLBatch) Closed() bool {
+	return b.batch.Closed
+}
+
+// Virtualized returns true if the batch has been sequenced on L1
+func (b *graphQLBatch) Virtualized() bool {
+	return b.batch.SendSequencesTxHash != nil
+}
+
+// Verified returns true if the batch has been verified on L1
+func (b *graphQLBatch) Verified() bool {
+	return b.batch.VerifyBatchTxHash != nil
+}
+
+// Blocks returns the blocks of the batch
+func (b *graphQLBatch) Blocks() ([]*graphQLBlock, error) {
+	blocks := make([]*graphQLBlock, 0, len(b.batch.Blocks))
+	for _, block := range b.batch.Blocks {
+		if block.Block != nil {
+			blocks = append(blocks, &graphQLBlock{r: b.r, block: block.Block})
+			continue
+		}
+		resolved, err := b.r.blockByHash(*block.Hash)
+		if err != nil {
+			return nil, err
+		} else if resolved != nil {
+			blocks = append(blocks, resolved)
+		}
+	}
+	return blocks, nil
+}
+
+// Transactions returns the transactions of the batch
+func (b *graphQLBatch) Transactions() []*graphQLTransaction {
+	txs := make([]*graphQLTransaction, 0, len(b.batch.Transactions))
+	for _, tx := range b.batch.Transactions {
+		txs = append(txs, newGraphQLTransaction(b.r, tx))
+	}
+	return txs
+}
+
+// BatchL2Data returns the L2 data of the batch
+func (b *graphQLBatch) BatchL2Data() hexutil.Bytes {
+	return hexutil.Bytes(b.batch.BatchL2Data)
+}
+
+// ZkCounters returns the ZK counters consumed by the batch
+func (b *graphQLBatch) ZkCounters(ctx context.Context) (*graphQLZKCounters, error) {
+	batch, err := b.r.zkevm.state.GetBatchByNumber(ctx, uint64(b.batch.Number), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the ZK counters of the batch %v: %w", uint64(b.batch.Number), err)
+	}
+	return &graphQLZKCounters{counters: batch.Resources.ZKCounters}, nil
+}
+
+// graphQLZKCounters resolves the ZK counters of a batch
+type graphQLZKCounters struct {
+	counters state.ZKCounters
+}
+
+// GasUsed returns the gas used by the batch
+func (c *graphQLZKCounters) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.GasUsed)
+}
+
+// KeccakHashes returns the keccak hashes used by the batch
+func (c *graphQLZKCounters) KeccakHashes() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.KeccakHashes)
+}
+
+// PoseidonHashes returns the poseidon hashes used by the batch
+func (c *graphQLZKCounters) PoseidonHashes() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.PoseidonHashes)
+}
+
+// PoseidonPaddings returns the poseidon paddings used by the batch
+func (c *graphQLZKCounters) PoseidonPaddings() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.PoseidonPaddings)
+}
+
+// MemAligns returns the mem aligns used by the batch
+func (c *graphQLZKCounters) MemAligns() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.MemAligns)
+}
+
+// Arithmetics returns the arithmetics used by the batch
+func (c *graphQLZKCounters) Arithmetics() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.Arithmetics)
+}
+
+// Binaries returns the binaries used by the batch
+func (c *graphQLZKCounters) Binaries() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.Binaries)
+}
+
+// Steps returns the steps used by the batch
+func (c *graphQLZKCounters) Steps() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.Steps)
+}
+
+// Sha256Hashes returns the sha256 hashes used by the batch
+func (c *graphQLZKCounters) Sha256Hashes() hexutil.Uint64 {
+	return hexutil.Uint64(c.counters.Sha256Hashes_V2)
+}
+
+// graphQLExitRoots resolves the exit roots of a global exit root
+type graphQLExitRoots struct {
+	exitRoots types.ExitRoots
+}
+
+// BlockNumber returns the L1 block number of the global exit root
+func (e *graphQLExitRoots) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(e.exitRoots.BlockNumber)
+}
+
+// Timestamp returns the timestamp of the global exit root
+func (e *graphQLExitRoots) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(e.exitRoots.Timestamp)
+}
+
+// MainnetExitRoot returns the mainnet exit root
+func (e *graphQLExitRoots) MainnetExitRoot() common.Hash {
+	return e.exitRoots.MainnetExitRoot
+}
+
+// RollupExitRoot returns the rollup exit root
+func (e *graphQLExitRoots) RollupExitRoot() common.Hash {
+	return e.exitRoots.RollupExitRoot
+}
@@ -0,0 +1,191 @@
+package jsonrpc
+
+// graphQLSchema is the EIP-1767 schema supported by the GraphQL server, extended
+// with the zkEVM batches, virtual and verified status, exit roots and ZK counters
+const graphQLSchema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes
+    # BigInt is a large integer, represented as 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer, input is accepted as a JSON number or as
+    # a 0x-prefixed hexadecimal string and outputs are 0x-prefixed hexadecimal.
+    scalar Long
+
+    schema {
+        query: Query
+        mutation: Mutation
+    }
+
+    # Account is an account at a particular L2 block.
+    type Account {
+        address: Address!
+        balance: BigInt!
+        transactionCount: Long!
+        code: Bytes!
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Long!
+        # Account is the contract account which generated this log.
+        account: Account!
+        topics: [Bytes32!]!
+        data: Bytes!
+        transaction: Transaction!
+    }
+
+    # Transaction is an L2 transaction.
+    type Transaction {
+        hash: Bytes32!
+        # L2Hash is the hash of the transaction computed by the zkEVM.
+        l2Hash: Bytes32
+        nonce: Long!
+        # Index is the index of this transaction in the block, null if it is not mined yet.
+        index: Long
+        from: Account!
+        # To is null for contract creation transactions.
+        to: Account
+        value: BigInt!
+        gasPrice: BigInt!
+        gas: Long!
+        inputData: Bytes!
+        # Block is the block this transaction was mined in, null if it is not mined yet.
+        block: Block
+        # The receipt fields are null if the transaction is not mined yet.
+        status: Long
+        gasUsed: Long
+        cumulativeGasUsed: Long
+        effectiveGasPrice: BigInt
+        createdContract: Account
+        logs: [Log!]
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+        type: Long!
+    }
+
+    # BlockFilterCriteria filters the logs of a single block.
+    input BlockFilterCriteria {
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is an L2 block.
+    type Block {
+        number: Long!
+        hash: Bytes32!
+        parent: Block
+        nonce: Bytes!
+        transactionsRoot: Bytes32!
+        transactionCount: Long!
+        stateRoot: Bytes32!
+        receiptsRoot: Bytes32!
+        miner: Account!
+        extraData: Bytes!
+        gasLimit: Long!
+        gasUsed: Long!
+        timestamp: Long!
+        logsBloom: Bytes!
+        mixHash: Bytes32!
+        difficulty: BigInt!
+        transactions: [Transaction!]!
+        transactionAt(index: Long!): Transaction
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        account(address: Address!): Account!
+        # GlobalExitRoot is the global exit root used by the block.
+        globalExitRoot: Bytes32
+        # BlockInfoRoot is the root of the block info tree of the block.
+        blockInfoRoot: Bytes32
+        # Batch is the batch the block belongs to.
+        batch: Batch
+        # Virtualized is true when the batch of the block has been sequenced on L1.
+        virtualized: Boolean!
+        # Consolidated is true when the batch of the block has been verified on L1.
+        consolidated: Boolean!
+    }
+
+    # FilterCriteria filters the logs of a range of blocks.
+    input FilterCriteria {
+        # FromBlock is the first block, latest if not provided.
+        fromBlock: Long
+        # ToBlock is the last block, latest if not provided.
+        toBlock: Long
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    # ZKCounters are the resources of the prover consumed by a batch.
+    type ZKCounters {
+        gasUsed: Long!
+        keccakHashes: Long!
+        poseidonHashes: Long!
+        poseidonPaddings: Long!
+        memAligns: Long!
+        arithmetics: Long!
+        binaries: Long!
+        steps: Long!
+        sha256Hashes: Long!
+    }
+
+    # Batch is a zkEVM batch.
+    type Batch {
+        number: Long!
+        forcedBatchNumber: Long
+        coinbase: Address!
+        stateRoot: Bytes32!
+        globalExitRoot: Bytes32!
+        mainnetExitRoot: Bytes32!
+        rollupExitRoot: Bytes32!
+        localExitRoot: Bytes32!
+        accInputHash: Bytes32!
+        timestamp: Long!
+        # SendSequencesTxHash is the L1 transaction that virtualized the batch.
+        sendSequencesTxHash: Bytes32
+        # VerifyBatchTxHash is the L1 transaction that verified the batch.
+        verifyBatchTxHash: Bytes32
+        closed: Boolean!
+        virtualized: Boolean!
+        verified: Boolean!
+        blocks: [Block!]!
+        transactions: [Transaction!]!
+        batchL2Data: Bytes!
+        zkCounters: ZKCounters!
+    }
+
+    # ExitRoots are the exit roots of a global exit root.
+    type ExitRoots {
+        blockNumber: Long!
+        timestamp: Long!
+        mainnetExitRoot: Bytes32!
+        rollupExitRoot: Bytes32!
+    }
+
+    type Query {
+        # Block returns a block by number or hash, the latest one if none is provided.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns the blocks in a range, until the latest one if to is not provided.
+        blocks(from: Long!, to: Long): [Block!]!
+        transaction(hash: Bytes32!): Transaction
+        logs(filter: FilterCriteria!): [Log!]!
+        gasPrice: BigInt!
+        chainID: BigInt!
+        # Batch returns a batch by number, the latest one if not provided.
+        batch(number: Long): Batch
+        batchNumber: Long!
+        virtualBatchNumber: Long!
+        verifiedBatchNumber: Long!
+        latestGlobalExitRoot: Bytes32!
+        exitRootsByGER(globalExitRoot: Bytes32!): ExitRoots
+    }
+
+    type Mutation {
+        # SendRawTransaction sends an RLP encoded transaction to the pool.
+        sendRawTransaction(data: Bytes!): Bytes32!
+    }
+`
@@ -0,0 +1,175 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func TestGraphQL(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.GraphQL = GraphQLConfig{Enabled: true, Host: "0.0.0.0", Port: 9143, MaxBlockRange: 10}
+	cfg.Auth = getAuthTestConfig(t)
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	graphQLURL := fmt.Sprintf("http://%s:%d", cfg.GraphQL.Host, cfg.GraphQL.Port)
+	doQuery := func(t *testing.T, query string, headers map[string]string) (int, graphQLResponse) {
+		reqBody, err := json.Marshal(map[string]string{"query": query})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, graphQLURL, bytes.NewReader(reqBody))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		httpRes, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer httpRes.Body.Close()
+		resBody, err := io.ReadAll(httpRes.Body)
+		require.NoError(t, err)
+
+		var res graphQLResponse
+		if httpRes.StatusCode != http.StatusUnauthorized {
+			require.NoError(t, json.Unmarshal(resBody, &res))
+		}
+		return httpRes.StatusCode, res
+	}
+	adminKey := map[string]string{apiKeyHeader: "admin-key"}
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get(graphQLURL) //nolint:gosec
+		if err != nil {
+			return false
+		}
+		res.Body.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	m.DbTx.On("Commit", mock.Anything).Return(nil)
+	m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil)
+
+	t.Run("zkevm namespace requires credentials", func(t *testing.T) {
+		statusCode, _ := doQuery(t, "{ batchNumber }", nil)
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+	})
+
+	t.Run("chain and batch numbers", func(t *testing.T) {
+		m.State.On("GetLastBatchNumber", mock.Anything, m.DbTx).Return(uint64(7), nil).Once()
+		m.State.On("GetLastVerifiedBatch", mock.Anything, m.DbTx).Return(&state.VerifiedBatch{BatchNumber: 5}, nil).Once()
+
+		statusCode, res := doQuery(t, "{ chainID batchNumber verifiedBatchNumber }", adminKey)
+		require.Equal(t, http.StatusOK, statusCode)
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, fmt.Sprintf(`{"chainID":"0x%x","batchNumber":"0x7","verifiedBatchNumber":"0x5"}`, chainID), string(res.Data))
+	})
+
+	t.Run("block with zkevm status", func(t *testing.T) {
+		header := &ethTypes.Header{Number: big.NewInt(2), UncleHash: ethTypes.EmptyUncleHash, Root: ethTypes.EmptyRootHash}
+		l2Header := state.NewL2Header(header)
+		l2Header.GlobalExitRoot = common.HexToHash("0x1")
+		block := state.NewL2Block(l2Header, nil, nil, nil, trie.NewStackTrie(nil))
+
+		m.State.On("GetL2BlockByNumber", mock.Anything, uint64(2), m.DbTx).Return(block, nil).Once()
+		m.State.On("IsL2BlockVirtualized", mock.Anything, uint64(2), m.DbTx).Return(true, nil).Once()
+		m.State.On("IsL2BlockConsolidated", mock.Anything, uint64(2), m.DbTx).Return(false, nil).Once()
+
+		statusCode, res := doQuery(t, "{ block(number: 2) { number hash globalExitRoot transactionCount virtualized consolidated } }", adminKey)
+		require.Equal(t, http.StatusOK, statusCode)
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, fmt.Sprintf(`{"block":{"number":"0x2","hash":"%v","globalExitRoot":"%v","transactionCount":"0x0","virtualized":true,"consolidated":false}}`,
+			block.Hash().String(), common.HexToHash("0x1").String()), string(res.Data))
+	})
+
+	t.Run("block range too large", func(t *testing.T) {
+		statusCode, res := doQuery(t, "{ blocks(from: 1, to: 20) { number } }", adminKey)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "block range too large, max range allowed is 10", res.Errors[0].Message)
+	})
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.GraphQL = GraphQLConfig{Enabled: true, Host: "0.0.0.0", Port: 9144, MaxBlockRange: 10, MaxDepth: 3, MaxComplexity: 3}
+	cfg.RateLimit = RateLimitConfig{
+		Enabled:           true,
+		DefaultMethodCost: 1,
+		MethodCosts:       map[string]uint64{graphQLFieldRateLimitMethod: 2},
+		Tiers:             map[string]RateLimitTierConfig{"default": {CostPerSecond: 0.001, Burst: 9}},
+		DefaultTier:       "default",
+	}
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	graphQLURL := fmt.Sprintf("http://%s:%d", cfg.GraphQL.Host, cfg.GraphQL.Port)
+	doQuery := func(t *testing.T, query string) (int, graphQLResponse) {
+		reqBody, err := json.Marshal(map[string]string{"query": query})
+		require.NoError(t, err)
+		httpRes, err := http.Post(graphQLURL, contentType, bytes.NewReader(reqBody)) //nolint:gosec
+		require.NoError(t, err)
+		defer httpRes.Body.Close()
+		resBody, err := io.ReadAll(httpRes.Body)
+		require.NoError(t, err)
+
+		var res graphQLResponse
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		return httpRes.StatusCode, res
+	}
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get(graphQLURL) //nolint:gosec
+		if err != nil {
+			return false
+		}
+		res.Body.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	m.DbTx.On("Commit", mock.Anything).Return(nil).Maybe()
+	m.State.On("BeginStateTransaction", mock.Anything).Return(m.DbTx, nil).Maybe()
+	m.State.On("GetLastBatchNumber", mock.Anything, m.DbTx).Return(uint64(7), nil).Maybe()
+
+	t.Run("query too deep", func(t *testing.T) {
+		statusCode, res := doQuery(t, "{ block(number: 2) { transactions { from { address } } } }")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		require.NotEmpty(t, res.Errors)
+		assert.Equal(t, `Field "address" has depth 4 that exceeds max depth 3`, res.Errors[0].Message)
+	})
+
+	t.Run("query too complex", func(t *testing.T) {
+		statusCode, res := doQuery(t, "{ a: batchNumber b: batchNumber c: batchNumber d: batchNumber }")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "query too complex, max number of fields resolved allowed is 3", res.Errors[0].Message)
+	})
+
+	t.Run("every field resolved is rate limited", func(t *testing.T) {
+		// the queries above consumed 8 units, enough quota is left for the query
+		// but not for its field
+		statusCode, res := doQuery(t, "{ batchNumber }")
+		assert.Equal(t, http.StatusTooManyRequests, statusCode)
+		require.Len(t, res.Errors, 1)
+		assert.Contains(t, res.Errors[0].Message, "rate limit exceeded")
+	})
+}
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -62,6 +63,7 @@ type handleRequest struct {
 // check the `eth.go` file for more example on how the methods are implemented
 type Handler struct {
 	serviceMap map[string]*serviceData
+	cache      *responseCache
 }
 
 func newJSONRpcHandler() *Handler {
@@ -82,6 +84,13 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		return types.NewResponse(req.Request, nil, err)
 	}
 
+	cacheable := h.cache != nil && h.cache.cacheable(req.Request)
+	if cacheable {
+		if data, found := h.cache.get(context.Background(), req.Request); found {
+			return types.NewResponse(req.Request, data, nil)
+		}
+	}
+
 	inArgsOffset := 0
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
@@ -137,6 +146,10 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		data = d
 	}
 
+	if cacheable {
+		h.cache.add(context.Background(), req.Request, data)
+	}
+
 	return types.NewResponse(req.Request, data, nil)
 }
 
@@ -238,6 +251,15 @@ func (h *Handler) getFnHandler(req types.Request) (*serviceData, *funcData, type
 	if !ok {
 		return nil, nil, types.NewRPCError(types.NotFoundErrorCode, methodNotFoundErrorMessage)
 	}
+
+	// the subscriptions of other namespaces are only available when the
+	// namespace is enabled
+	if subscriptionName := authMethod(req); subscriptionName != req.Method {
+		subscriptionService, _, _ := strings.Cut(subscriptionName, "_")
+		if _, ok := h.serviceMap[subscriptionService]; !ok {
+			return nil, nil, types.NewRPCError(types.NotFoundErrorCode, fmt.Sprintf("the subscription %s does not exist/is not available", subscriptionName))
+		}
+	}
 	return service, fd, nil
 }
 
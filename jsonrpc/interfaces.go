@@ -4,10 +4,13 @@ package jsonrpc
 type storageInterface interface {
 	GetAllBlockFiltersWithWSConn() []*Filter
 	GetAllLogFiltersWithWSConn() []*Filter
+	GetAllPendingTxFiltersWithWSConn() []*Filter
+	GetAllSubscriptionFiltersWithWSConn(t FilterType) []*Filter
 	GetFilter(filterID string) (*Filter, error)
 	NewBlockFilter(wsConn *concurrentWsConn) (string, error)
 	NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error)
-	NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error)
+	NewPendingTransactionFilter(wsConn *concurrentWsConn, filter PendingTxFilter) (string, error)
+	NewSubscriptionFilter(wsConn *concurrentWsConn, t FilterType) (string, error)
 	UninstallFilter(filterID string) error
 	UninstallFilterByWSConn(wsConn *concurrentWsConn) error
 	UpdateFilterLastPoll(filterID string) error
@@ -13,8 +13,13 @@ const (
 	requestsHandledName = requestPrefix + "handled"
 	requestDurationName = requestPrefix + "duration"
 	connName            = requestPrefix + "connection"
+	rateLimitedName     = requestPrefix + "rate_limited"
+	unauthorizedName    = requestPrefix + "unauthorized"
+	cacheHitName        = prefix + "cache_hit"
+	cacheMissName       = prefix + "cache_miss"
 
 	requestHandledTypeLabelName = "type"
+	cacheMethodLabelName        = "method"
 )
 
 // RequestHandledLabel represents the possible values for the
@@ -56,6 +61,31 @@ func Register() {
 			},
 			Labels: []string{requestHandledTypeLabelName},
 		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: cacheHitName,
+				Help: "[JSONRPC] number of requests answered by the response cache",
+			},
+			Labels: []string{cacheMethodLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: cacheMissName,
+				Help: "[JSONRPC] number of cacheable requests not found in the response cache",
+			},
+			Labels: []string{cacheMethodLabelName},
+		},
+	}
+
+	counters := []prometheus.CounterOpts{
+		{
+			Name: rateLimitedName,
+			Help: "[JSONRPC] number of requests rejected by the rate limit",
+		},
+		{
+			Name: unauthorizedName,
+			Help: "[JSONRPC] number of requests rejected by the access control",
+		},
 	}
 
 	start := 0.1
@@ -70,6 +100,7 @@ func Register() {
 	}
 
 	metrics.RegisterCounterVecs(counterVecs...)
+	metrics.RegisterCounters(counters...)
 	metrics.RegisterHistograms(histograms...)
 }
 
@@ -90,3 +121,27 @@ func RequestHandled(label RequestHandledLabel) {
 func RequestDuration(start time.Time) {
 	metrics.HistogramObserve(requestDurationName, time.Since(start).Seconds())
 }
+
+// RequestRateLimited increments the requests rejected by the rate limit
+// counter by one.
+func RequestRateLimited() {
+	metrics.CounterInc(rateLimitedName)
+}
+
+// RequestUnauthorized increments the requests rejected by the access control
+// counter by one.
+func RequestUnauthorized() {
+	metrics.CounterInc(unauthorizedName)
+}
+
+// CacheHit increments the response cache hits counter vector by one for the
+// given method.
+func CacheHit(method string) {
+	metrics.CounterVecInc(cacheHitName, method)
+}
+
+// CacheMiss increments the response cache misses counter vector by one for the
+// given method.
+func CacheMiss(method string) {
+	metrics.CounterVecInc(cacheMissName, method)
+}
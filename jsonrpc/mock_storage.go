@@ -49,6 +49,46 @@ func (_m *storageMock) GetAllLogFiltersWithWSConn() []*Filter {
 	return r0
 }
 
+// GetAllPendingTxFiltersWithWSConn provides a mock function with given fields:
+func (_m *storageMock) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPendingTxFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func() []*Filter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
+// GetAllSubscriptionFiltersWithWSConn provides a mock function with given fields: t
+func (_m *storageMock) GetAllSubscriptionFiltersWithWSConn(t FilterType) []*Filter {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllSubscriptionFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func(FilterType) []*Filter); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
 // GetFilter provides a mock function with given fields: filterID
 func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 	ret := _m.Called(filterID)
@@ -135,9 +175,9 @@ func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter)
 	return r0, r1
 }
 
-// NewPendingTransactionFilter provides a mock function with given fields: wsConn
-func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
-	ret := _m.Called(wsConn)
+// NewPendingTransactionFilter provides a mock function with given fields: wsConn, filter
+func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn, filter PendingTxFilter) (string, error) {
+	ret := _m.Called(wsConn, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewPendingTransactionFilter")
@@ -145,17 +185,45 @@ func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (st
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
-		return rf(wsConn)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, PendingTxFilter) (string, error)); ok {
+		return rf(wsConn, filter)
 	}
-	if rf, ok := ret.Get(0).(func(*concurrentWsConn) string); ok {
-		r0 = rf(wsConn)
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, PendingTxFilter) string); ok {
+		r0 = rf(wsConn, filter)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*concurrentWsConn) error); ok {
-		r1 = rf(wsConn)
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn, PendingTxFilter) error); ok {
+		r1 = rf(wsConn, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSubscriptionFilter provides a mock function with given fields: wsConn, t
+func (_m *storageMock) NewSubscriptionFilter(wsConn *concurrentWsConn, t FilterType) (string, error) {
+	ret := _m.Called(wsConn, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewSubscriptionFilter")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, FilterType) (string, error)); ok {
+		return rf(wsConn, t)
+	}
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, FilterType) string); ok {
+		r0 = rf(wsConn, t)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn, FilterType) error); ok {
+		r1 = rf(wsConn, t)
 	} else {
 		r1 = ret.Error(1)
 	}
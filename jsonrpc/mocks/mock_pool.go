@@ -22,6 +22,54 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddBundle provides a mock function with given fields: ctx, txs, ip
+func (_m *PoolMock) AddBundle(ctx context.Context, txs []types.Transaction, ip string) (common.Hash, error) {
+	ret := _m.Called(ctx, txs, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBundle")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Transaction, string) (common.Hash, error)); ok {
+		return rf(ctx, txs, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Transaction, string) common.Hash); ok {
+		r0 = rf(ctx, txs, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.Transaction, string) error); ok {
+		r1 = rf(ctx, txs, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddPrivateTx provides a mock function with given fields: ctx, tx, ip, maxL2BlockNumber
+func (_m *PoolMock) AddPrivateTx(ctx context.Context, tx types.Transaction, ip string, maxL2BlockNumber *uint64) error {
+	ret := _m.Called(ctx, tx, ip, maxL2BlockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPrivateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string, *uint64) error); ok {
+		r0 = rf(ctx, tx, ip, maxL2BlockNumber)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTx provides a mock function with given fields: ctx, tx, ip
 func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
 	ret := _m.Called(ctx, tx, ip)
@@ -98,6 +146,34 @@ func (_m *PoolMock) CalculateEffectiveGasPricePercentage(gasPrice *big.Int, effe
 	return r0, r1
 }
 
+// CheckCallPolicy provides a mock function with given fields: ctx, contract, selector
+func (_m *PoolMock) CheckCallPolicy(ctx context.Context, contract common.Address, selector []byte) (bool, error) {
+	ret := _m.Called(ctx, contract, selector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckCallPolicy")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []byte) (bool, error)); ok {
+		return rf(ctx, contract, selector)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []byte) bool); ok {
+		r0 = rf(ctx, contract, selector)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, []byte) error); ok {
+		r1 = rf(ctx, contract, selector)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckPolicy provides a mock function with given fields: ctx, policy, address
 func (_m *PoolMock) CheckPolicy(ctx context.Context, policy pool.PolicyName, address common.Address) (bool, error) {
 	ret := _m.Called(ctx, policy, address)
@@ -126,6 +202,34 @@ func (_m *PoolMock) CheckPolicy(ctx context.Context, policy pool.PolicyName, add
 	return r0, r1
 }
 
+// CheckValuePolicy provides a mock function with given fields: ctx, address, value
+func (_m *PoolMock) CheckValuePolicy(ctx context.Context, address common.Address, value *big.Int) (bool, error) {
+	ret := _m.Called(ctx, address, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckValuePolicy")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) (bool, error)); ok {
+		return rf(ctx, address, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) bool); ok {
+		r0 = rf(ctx, address, value)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, *big.Int) error); ok {
+		r1 = rf(ctx, address, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountPendingTransactions provides a mock function with given fields: ctx
 func (_m *PoolMock) CountPendingTransactions(ctx context.Context) (uint64, error) {
 	ret := _m.Called(ctx)
@@ -172,6 +276,66 @@ func (_m *PoolMock) EffectiveGasPriceEnabled() bool {
 	return r0
 }
 
+// GetContent provides a mock function with given fields: ctx, offset, limit
+func (_m *PoolMock) GetContent(ctx context.Context, offset uint64, limit uint64) (*pool.TxPoolContent, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContent")
+	}
+
+	var r0 *pool.TxPoolContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*pool.TxPoolContent, error)); ok {
+		return rf(ctx, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *pool.TxPoolContent); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.TxPoolContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentFrom provides a mock function with given fields: ctx, address
+func (_m *PoolMock) GetContentFrom(ctx context.Context, address common.Address) (*pool.TxPoolContent, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentFrom")
+	}
+
+	var r0 *pool.TxPoolContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (*pool.TxPoolContent, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *pool.TxPoolContent); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.TxPoolContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGasPrices provides a mock function with given fields: ctx
 func (_m *PoolMock) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
 	ret := _m.Called(ctx)
@@ -288,6 +452,34 @@ func (_m *PoolMock) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Tra
 	return r0, r1
 }
 
+// GetStatus provides a mock function with given fields: ctx
+func (_m *PoolMock) GetStatus(ctx context.Context) (pool.TxPoolStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 pool.TxPoolStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (pool.TxPoolStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) pool.TxPoolStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(pool.TxPoolStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) GetTransactionByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
 	ret := _m.Called(ctx, hash)
@@ -348,6 +540,34 @@ func (_m *PoolMock) GetTransactionByL2Hash(ctx context.Context, hash common.Hash
 	return r0, r1
 }
 
+// MaxBundleTxs provides a mock function with given fields:
+func (_m *PoolMock) MaxBundleTxs() uint64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaxBundleTxs")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// RegisterNewPendingTxEventHandler provides a mock function with given fields: h
+func (_m *PoolMock) RegisterNewPendingTxEventHandler(h pool.NewPendingTxEventHandler) {
+	_m.Called(h)
+}
+
+// StartToMonitorNewPendingTxs provides a mock function with given fields:
+func (_m *PoolMock) StartToMonitorNewPendingTxs() {
+	_m.Called()
+}
+
 // NewPoolMock creates a new instance of PoolMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPoolMock(t interface {
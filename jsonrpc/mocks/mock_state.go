@@ -10,6 +10,8 @@ import (
 
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 
+	merkletree "github.com/0xPolygonHermez/zkevm-node/merkletree"
+
 	mock "github.com/stretchr/testify/mock"
 
 	pgx "github.com/jackc/pgx/v4"
@@ -84,6 +86,34 @@ func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
 	return r0, r1
 }
 
+// CountL1Reorgs provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) CountL1Reorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountL1Reorgs")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DebugTransaction provides a mock function with given fields: ctx, transactionHash, traceConfig, dbTx
 func (_m *StateMock) DebugTransaction(ctx context.Context, transactionHash common.Hash, traceConfig state.TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
 	ret := _m.Called(ctx, transactionHash, traceConfig, dbTx)
@@ -114,9 +144,39 @@ func (_m *StateMock) DebugTransaction(ctx context.Context, transactionHash commo
 	return r0, r1
 }
 
-// EstimateGas provides a mock function with given fields: transaction, senderAddress, l2BlockNumber, dbTx
-func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
-	ret := _m.Called(transaction, senderAddress, l2BlockNumber, dbTx)
+// DebugUnsignedTransaction provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, blockOverride, dbTx
+func (_m *StateMock) DebugUnsignedTransaction(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, traceConfig state.TraceConfig, stateOverride state.StateOverride, blockOverride *state.BlockOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, blockOverride, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DebugUnsignedTransaction")
+	}
+
+	var r0 *runtime.ExecutionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.TraceConfig, state.StateOverride, *state.BlockOverride, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, blockOverride, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.TraceConfig, state.StateOverride, *state.BlockOverride, pgx.Tx) *runtime.ExecutionResult); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, blockOverride, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.TraceConfig, state.StateOverride, *state.BlockOverride, pgx.Tx) error); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, blockOverride, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateGas provides a mock function with given fields: transaction, senderAddress, l2BlockNumber, stateOverride, dbTx
+func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
+	ret := _m.Called(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for EstimateGas")
@@ -125,25 +185,25 @@ func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddre
 	var r0 uint64
 	var r1 []byte
 	var r2 error
-	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) (uint64, []byte, error)); ok {
-		return rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) (uint64, []byte, error)); ok {
+		return rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) uint64); ok {
-		r0 = rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) uint64); ok {
+		r0 = rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) []byte); ok {
-		r1 = rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(1).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) []byte); ok {
+		r1 = rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) error); ok {
-		r2 = rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(2).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) error); ok {
+		r2 = rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -151,6 +211,36 @@ func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddre
 	return r0, r1, r2
 }
 
+// GetAccountProof provides a mock function with given fields: ctx, address, positions, root
+func (_m *StateMock) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error) {
+	ret := _m.Called(ctx, address, positions, root)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountProof")
+	}
+
+	var r0 *merkletree.AccountProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*big.Int, common.Hash) (*merkletree.AccountProof, error)); ok {
+		return rf(ctx, address, positions, root)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*big.Int, common.Hash) *merkletree.AccountProof); ok {
+		r0 = rf(ctx, address, positions, root)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*merkletree.AccountProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, []*big.Int, common.Hash) error); ok {
+		r1 = rf(ctx, address, positions, root)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: ctx, address, root
 func (_m *StateMock) GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, root)
@@ -1177,6 +1267,45 @@ func (_m *StateMock) GetTransactionReceipt(ctx context.Context, transactionHash
 	return r0, r1
 }
 
+// GetTransactionReceiptsByBlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StateMock) GetTransactionReceiptsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*coretypes.Receipt, []*common.Hash, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceiptsByBlockNumber")
+	}
+
+	var r0 []*coretypes.Receipt
+	var r1 []*common.Hash
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]*coretypes.Receipt, []*common.Hash, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []*coretypes.Receipt); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*coretypes.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) []*common.Hash); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionsByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]coretypes.Transaction, []uint8, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -1362,9 +1491,39 @@ func (_m *StateMock) PreProcessUnsignedTransaction(ctx context.Context, tx *core
 	return r0, r1
 }
 
-// ProcessUnsignedTransaction provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx
-func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
-	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+// PreProcessUnsignedTransactions provides a mock function with given fields: ctx, txs, sender, l2BlockNumber, dbTx
+func (_m *StateMock) PreProcessUnsignedTransactions(ctx context.Context, txs []*coretypes.Transaction, sender common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (*state.ProcessBatchResponse, error) {
+	ret := _m.Called(ctx, txs, sender, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreProcessUnsignedTransactions")
+	}
+
+	var r0 *state.ProcessBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*coretypes.Transaction, common.Address, *uint64, pgx.Tx) (*state.ProcessBatchResponse, error)); ok {
+		return rf(ctx, txs, sender, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*coretypes.Transaction, common.Address, *uint64, pgx.Tx) *state.ProcessBatchResponse); ok {
+		r0 = rf(ctx, txs, sender, l2BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.ProcessBatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*coretypes.Transaction, common.Address, *uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, txs, sender, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessUnsignedTransaction provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx
+func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessUnsignedTransaction")
@@ -1372,19 +1531,19 @@ func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretyp
 
 	var r0 *runtime.ExecutionResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
-		return rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) *runtime.ExecutionResult); ok {
-		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) *runtime.ExecutionResult); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*runtime.ExecutionResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) error); ok {
-		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) error); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1392,11 +1551,26 @@ func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretyp
 	return r0, r1
 }
 
+// RegisterNewBatchEventHandler provides a mock function with given fields: h
+func (_m *StateMock) RegisterNewBatchEventHandler(h state.NewBatchEventHandler) {
+	_m.Called(h)
+}
+
 // RegisterNewL2BlockEventHandler provides a mock function with given fields: h
 func (_m *StateMock) RegisterNewL2BlockEventHandler(h state.NewL2BlockEventHandler) {
 	_m.Called(h)
 }
 
+// RegisterSyncingStatusEventHandler provides a mock function with given fields: h
+func (_m *StateMock) RegisterSyncingStatusEventHandler(h state.SyncingStatusEventHandler) {
+	_m.Called(h)
+}
+
+// StartToMonitorNewBatches provides a mock function with given fields:
+func (_m *StateMock) StartToMonitorNewBatches() {
+	_m.Called()
+}
+
 // StartToMonitorNewL2Blocks provides a mock function with given fields:
 func (_m *StateMock) StartToMonitorNewL2Blocks() {
 	_m.Called()
@@ -10,6 +10,10 @@ import (
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
+// checkPolicy checks the policies that apply to the tx in this order, rejecting it on the first one that denies it:
+//   - send_tx for the recipient and then for the sender, or deploy for the sender if the tx creates a contract
+//   - call for the recipient and the function selector, if the tx has data
+//   - value for the sender, if the tx transfers value
 func checkPolicy(ctx context.Context, p types.PoolInterface, input string) error {
 	tx, err := hexToTx(input)
 	if err != nil {
@@ -25,9 +29,9 @@ func checkPolicy(ctx context.Context, p types.PoolInterface, input string) error
 		return nil
 	}
 
+	var allow bool
 	switch resolvePolicy(tx) {
 	case pool.SendTx:
-		var allow bool
 		if allow, err = p.CheckPolicy(ctx, pool.SendTx, *tx.To()); err != nil {
 			return err
 		}
@@ -40,8 +44,16 @@ func checkPolicy(ctx context.Context, p types.PoolInterface, input string) error
 		if !allow {
 			return pool.ErrSenderDisallowedSendTx
 		}
+		// plain transfers are not contract calls
+		if len(tx.Data()) > 0 {
+			if allow, err = p.CheckCallPolicy(ctx, *tx.To(), pool.CallSelector(tx.Data())); err != nil {
+				return err
+			}
+			if !allow {
+				return pool.ErrContractDisallowedCall
+			}
+		}
 	case pool.Deploy:
-		var allow bool
 		// check that sender may deploy contracts
 		if allow, err = p.CheckPolicy(ctx, pool.Deploy, from); err != nil {
 			return err
@@ -50,6 +62,16 @@ func checkPolicy(ctx context.Context, p types.PoolInterface, input string) error
 			return pool.ErrSenderDisallowedDeploy
 		}
 	}
+
+	// any address may send txs without value
+	if tx.Value().Sign() > 0 {
+		if allow, err = p.CheckValuePolicy(ctx, from, tx.Value()); err != nil {
+			return err
+		}
+		if !allow {
+			return pool.ErrSenderValueExceedsLimit
+		}
+	}
 	return nil
 }
 
@@ -23,6 +23,14 @@ const (
 	FilterTypeBlock = "block"
 	// FilterTypePendingTx represent a filter of type pending Tx.
 	FilterTypePendingTx = "pendingTx"
+	// FilterTypeSyncing represents a web socket subscription to the syncing status.
+	FilterTypeSyncing = "syncing"
+	// FilterTypeNewBatches represents a web socket subscription to the trusted batches closed.
+	FilterTypeNewBatches = "newBatches"
+	// FilterTypeVirtualizedBatches represents a web socket subscription to the batches virtualized.
+	FilterTypeVirtualizedBatches = "virtualizedBatches"
+	// FilterTypeVerifiedBatches represents a web socket subscription to the batches verified.
+	FilterTypeVerifiedBatches = "verifiedBatches"
 )
 
 // Filter represents a filter.
@@ -358,6 +366,30 @@ func (f *LogFilter) Validate() error {
 	return nil
 }
 
+// PendingTxFilter is a filter for pending transactions
+type PendingTxFilter struct {
+	// FullTx sends the full transactions to the web socket subscriptions instead of their hashes
+	FullTx bool
+}
+
+// SubscriptionParams is the optional param of eth_subscribe, a log filter
+// for the logs subscriptions or a flag to receive the full transactions for
+// the pending transactions subscriptions
+type SubscriptionParams struct {
+	LogFilter *LogFilter
+	FullTx    bool
+}
+
+// UnmarshalJSON decodes the subscription params as a boolean or a log filter
+func (p *SubscriptionParams) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.FullTx); err == nil {
+		return nil
+	}
+
+	p.LogFilter = &LogFilter{}
+	return json.Unmarshal(data, p.LogFilter)
+}
+
 // NativeBlockHashBlockRangeFilter is a filter to filter native block hash by block by number
 type NativeBlockHashBlockRangeFilter struct {
 	FromBlock types.BlockNumber `json:"fromBlock"`
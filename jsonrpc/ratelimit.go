@@ -0,0 +1,192 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/encoding"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/didip/tollbooth/v6/libstring"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// apiKeyHeader is the HTTP header used to send the API key
+	apiKeyHeader = "X-API-Key"
+	// apiKeyQueryParam is the query param used to send the API key, useful
+	// for web socket clients that can't set headers
+	apiKeyQueryParam = "apikey"
+
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	retryAfterHeader         = "Retry-After"
+
+	// rateLimitCleanupInterval is the min time between the removal of the
+	// limiters of the clients that have their quota full
+	rateLimitCleanupInterval = time.Minute
+)
+
+// rateLimitIPLookups are the places used to find the IP of a client, the same
+// used by the tollbooth limiter
+var rateLimitIPLookups = []string{"RemoteAddr", "X-Forwarded-For", "X-Real-IP"}
+
+// rateLimiter limits the requests of each client by the cost of the methods
+// requested, every client has a quota defined by its tier that recovers over
+// time
+type rateLimiter struct {
+	defaultMethodCost uint64
+	methodCosts       map[string]uint64
+	tiers             map[string]RateLimitTierConfig
+	defaultTier       string
+	apiKeys           map[string]string
+
+	limiters    map[string]*rate.Limiter
+	lastCleanup time.Time
+	mutex       *sync.Mutex
+}
+
+// rateLimitResult is the outcome of checking a request against the rate limit
+type rateLimitResult struct {
+	allowed    bool
+	limit      uint64
+	remaining  uint64
+	retryAfter time.Duration
+}
+
+// newRateLimiter creates the rate limiter for the provided config, nil is
+// returned when the rate limit is disabled
+func newRateLimiter(cfg RateLimitConfig) (*rateLimiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	l := &rateLimiter{
+		defaultMethodCost: cfg.DefaultMethodCost,
+		methodCosts:       make(map[string]uint64, len(cfg.MethodCosts)),
+		tiers:             make(map[string]RateLimitTierConfig, len(cfg.Tiers)),
+		defaultTier:       strings.ToLower(cfg.DefaultTier),
+		apiKeys:           make(map[string]string, len(cfg.APIKeys)),
+		limiters:          make(map[string]*rate.Limiter),
+		lastCleanup:       time.Now(),
+		mutex:             &sync.Mutex{},
+	}
+
+	// the config keys are case insensitive
+	for method, cost := range cfg.MethodCosts {
+		l.methodCosts[strings.ToLower(method)] = cost
+	}
+	for name, tier := range cfg.Tiers {
+		if tier.Burst == 0 {
+			return nil, fmt.Errorf("rate limit tier %v must have a burst greater than zero", name)
+		}
+		l.tiers[strings.ToLower(name)] = tier
+	}
+	if _, found := l.tiers[l.defaultTier]; !found {
+		return nil, fmt.Errorf("rate limit default tier %v not found", cfg.DefaultTier)
+	}
+	for _, apiKey := range cfg.APIKeys {
+		if apiKey.Key == "" {
+			return nil, fmt.Errorf("rate limit API key can't be empty")
+		}
+		tier := strings.ToLower(apiKey.Tier)
+		if _, found := l.tiers[tier]; !found {
+			return nil, fmt.Errorf("rate limit tier %v of an API key not found", apiKey.Tier)
+		}
+		l.apiKeys[apiKey.Key] = tier
+	}
+
+	return l, nil
+}
+
+// allow consumes the cost of the method from the quota of the client sending
+// the request, when the quota is not enough nothing is consumed and the time
+// to wait until the request can be retried is returned
+func (l *rateLimiter) allow(httpRequest *http.Request, method string) rateLimitResult {
+	clientID, tier := l.client(httpRequest)
+	cost := l.methodCost(method)
+	now := time.Now()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.cleanup(now)
+
+	limiter, found := l.limiters[clientID]
+	if !found {
+		limiter = rate.NewLimiter(rate.Limit(tier.CostPerSecond), int(tier.Burst))
+		l.limiters[clientID] = limiter
+	}
+
+	result := rateLimitResult{allowed: true, limit: tier.Burst}
+	reservation := limiter.ReserveN(now, int(cost))
+	if !reservation.OK() {
+		// the cost is greater than the burst, so it will never be allowed
+		result.allowed = false
+	} else if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		result.allowed = false
+		result.retryAfter = delay
+	}
+	result.remaining = uint64(math.Max(0, math.Floor(limiter.TokensAt(now))))
+
+	return result
+}
+
+// client returns the id of the client sending the request and its tier
+func (l *rateLimiter) client(httpRequest *http.Request) (string, RateLimitTierConfig) {
+	apiKey := httpRequest.Header.Get(apiKeyHeader)
+	if apiKey == "" {
+		apiKey = httpRequest.URL.Query().Get(apiKeyQueryParam)
+	}
+	if tier, found := l.apiKeys[apiKey]; apiKey != "" && found {
+		return "key:" + apiKey, l.tiers[tier]
+	}
+
+	ip := libstring.RemoteIP(rateLimitIPLookups, 0, httpRequest)
+	return "ip:" + ip, l.tiers[l.defaultTier]
+}
+
+// methodCost returns the cost of the provided method
+func (l *rateLimiter) methodCost(method string) uint64 {
+	if cost, found := l.methodCosts[strings.ToLower(method)]; found {
+		return cost
+	}
+	return l.defaultMethodCost
+}
+
+// cleanup removes the limiters of the clients with their quota full, they
+// behave the same as a new limiter so there is no need to keep them
+func (l *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < rateLimitCleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+
+	for clientID, limiter := range l.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(l.limiters, clientID)
+		}
+	}
+}
+
+// writeHeaders sets the rate limit headers of the response
+func (r rateLimitResult) writeHeaders(w http.ResponseWriter) {
+	w.Header().Set(rateLimitLimitHeader, strconv.FormatUint(r.limit, encoding.Base10))
+	w.Header().Set(rateLimitRemainingHeader, strconv.FormatUint(r.remaining, encoding.Base10))
+	if !r.allowed && r.retryAfter > 0 {
+		w.Header().Set(retryAfterHeader, strconv.FormatInt(int64(math.Ceil(r.retryAfter.Seconds())), encoding.Base10))
+	}
+}
+
+// rpcError returns the error to be returned for a request rejected by the rate limit
+func (r rateLimitResult) rpcError(method string) types.Error {
+	if r.retryAfter == 0 {
+		return types.NewRPCError(types.LimitExceededErrorCode, "rate limit exceeded: the cost of %v is greater than the quota", method)
+	}
+	return types.NewRPCError(types.LimitExceededErrorCode, "rate limit exceeded: retry after %v", r.retryAfter.Round(time.Millisecond))
+}
@@ -0,0 +1,216 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func getRateLimitTestConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Enabled:           true,
+		DefaultMethodCost: 1,
+		MethodCosts: map[string]uint64{
+			"eth_chainid":       2,
+			"debug_tracecall":   20,
+			"zkevm_batchnumber": 1,
+		},
+		Tiers: map[string]RateLimitTierConfig{
+			"default": {CostPerSecond: 0.001, Burst: 3},
+			"premium": {CostPerSecond: 0.001, Burst: 10},
+		},
+		DefaultTier: "default",
+		APIKeys:     []RateLimitAPIKeyConfig{{Key: "premium-key", Tier: "Premium"}},
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	l, err := newRateLimiter(RateLimitConfig{Enabled: false})
+	require.NoError(t, err)
+	assert.Nil(t, l)
+
+	cfg := getRateLimitTestConfig()
+	cfg.DefaultTier = "free"
+	_, err = newRateLimiter(cfg)
+	assert.EqualError(t, err, "rate limit default tier free not found")
+
+	cfg = getRateLimitTestConfig()
+	cfg.Tiers["free"] = RateLimitTierConfig{CostPerSecond: 1}
+	_, err = newRateLimiter(cfg)
+	assert.EqualError(t, err, "rate limit tier free must have a burst greater than zero")
+
+	cfg = getRateLimitTestConfig()
+	cfg.APIKeys = append(cfg.APIKeys, RateLimitAPIKeyConfig{Key: "key", Tier: "gold"})
+	_, err = newRateLimiter(cfg)
+	assert.EqualError(t, err, "rate limit tier gold of an API key not found")
+
+	cfg = getRateLimitTestConfig()
+	cfg.APIKeys = append(cfg.APIKeys, RateLimitAPIKeyConfig{Tier: "default"})
+	_, err = newRateLimiter(cfg)
+	assert.EqualError(t, err, "rate limit API key can't be empty")
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	l, err := newRateLimiter(getRateLimitTestConfig())
+	require.NoError(t, err)
+
+	newRequest := func(ip, apiKey string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = ip + ":1234"
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		return req
+	}
+
+	// method costs are case insensitive
+	res := l.allow(newRequest("10.0.0.1", ""), "eth_chainId")
+	assert.True(t, res.allowed)
+	assert.Equal(t, uint64(3), res.limit)
+	assert.Equal(t, uint64(1), res.remaining)
+
+	res = l.allow(newRequest("10.0.0.1", ""), "eth_chainId")
+	assert.False(t, res.allowed)
+	assert.Equal(t, uint64(1), res.remaining)
+	assert.Greater(t, res.retryAfter.Seconds(), float64(0))
+
+	// rejected requests don't consume the quota, the default cost applies to
+	// the methods without a specific cost
+	res = l.allow(newRequest("10.0.0.1", ""), "eth_blockNumber")
+	assert.True(t, res.allowed)
+	assert.Equal(t, uint64(0), res.remaining)
+
+	// every IP has its own quota
+	res = l.allow(newRequest("10.0.0.2", ""), "eth_chainId")
+	assert.True(t, res.allowed)
+
+	// known API keys use the quota of their tier, unknown ones are limited by IP
+	res = l.allow(newRequest("10.0.0.1", "premium-key"), "eth_chainId")
+	assert.True(t, res.allowed)
+	assert.Equal(t, uint64(10), res.limit)
+	assert.Equal(t, uint64(8), res.remaining)
+
+	res = l.allow(newRequest("10.0.0.1", "unknown-key"), "eth_blockNumber")
+	assert.False(t, res.allowed)
+
+	// the API key can be provided as query param
+	req := httptest.NewRequest(http.MethodGet, "/?apikey=premium-key", nil)
+	res = l.allow(req, "eth_chainId")
+	assert.True(t, res.allowed)
+	assert.Equal(t, uint64(6), res.remaining)
+
+	// methods costing more than the burst are never allowed
+	res = l.allow(newRequest("10.0.0.3", ""), "debug_traceCall")
+	assert.False(t, res.allowed)
+	assert.Zero(t, res.retryAfter)
+	assert.Equal(t, "rate limit exceeded: the cost of debug_traceCall is greater than the quota", res.rpcError("debug_traceCall").Error())
+}
+
+func TestRateLimitRequests(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.RateLimit = getRateLimitTestConfig()
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	m.Storage.On("UninstallFilterByWSConn", mock.Anything).Return(nil).Maybe()
+
+	doRequest := func(t *testing.T, body interface{}, apiKey string) (*http.Response, []byte) {
+		reqBody, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, s.ServerURL, bytes.NewReader(reqBody))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		httpRes, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer httpRes.Body.Close()
+		resBody, err := io.ReadAll(httpRes.Body)
+		require.NoError(t, err)
+		return httpRes, resBody
+	}
+	newRequest := func(id int, method string) types.Request {
+		return types.Request{JSONRPC: "2.0", ID: float64(id), Method: method, Params: json.RawMessage("[]")}
+	}
+
+	t.Run("single requests", func(t *testing.T) {
+		httpRes, resBody := doRequest(t, newRequest(1, "eth_chainId"), "")
+		require.Equal(t, http.StatusOK, httpRes.StatusCode)
+		assert.Equal(t, "3", httpRes.Header.Get(rateLimitLimitHeader))
+		assert.Equal(t, "1", httpRes.Header.Get(rateLimitRemainingHeader))
+		var res types.Response
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		assert.Nil(t, res.Error)
+
+		httpRes, resBody = doRequest(t, newRequest(2, "eth_chainId"), "")
+		require.Equal(t, http.StatusTooManyRequests, httpRes.StatusCode)
+		assert.Equal(t, "1", httpRes.Header.Get(rateLimitRemainingHeader))
+		assert.NotEmpty(t, httpRes.Header.Get(retryAfterHeader))
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.LimitExceededErrorCode, res.Error.Code)
+	})
+
+	t.Run("batch requests", func(t *testing.T) {
+		httpRes, resBody := doRequest(t, []types.Request{
+			newRequest(1, "eth_chainId"),
+			newRequest(2, "net_version"),
+			newRequest(3, "eth_chainId"),
+		}, "premium-key")
+		require.Equal(t, http.StatusOK, httpRes.StatusCode)
+
+		var res []types.Response
+		require.NoError(t, json.Unmarshal(resBody, &res))
+		require.Len(t, res, 3)
+		assert.Nil(t, res[0].Error)
+		assert.Nil(t, res[1].Error)
+		assert.Nil(t, res[2].Error)
+
+		responses, err := client.JSONRPCBatchCall(s.ServerURL,
+			client.BatchCall{Method: "eth_chainId"},
+			client.BatchCall{Method: "net_version"},
+		)
+		require.NoError(t, err)
+		require.Len(t, responses, 2)
+		// the quota of the IP was consumed by the single requests
+		require.NotNil(t, responses[0].Error)
+		assert.Equal(t, types.LimitExceededErrorCode, responses[0].Error.Code)
+		assert.Nil(t, responses[1].Error)
+	})
+
+	t.Run("web socket messages", func(t *testing.T) {
+		wsConn, _, err := websocket.DefaultDialer.Dial(s.ServerWebSocketsURL+"?apikey=premium-key", nil)
+		require.NoError(t, err)
+		defer wsConn.Close()
+
+		// the premium quota has 5 units left after the batch request
+		expectedErrors := []bool{false, false, true}
+		for i, expectedError := range expectedErrors {
+			message, err := json.Marshal(newRequest(i, "eth_chainId"))
+			require.NoError(t, err)
+			require.NoError(t, wsConn.WriteMessage(websocket.TextMessage, message))
+
+			_, resBody, err := wsConn.ReadMessage()
+			require.NoError(t, err)
+			var res types.Response
+			require.NoError(t, json.Unmarshal(resBody, &res))
+			if expectedError {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, types.LimitExceededErrorCode, res.Error.Code)
+			} else {
+				assert.Nil(t, res.Error)
+			}
+		}
+	})
+}
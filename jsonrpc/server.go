@@ -18,6 +18,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/didip/tollbooth/v6"
 	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
 )
 
 const (
@@ -50,6 +51,12 @@ type Server struct {
 	srv        *http.Server
 	wsSrv      *http.Server
 	wsUpgrader websocket.Upgrader
+
+	graphQLSrv    *http.Server
+	graphQLSchema *graphql.Schema
+
+	rateLimiter   *rateLimiter
+	authenticator *authenticator
 }
 
 // Service defines a struct that will provide public methods to be exposed
@@ -76,9 +83,12 @@ func NewServer(
 ) *Server {
 	if cfg.WebSockets.Enabled {
 		s.StartToMonitorNewL2Blocks()
+		s.StartToMonitorNewBatches()
+		p.StartToMonitorNewPendingTxs()
 	}
 
 	handler := newJSONRpcHandler()
+	handler.cache = newResponseCache(cfg.ResponseCache, s)
 
 	for _, service := range services {
 		handler.registerService(service)
@@ -96,10 +106,31 @@ func NewServer(
 func (s *Server) Start() error {
 	metrics.Register()
 
+	rateLimiter, err := newRateLimiter(s.config.RateLimit)
+	if err != nil {
+		return fmt.Errorf("failed to create the rate limiter: %w", err)
+	}
+	s.rateLimiter = rateLimiter
+
+	authenticator, err := newAuthenticator(s.config.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to create the authenticator: %w", err)
+	}
+	s.authenticator = authenticator
+
 	if s.config.WebSockets.Enabled {
 		go s.startWS()
 	}
 
+	if s.config.GraphQL.Enabled {
+		graphQLSchema, err := newGraphQLSchema(s.config.GraphQL, s.handler)
+		if err != nil {
+			return fmt.Errorf("failed to create the GraphQL schema: %w", err)
+		}
+		s.graphQLSchema = graphQLSchema
+		go s.startGraphQL()
+	}
+
 	return s.startHTTP()
 }
 
@@ -181,6 +212,45 @@ func (s *Server) startWS() {
 	}
 }
 
+// startGraphQL starts a server to respond GraphQL queries
+func (s *Server) startGraphQL() {
+	log.Infof("starting GraphQL server")
+
+	if s.graphQLSrv != nil {
+		log.Errorf("GraphQL server already started")
+		return
+	}
+
+	address := fmt.Sprintf("%s:%d", s.config.GraphQL.Host, s.config.GraphQL.Port)
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Errorf("failed to create tcp listener: %v", err)
+		return
+	}
+
+	mux := http.NewServeMux()
+
+	lmt := tollbooth.NewLimiter(s.config.MaxRequestsPerIPAndSecond, nil)
+	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handleGraphQL))
+
+	s.graphQLSrv = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: s.config.ReadTimeout.Duration,
+		ReadTimeout:       s.config.ReadTimeout.Duration,
+		WriteTimeout:      s.config.WriteTimeout.Duration,
+	}
+	log.Infof("GraphQL server started: %s", address)
+	if err := s.graphQLSrv.Serve(lis); err != nil {
+		if err == http.ErrServerClosed {
+			log.Infof("GraphQL server stopped")
+			return
+		}
+		log.Errorf("closed GraphQL connection: %v", err)
+		return
+	}
+}
+
 // Stop shutdown the rpc server
 func (s *Server) Stop() error {
 	if s.srv != nil {
@@ -205,6 +275,17 @@ func (s *Server) Stop() error {
 		s.wsSrv = nil
 	}
 
+	if s.graphQLSrv != nil {
+		if err := s.graphQLSrv.Shutdown(context.Background()); err != nil {
+			return err
+		}
+
+		if err := s.graphQLSrv.Close(); err != nil {
+			return err
+		}
+		s.graphQLSrv = nil
+	}
+
 	return nil
 }
 
@@ -212,7 +293,7 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key")
 
 	if req.Method == http.MethodOptions {
 		return
@@ -300,8 +381,17 @@ func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseW
 		handleInvalidRequest(w, err, http.StatusBadRequest)
 		return 0
 	}
-	req := handleRequest{Request: request, HttpRequest: httpRequest}
-	response := s.handler.Handle(req)
+	var response types.Response
+	if rpcErr := s.checkAuth(s.authenticate(httpRequest), authMethod(request)); rpcErr != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		response = types.NewResponse(request, nil, rpcErr)
+	} else if rpcErr := s.checkRateLimit(w, httpRequest, request.Method); rpcErr != nil {
+		w.WriteHeader(http.StatusTooManyRequests)
+		response = types.NewResponse(request, nil, rpcErr)
+	} else {
+		req := handleRequest{Request: request, HttpRequest: httpRequest}
+		response = s.handler.Handle(req)
+	}
 
 	respBytes, err := json.Marshal(response)
 	if err != nil {
@@ -341,7 +431,16 @@ func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWr
 
 	responses := make([]types.Response, 0, len(requests))
 
+	grant := s.authenticate(httpRequest)
 	for _, request := range requests {
+		if rpcErr := s.checkAuth(grant, authMethod(request)); rpcErr != nil {
+			responses = append(responses, types.NewResponse(request, nil, rpcErr))
+			continue
+		}
+		if rpcErr := s.checkRateLimit(w, httpRequest, request.Method); rpcErr != nil {
+			responses = append(responses, types.NewResponse(request, nil, rpcErr))
+			continue
+		}
 		req := handleRequest{Request: request, HttpRequest: httpRequest}
 		response := s.handler.Handle(req)
 		responses = append(responses, response)
@@ -402,6 +501,10 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 
 	s.increaseWsConnCounter()
 
+	// the credentials are checked once per connection, so JWTs only need
+	// to be valid when the connection is established
+	grant := s.authenticate(req)
+
 	// recover
 	defer func() {
 		if err := recover(); err != nil {
@@ -427,6 +530,10 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 
 		if msgType == websocket.TextMessage || msgType == websocket.BinaryMessage {
+			if resp := s.checkWsRequest(req, grant, message); resp != nil {
+				_ = wsConn.WriteMessage(msgType, resp)
+				continue
+			}
 			resp, err := s.handler.HandleWs(message, wsConn, req)
 			if err != nil {
 				log.Error(fmt.Sprintf("Unable to handle WS request, %s", err.Error()))
@@ -438,6 +545,74 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// checkRateLimit consumes the cost of the method from the quota of the client
+// and returns an error if the request exceeds it. The rate limit headers are
+// set to the response writer when provided
+func (s *Server) checkRateLimit(w http.ResponseWriter, httpRequest *http.Request, method string) types.Error {
+	if s.rateLimiter == nil {
+		return nil
+	}
+
+	result := s.rateLimiter.allow(httpRequest, method)
+	if w != nil {
+		result.writeHeaders(w)
+	}
+	if result.allowed {
+		return nil
+	}
+	metrics.RequestRateLimited()
+	return result.rpcError(method)
+}
+
+// authenticate returns the protected namespaces the client sending the request can access
+func (s *Server) authenticate(httpRequest *http.Request) authGrant {
+	if s.authenticator == nil {
+		return authGrant{}
+	}
+	return s.authenticator.authenticate(httpRequest, time.Now())
+}
+
+// checkAuth returns an error if the grant doesn't give access to the namespace of the method
+func (s *Server) checkAuth(grant authGrant, method string) types.Error {
+	if s.authenticator == nil {
+		return nil
+	}
+
+	rpcErr := s.authenticator.authorize(grant, method)
+	if rpcErr != nil {
+		metrics.RequestUnauthorized()
+	}
+	return rpcErr
+}
+
+// checkWsRequest checks the access control and the rate limit of a web socket
+// message and returns the response to be sent if the request is rejected
+func (s *Server) checkWsRequest(httpRequest *http.Request, grant authGrant, message []byte) []byte {
+	if s.authenticator == nil && s.rateLimiter == nil {
+		return nil
+	}
+
+	var request types.Request
+	if err := json.Unmarshal(message, &request); err != nil {
+		// invalid requests are rejected by the handler
+		return nil
+	}
+
+	rpcErr := s.checkAuth(grant, authMethod(request))
+	if rpcErr == nil {
+		rpcErr = s.checkRateLimit(nil, httpRequest, request.Method)
+	}
+	if rpcErr == nil {
+		return nil
+	}
+	resp, err := types.NewResponse(request, nil, rpcErr).Bytes()
+	if err != nil {
+		log.Errorf("failed to encode the rejection response: %v", err)
+		return nil
+	}
+	return resp
+}
+
 func (s *Server) increaseHttpConnCounter() {
 	metrics.CountConn(metrics.HTTPConnLabel)
 }
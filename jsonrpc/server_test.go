@@ -63,6 +63,13 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	var newL2BlockEventHandler state.NewL2BlockEventHandler = func(e state.NewL2BlockEvent) {}
 	st.On("RegisterNewL2BlockEventHandler", mock.IsType(newL2BlockEventHandler)).Once()
 	st.On("StartToMonitorNewL2Blocks").Once()
+	var newBatchEventHandler state.NewBatchEventHandler = func(e state.NewBatchEvent) {}
+	st.On("RegisterNewBatchEventHandler", mock.IsType(newBatchEventHandler)).Once()
+	var syncingStatusEventHandler state.SyncingStatusEventHandler = func(e state.SyncingStatusEvent) {}
+	st.On("RegisterSyncingStatusEventHandler", mock.IsType(syncingStatusEventHandler)).Once()
+	st.On("StartToMonitorNewBatches").Once()
+	pool.On("RegisterNewPendingTxEventHandler", mock.AnythingOfType("pool.NewPendingTxEventHandler")).Once()
+	pool.On("StartToMonitorNewPendingTxs").Once()
 
 	services := []Service{}
 	if _, ok := apis[APIEth]; ok {
@@ -89,7 +96,7 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	if _, ok := apis[APITxPool]; ok {
 		services = append(services, Service{
 			Name:    APITxPool,
-			Service: &TxPoolEndpoints{},
+			Service: NewTxPoolEndpoints(cfg, pool),
 		})
 	}
 
@@ -151,14 +158,17 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 
 func getSequencerDefaultConfig() Config {
 	cfg := Config{
-		Host:                         "0.0.0.0",
-		Port:                         9123,
-		MaxRequestsPerIPAndSecond:    maxRequestsPerIPAndSecond,
-		MaxCumulativeGasUsed:         300000,
-		BatchRequestsEnabled:         true,
-		MaxLogsCount:                 10000,
-		MaxLogsBlockRange:            10000,
-		MaxNativeBlockHashBlockRange: 60000,
+		Host:                          "0.0.0.0",
+		Port:                          9123,
+		MaxRequestsPerIPAndSecond:     maxRequestsPerIPAndSecond,
+		MaxCumulativeGasUsed:          300000,
+		BatchRequestsEnabled:          true,
+		MaxLogsCount:                  10000,
+		MaxLogsBlockRange:             10000,
+		MaxNativeBlockHashBlockRange:  60000,
+		MaxTxPoolContentAccounts:      1000,
+		MaxFeeHistoryBlockCount:       1024,
+		MaxFeeHistoryRewardBlockCount: 2,
 		WebSockets: WebSocketsConfig{
 			Enabled:   true,
 			Host:      "0.0.0.0",
@@ -352,7 +362,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"application/json"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "",
 		},
@@ -364,7 +374,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"application/json"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "zkEVM JSON RPC Server",
 		},
@@ -376,7 +386,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "",
 		},
@@ -388,7 +398,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method PUT not allowed\n",
 		},
@@ -400,7 +410,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method PATCH not allowed\n",
 		},
@@ -412,7 +422,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method DELETE not allowed\n",
 		},
@@ -433,7 +443,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "method TRACE not allowed\n",
 		},
@@ -446,7 +456,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "content length too large (5242881>5242880)\n",
 		},
@@ -459,7 +469,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid content type, only application/json is supported\n",
 		},
@@ -473,7 +483,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "empty request body\n",
 		},
@@ -487,7 +497,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid json object request body\n",
 		},
@@ -501,7 +511,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid json object request body\n",
 		},
@@ -515,7 +525,7 @@ func TestRequestValidation(t *testing.T) {
 				"Content-Type":                 {"text/plain; charset=utf-8"},
 				"Access-Control-Allow-Origin":  {"*"},
 				"Access-Control-Allow-Methods": {"POST, OPTIONS"},
-				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"},
+				"Access-Control-Allow-Headers": {"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key"},
 			},
 			ExpectedMessage: "invalid json array request body\n",
 		},
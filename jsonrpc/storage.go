@@ -26,22 +26,29 @@ type Storage struct {
 	logFiltersWithWSConn       map[string]*Filter
 	pendingTxFiltersWithWSConn map[string]*Filter
 
-	blockMutex     *sync.Mutex
-	logMutex       *sync.Mutex
-	pendingTxMutex *sync.Mutex
+	// subscriptionFiltersWithWSConn keeps the web socket subscriptions that
+	// don't have a filter API counterpart, grouped by type
+	subscriptionFiltersWithWSConn map[FilterType]map[string]*Filter
+
+	blockMutex        *sync.Mutex
+	logMutex          *sync.Mutex
+	pendingTxMutex    *sync.Mutex
+	subscriptionMutex *sync.Mutex
 }
 
 // NewStorage creates and initializes an instance of Storage
 func NewStorage() *Storage {
 	return &Storage{
-		allFilters:                 make(map[string]*Filter),
-		allFiltersWithWSConn:       make(map[*concurrentWsConn]map[string]*Filter),
-		blockFiltersWithWSConn:     make(map[string]*Filter),
-		logFiltersWithWSConn:       make(map[string]*Filter),
-		pendingTxFiltersWithWSConn: make(map[string]*Filter),
-		blockMutex:                 &sync.Mutex{},
-		logMutex:                   &sync.Mutex{},
-		pendingTxMutex:             &sync.Mutex{},
+		allFilters:                    make(map[string]*Filter),
+		allFiltersWithWSConn:          make(map[*concurrentWsConn]map[string]*Filter),
+		blockFiltersWithWSConn:        make(map[string]*Filter),
+		logFiltersWithWSConn:          make(map[string]*Filter),
+		pendingTxFiltersWithWSConn:    make(map[string]*Filter),
+		subscriptionFiltersWithWSConn: make(map[FilterType]map[string]*Filter),
+		blockMutex:                    &sync.Mutex{},
+		logMutex:                      &sync.Mutex{},
+		pendingTxMutex:                &sync.Mutex{},
+		subscriptionMutex:             &sync.Mutex{},
 	}
 }
 
@@ -60,8 +67,14 @@ func (s *Storage) NewBlockFilter(wsConn *concurrentWsConn) (string, error) {
 }
 
 // NewPendingTransactionFilter persists a new pending transaction filter
-func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
-	return s.createFilter(FilterTypePendingTx, nil, wsConn)
+func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn, filter PendingTxFilter) (string, error) {
+	return s.createFilter(FilterTypePendingTx, filter, wsConn)
+}
+
+// NewSubscriptionFilter persists a new web socket subscription filter of
+// the provided type
+func (s *Storage) NewSubscriptionFilter(wsConn *concurrentWsConn, t FilterType) (string, error) {
+	return s.createFilter(t, nil, wsConn)
 }
 
 // create persists the filter to the memory and provides the filter id
@@ -75,9 +88,11 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.subscriptionMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.subscriptionMutex.Unlock()
 
 	f := &Filter{
 		ID:            id,
@@ -104,6 +119,11 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 			s.logFiltersWithWSConn[id] = f
 		} else if t == FilterTypePendingTx {
 			s.pendingTxFiltersWithWSConn[id] = f
+		} else {
+			if _, found := s.subscriptionFiltersWithWSConn[t]; !found {
+				s.subscriptionFiltersWithWSConn[t] = make(map[string]*Filter)
+			}
+			s.subscriptionFiltersWithWSConn[t][id] = f
 		}
 	}
 	return id, nil
@@ -138,6 +158,20 @@ func (s *Storage) GetAllBlockFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllPendingTxFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new pending transactions
+func (s *Storage) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	s.pendingTxMutex.Lock()
+	defer s.pendingTxMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.pendingTxFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetAllLogFiltersWithWSConn returns an array with all filter that have
 // a web socket connection and are filtering by new logs
 func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
@@ -152,14 +186,30 @@ func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllSubscriptionFiltersWithWSConn returns an array with all the web
+// socket subscription filters of the provided type
+func (s *Storage) GetAllSubscriptionFiltersWithWSConn(t FilterType) []*Filter {
+	s.subscriptionMutex.Lock()
+	defer s.subscriptionMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.subscriptionFiltersWithWSConn[t] {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetFilter gets a filter by its id
 func (s *Storage) GetFilter(filterID string) (*Filter, error) {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.subscriptionMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.subscriptionMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -174,9 +224,11 @@ func (s *Storage) UpdateFilterLastPoll(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.subscriptionMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.subscriptionMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -192,9 +244,11 @@ func (s *Storage) UninstallFilter(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.subscriptionMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.subscriptionMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -210,9 +264,11 @@ func (s *Storage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.subscriptionMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.subscriptionMutex.Unlock()
 
 	filters, found := s.allFiltersWithWSConn[wsConn]
 	if !found {
@@ -234,6 +290,8 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		delete(s.logFiltersWithWSConn, filter.ID)
 	} else if filter.Type == FilterTypePendingTx {
 		delete(s.pendingTxFiltersWithWSConn, filter.ID)
+	} else {
+		delete(s.subscriptionFiltersWithWSConn[filter.Type], filter.ID)
 	}
 
 	if filter.WsConn != nil {
@@ -0,0 +1,281 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	// FilterStorageTypeMemory keeps the filters in the memory of the json rpc
+	// server instance that created them
+	FilterStorageTypeMemory = "memory"
+	// FilterStorageTypePostgres persists the filters in postgres, so they are
+	// shared by all the json rpc server instances using the same database
+	FilterStorageTypePostgres = "postgres"
+)
+
+// NewFilterStorage creates the filter storage selected by the config, the
+// postgres storage uses the provided db config
+func NewFilterStorage(cfg FilterStorageConfig, dbCfg db.Config) (storageInterface, error) {
+	switch cfg.Type {
+	case "", FilterStorageTypeMemory:
+		return NewStorage(), nil
+	case FilterStorageTypePostgres:
+		return NewPostgresStorage(dbCfg, cfg.FilterTimeout.Duration)
+	default:
+		return nil, fmt.Errorf("invalid filter storage type: %v", cfg.Type)
+	}
+}
+
+// PostgresStorage uses postgres to store the filters created via HTTP, so a
+// filter created in a json rpc server instance can be polled from any other
+// instance sharing the database.
+// Filters bound to a web socket connection only make sense in the instance
+// holding the connection, so they are kept in memory.
+type PostgresStorage struct {
+	*Storage
+	db            *pgxpool.Pool
+	filterTimeout time.Duration
+}
+
+// NewPostgresStorage creates and initializes an instance of PostgresStorage,
+// the filters not polled within the filter timeout are removed, zero means
+// filters never expire
+func NewPostgresStorage(cfg db.Config, filterTimeout time.Duration) (*PostgresStorage, error) {
+	filterDB, err := db.NewSQLDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		Storage:       NewStorage(),
+		db:            filterDB,
+		filterTimeout: filterTimeout,
+	}, nil
+}
+
+// NewLogFilter persists a new log filter
+func (s *PostgresStorage) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error) {
+	if wsConn != nil {
+		return s.Storage.NewLogFilter(wsConn, filter)
+	}
+
+	if err := filter.Validate(); err != nil {
+		return "", err
+	}
+
+	return s.insertFilter(FilterTypeLog, filter)
+}
+
+// NewBlockFilter persists a new block log filter
+func (s *PostgresStorage) NewBlockFilter(wsConn *concurrentWsConn) (string, error) {
+	if wsConn != nil {
+		return s.Storage.NewBlockFilter(wsConn)
+	}
+	return s.insertFilter(FilterTypeBlock, nil)
+}
+
+// NewPendingTransactionFilter persists a new pending transaction filter,
+// the filter parameters only apply to the web socket subscriptions
+func (s *PostgresStorage) NewPendingTransactionFilter(wsConn *concurrentWsConn, filter PendingTxFilter) (string, error) {
+	if wsConn != nil {
+		return s.Storage.NewPendingTransactionFilter(wsConn, filter)
+	}
+	return s.insertFilter(FilterTypePendingTx, nil)
+}
+
+// insertFilter persists the filter to the database and provides the filter id
+func (s *PostgresStorage) insertFilter(t FilterType, parameters interface{}) (string, error) {
+	ctx := context.Background()
+
+	id, err := s.generateFilterID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate filter ID: %w", err)
+	}
+
+	encodedParameters, err := encodeFilterParameters(t, parameters)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode filter parameters: %w", err)
+	}
+
+	// expired filters are removed when new ones are created, so the table
+	// doesn't grow with the filters that are never uninstalled
+	if err := s.deleteExpiredFilters(ctx); err != nil {
+		return "", err
+	}
+
+	const insertFilterSQL = "INSERT INTO rpc.filter (id, type, parameters, last_poll) VALUES ($1, $2, $3, $4)"
+	if _, err := s.db.Exec(ctx, insertFilterSQL, id, string(t), encodedParameters, time.Now().UTC()); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetFilter gets a filter by its id
+func (s *PostgresStorage) GetFilter(filterID string) (*Filter, error) {
+	filter, err := s.Storage.GetFilter(filterID)
+	if !errors.Is(err, ErrNotFound) {
+		return filter, err
+	}
+
+	var (
+		filterType        string
+		encodedParameters []byte
+		lastPoll          time.Time
+	)
+	const getFilterSQL = "SELECT type, parameters, last_poll FROM rpc.filter WHERE id = $1 AND last_poll >= $2"
+	err = s.db.QueryRow(context.Background(), getFilterSQL, filterID, s.expirationLimit()).Scan(&filterType, &encodedParameters, &lastPoll)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	parameters, err := decodeFilterParameters(FilterType(filterType), encodedParameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode parameters of filter %v: %w", filterID, err)
+	}
+
+	return &Filter{
+		ID:         filterID,
+		Type:       FilterType(filterType),
+		Parameters: parameters,
+		LastPoll:   lastPoll.UTC(),
+	}, nil
+}
+
+// UpdateFilterLastPoll updates the last poll to now
+func (s *PostgresStorage) UpdateFilterLastPoll(filterID string) error {
+	err := s.Storage.UpdateFilterLastPoll(filterID)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	const updateFilterLastPollSQL = "UPDATE rpc.filter SET last_poll = $2 WHERE id = $1 AND last_poll >= $3"
+	commandTag, err := s.db.Exec(context.Background(), updateFilterLastPollSQL, filterID, time.Now().UTC(), s.expirationLimit())
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UninstallFilter deletes a filter by its id
+func (s *PostgresStorage) UninstallFilter(filterID string) error {
+	err := s.Storage.UninstallFilter(filterID)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	const deleteFilterSQL = "DELETE FROM rpc.filter WHERE id = $1"
+	commandTag, err := s.db.Exec(context.Background(), deleteFilterSQL, filterID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// deleteExpiredFilters deletes the filters that were not polled within the filter timeout
+func (s *PostgresStorage) deleteExpiredFilters(ctx context.Context) error {
+	if s.filterTimeout == 0 {
+		return nil
+	}
+
+	const deleteExpiredFiltersSQL = "DELETE FROM rpc.filter WHERE last_poll < $1"
+	_, err := s.db.Exec(ctx, deleteExpiredFiltersSQL, s.expirationLimit())
+	return err
+}
+
+// expirationLimit returns the last poll time under which the filters are expired
+func (s *PostgresStorage) expirationLimit() time.Time {
+	if s.filterTimeout == 0 {
+		return time.Time{}
+	}
+	return time.Now().UTC().Add(-s.filterTimeout)
+}
+
+// pgLogFilter is the representation of a LogFilter persisted in the database.
+// Block numbers are stored as plain numbers to keep the block tags like latest
+// or pending, which are negative numbers.
+type pgLogFilter struct {
+	BlockHash *common.Hash     `json:"blockHash,omitempty"`
+	FromBlock *int64           `json:"fromBlock,omitempty"`
+	ToBlock   *int64           `json:"toBlock,omitempty"`
+	Addresses []common.Address `json:"addresses,omitempty"`
+	Topics    [][]common.Hash  `json:"topics,omitempty"`
+}
+
+// encodeFilterParameters encodes the parameters of the filter to be persisted,
+// only the log filters have parameters
+func encodeFilterParameters(t FilterType, parameters interface{}) (interface{}, error) {
+	if t != FilterTypeLog {
+		return nil, nil
+	}
+
+	logFilter, ok := parameters.(LogFilter)
+	if !ok {
+		return nil, fmt.Errorf("invalid log filter parameters type: %T", parameters)
+	}
+
+	pgFilter := pgLogFilter{
+		BlockHash: logFilter.BlockHash,
+		Addresses: logFilter.Addresses,
+		Topics:    logFilter.Topics,
+	}
+	if logFilter.FromBlock != nil {
+		fromBlock := int64(*logFilter.FromBlock)
+		pgFilter.FromBlock = &fromBlock
+	}
+	if logFilter.ToBlock != nil {
+		toBlock := int64(*logFilter.ToBlock)
+		pgFilter.ToBlock = &toBlock
+	}
+
+	encoded, err := json.Marshal(pgFilter)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// decodeFilterParameters decodes the parameters of a persisted filter
+func decodeFilterParameters(t FilterType, encoded []byte) (interface{}, error) {
+	if t != FilterTypeLog {
+		return nil, nil
+	}
+
+	var pgFilter pgLogFilter
+	if err := json.Unmarshal(encoded, &pgFilter); err != nil {
+		return nil, err
+	}
+
+	logFilter := LogFilter{
+		BlockHash: pgFilter.BlockHash,
+		Addresses: pgFilter.Addresses,
+		Topics:    pgFilter.Topics,
+	}
+	if pgFilter.FromBlock != nil {
+		fromBlock := types.BlockNumber(*pgFilter.FromBlock)
+		logFilter.FromBlock = &fromBlock
+	}
+	if pgFilter.ToBlock != nil {
+		toBlock := types.BlockNumber(*pgFilter.ToBlock)
+		logFilter.ToBlock = &toBlock
+	}
+	return logFilter, nil
+}
@@ -0,0 +1,73 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterParametersEncoding(t *testing.T) {
+	blockHash := common.HexToHash("0x1")
+	fromBlock := types.PendingBlockNumber
+	toBlock := types.BlockNumber(10)
+
+	testCases := []struct {
+		name   string
+		filter LogFilter
+	}{
+		{
+			name: "block hash",
+			filter: LogFilter{
+				BlockHash: &blockHash,
+				Addresses: []common.Address{common.HexToAddress("0x2"), common.HexToAddress("0x3")},
+			},
+		},
+		{
+			name: "block range with tags and topics",
+			filter: LogFilter{
+				FromBlock: &fromBlock,
+				ToBlock:   &toBlock,
+				Topics:    [][]common.Hash{{common.HexToHash("0x4")}, {}, {common.HexToHash("0x5"), common.HexToHash("0x6")}},
+			},
+		},
+		{
+			name:   "empty",
+			filter: LogFilter{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			encoded, err := encodeFilterParameters(FilterTypeLog, testCase.filter)
+			require.NoError(t, err)
+
+			decoded, err := decodeFilterParameters(FilterTypeLog, []byte(encoded.(string)))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.filter, decoded)
+		})
+	}
+
+	encoded, err := encodeFilterParameters(FilterTypeBlock, nil)
+	require.NoError(t, err)
+	assert.Nil(t, encoded)
+
+	_, err = encodeFilterParameters(FilterTypeLog, "invalid")
+	assert.EqualError(t, err, "invalid log filter parameters type: string")
+}
+
+func TestNewFilterStorage(t *testing.T) {
+	storage, err := NewFilterStorage(FilterStorageConfig{Type: FilterStorageTypeMemory}, db.Config{})
+	require.NoError(t, err)
+	assert.IsType(t, &Storage{}, storage)
+
+	storage, err = NewFilterStorage(FilterStorageConfig{}, db.Config{})
+	require.NoError(t, err)
+	assert.IsType(t, &Storage{}, storage)
+
+	_, err = NewFilterStorage(FilterStorageConfig{Type: "redis"}, db.Config{})
+	assert.EqualError(t, err, "invalid filter storage type: redis")
+}
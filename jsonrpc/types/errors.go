@@ -17,6 +17,8 @@ const (
 	ParserErrorCode = -32700
 	// AccessDeniedCode error code when requests are denied
 	AccessDeniedCode = -32800
+	// LimitExceededErrorCode error code when a request exceeds the rate limit
+	LimitExceededErrorCode = -32005
 )
 
 var (
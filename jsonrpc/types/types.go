@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -9,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -237,6 +240,113 @@ func (args *TxArgs) ToTransaction(ctx context.Context, st StateInterface, maxCum
 	return sender, tx, nil
 }
 
+// PrivateTransactionArgs is the argument of eth_sendPrivateTransaction, the
+// max block number is the last L2 block the tx can be included in
+type PrivateTransactionArgs struct {
+	Tx             string     `json:"tx"`
+	MaxBlockNumber *ArgUint64 `json:"maxBlockNumber,omitempty"`
+}
+
+// BundleArgs is the argument of eth_sendBundle, the raw txs are included
+// consecutively in the same L2 block in the provided order or not at all
+type BundleArgs struct {
+	Txs []string `json:"txs"`
+}
+
+// BundleResponse is the response of eth_sendBundle
+type BundleResponse struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// StateOverride is the collection of accounts to be ephemerally overridden
+// before executing a call
+type StateOverride map[common.Address]OverrideAccount
+
+// OverrideAccount indicates the overriding fields of an account during the
+// execution of a call
+type OverrideAccount struct {
+	Nonce     *ArgUint64                   `json:"nonce"`
+	Code      *ArgBytes                    `json:"code"`
+	Balance   *ArgBig                      `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// ToStateOverride converts the override to the format expected by the state
+func (so *StateOverride) ToStateOverride() (state.StateOverride, error) {
+	if so == nil {
+		return nil, nil
+	}
+	stateOverride := make(state.StateOverride, len(*so))
+	for address, account := range *so {
+		overrideAccount := state.OverrideAccount{}
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			overrideAccount.Nonce = &nonce
+		}
+		if account.Code != nil {
+			code := []byte(*account.Code)
+			overrideAccount.Code = &code
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = (*big.Int)(account.Balance)
+		}
+		if account.State != nil {
+			overrideAccount.State = *account.State
+		}
+		if account.StateDiff != nil {
+			overrideAccount.StateDiff = *account.StateDiff
+		}
+		stateOverride[address] = overrideAccount
+	}
+	if err := stateOverride.Validate(); err != nil {
+		return nil, err
+	}
+	return stateOverride, nil
+}
+
+// BlockOverrides is the set of block context fields to be overridden before
+// executing a call
+type BlockOverrides struct {
+	Number     *ArgBig         `json:"number"`
+	Difficulty *ArgBig         `json:"difficulty"`
+	Time       *ArgUint64      `json:"time"`
+	GasLimit   *ArgUint64      `json:"gasLimit"`
+	Coinbase   *common.Address `json:"coinbase"`
+	Random     *common.Hash    `json:"random"`
+	BaseFee    *ArgBig         `json:"baseFee"`
+}
+
+// ToBlockOverride converts the override to the format expected by the state,
+// the executor only allows to override the block timestamp and coinbase, so
+// an error is returned if any other field is set
+func (bo *BlockOverrides) ToBlockOverride() (*state.BlockOverride, error) {
+	if bo == nil {
+		return nil, nil
+	}
+	unsupported := []struct {
+		field string
+		isSet bool
+	}{
+		{"number", bo.Number != nil},
+		{"difficulty", bo.Difficulty != nil},
+		{"gasLimit", bo.GasLimit != nil},
+		{"random", bo.Random != nil},
+		{"baseFee", bo.BaseFee != nil},
+	}
+	for _, u := range unsupported {
+		if u.isSet {
+			return nil, fmt.Errorf("block override of '%s' is not supported", u.field)
+		}
+	}
+	blockOverride := &state.BlockOverride{Coinbase: bo.Coinbase}
+	if bo.Time != nil {
+		t := uint64(*bo.Time)
+		blockOverride.Time = &t
+	}
+	return blockOverride, nil
+}
+
 // Block structure
 type Block struct {
 	ParentHash      common.Hash         `json:"parentHash"`
@@ -716,6 +826,140 @@ func NewLog(l types.Log) Log {
 	}
 }
 
+// FeeHistory structure
+type FeeHistory struct {
+	OldestBlock   ArgUint64  `json:"oldestBlock"`
+	Reward        [][]ArgBig `json:"reward,omitempty"`
+	BaseFeePerGas []ArgBig   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+}
+
+// AccessListAdvisoryNote explains why the access list returned by
+// eth_createAccessList can't be attached to a transaction sent to this node
+const AccessListAdvisoryNote = "advisory only: the pool accepts legacy transactions only, so this access list can't be attached to a transaction sent to this network"
+
+// AccessListResult structure
+type AccessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	GasUsed    ArgUint64        `json:"gasUsed"`
+	Error      string           `json:"error,omitempty"`
+	Advisory   bool             `json:"advisory"`
+	Note       string           `json:"note"`
+}
+
+// AccountProof structure
+type AccountProof struct {
+	Address       common.Address `json:"address"`
+	StateRoot     common.Hash    `json:"stateRoot"`
+	Balance       ArgBig         `json:"balance"`
+	BalanceProof  []ArgBytes     `json:"balanceProof"`
+	Nonce         ArgUint64      `json:"nonce"`
+	NonceProof    []ArgBytes     `json:"nonceProof"`
+	CodeHash      common.Hash    `json:"codeHash"`
+	CodeHashProof []ArgBytes     `json:"codeHashProof"`
+	StorageProof  []StorageProof `json:"storageProof"`
+}
+
+// StorageProof structure
+type StorageProof struct {
+	Key   common.Hash `json:"key"`
+	Value ArgBig      `json:"value"`
+	Proof []ArgBytes  `json:"proof"`
+}
+
+// NewAccountProof creates an AccountProof instance from the merkle tree proof
+// of an account at the provided state root
+func NewAccountProof(stateRoot common.Hash, p *merkletree.AccountProof) AccountProof {
+	storageProof := make([]StorageProof, 0, len(p.Storage))
+	for _, sp := range p.Storage {
+		storageProof = append(storageProof, StorageProof{
+			Key:   common.BigToHash(sp.Position),
+			Value: ArgBig(*sp.Proof.Value),
+			Proof: encodeProofNodes(sp.Proof.Siblings),
+		})
+	}
+
+	return AccountProof{
+		Address:       p.Address,
+		StateRoot:     stateRoot,
+		Balance:       ArgBig(*p.Balance.Value),
+		BalanceProof:  encodeProofNodes(p.Balance.Siblings),
+		Nonce:         ArgUint64(p.Nonce.Value.Uint64()),
+		NonceProof:    encodeProofNodes(p.Nonce.Siblings),
+		CodeHash:      common.BigToHash(p.CodeHash.Value),
+		CodeHashProof: encodeProofNodes(p.CodeHash.Siblings),
+		StorageProof:  storageProof,
+	}
+}
+
+// MerkleTreeProof converts the AccountProof into a merkle tree proof that can
+// be verified against the state root with merkletree.VerifyAccountProof
+func (p AccountProof) MerkleTreeProof() (*merkletree.AccountProof, error) {
+	balanceSiblings, err := decodeProofNodes(p.BalanceProof)
+	if err != nil {
+		return nil, err
+	}
+	nonceSiblings, err := decodeProofNodes(p.NonceProof)
+	if err != nil {
+		return nil, err
+	}
+	codeHashSiblings, err := decodeProofNodes(p.CodeHashProof)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := make([]merkletree.StorageProof, 0, len(p.StorageProof))
+	for _, sp := range p.StorageProof {
+		siblings, err := decodeProofNodes(sp.Proof)
+		if err != nil {
+			return nil, err
+		}
+		value := big.Int(sp.Value)
+		storage = append(storage, merkletree.StorageProof{
+			Position: sp.Key.Big(),
+			Proof:    &merkletree.ValueProof{Value: &value, Siblings: siblings},
+		})
+	}
+
+	balance := big.Int(p.Balance)
+	return &merkletree.AccountProof{
+		Address:  p.Address,
+		Balance:  &merkletree.ValueProof{Value: &balance, Siblings: balanceSiblings},
+		Nonce:    &merkletree.ValueProof{Value: new(big.Int).SetUint64(uint64(p.Nonce)), Siblings: nonceSiblings},
+		CodeHash: &merkletree.ValueProof{Value: p.CodeHash.Big(), Siblings: codeHashSiblings},
+		Storage:  storage,
+	}, nil
+}
+
+// encodeProofNodes encodes each proof node as the concatenation of its
+// elements as 8 bytes big endian integers
+func encodeProofNodes(nodes [][]uint64) []ArgBytes {
+	encoded := make([]ArgBytes, 0, len(nodes))
+	for _, node := range nodes {
+		b := make([]byte, len(node)*8) //nolint:gomnd
+		for i, element := range node {
+			binary.BigEndian.PutUint64(b[i*8:], element) //nolint:gomnd
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
+
+func decodeProofNodes(encoded []ArgBytes) ([][]uint64, error) {
+	nodes := make([][]uint64, 0, len(encoded))
+	for _, b := range encoded {
+		if len(b)%8 != 0 { //nolint:gomnd
+			return nil, fmt.Errorf("invalid proof node length %d", len(b))
+		}
+		node := make([]uint64, len(b)/8) //nolint:gomnd
+		for i := range node {
+			node[i] = binary.BigEndian.Uint64(b[i*8:]) //nolint:gomnd
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
 // ExitRoots structure
 type ExitRoots struct {
 	BlockNumber     ArgUint64   `json:"blockNumber"`
@@ -724,6 +968,65 @@ type ExitRoots struct {
 	RollupExitRoot  common.Hash `json:"rollupExitRoot"`
 }
 
+// Lifecycle statuses of a tx returned by zkevm_getTransactionStatus
+const (
+	// TransactionStatusPending is the status of a tx waiting in the pool to be processed
+	TransactionStatusPending = "pending"
+	// TransactionStatusWIP is the status of a tx loaded by the sequencer to be processed
+	TransactionStatusWIP = "wip"
+	// TransactionStatusSelected is the status of a tx processed by the sequencer
+	// whose L2 block is not stored yet in the state of the node
+	TransactionStatusSelected = "selected"
+	// TransactionStatusMined is the status of a tx included in a trusted L2 block
+	TransactionStatusMined = "mined"
+	// TransactionStatusVirtualized is the status of a tx included in a L2 block sequenced on L1
+	TransactionStatusVirtualized = "virtualized"
+	// TransactionStatusVerified is the status of a tx included in a L2 block verified on L1
+	TransactionStatusVerified = "verified"
+	// TransactionStatusFailed is the status of a tx discarded by the sequencer
+	// after processing it or because it expired
+	TransactionStatusFailed = "failed"
+	// TransactionStatusInvalid is the status of a tx rejected by the sequencer
+	// because it can't be included in a batch
+	TransactionStatusInvalid = "invalid"
+)
+
+// TransactionStatus is the status of a tx in its lifecycle, the block and
+// batch are only set once it is included in a L2 block and the failed reason
+// once it is discarded
+type TransactionStatus struct {
+	Hash         common.Hash  `json:"hash"`
+	Status       string       `json:"status"`
+	BlockNumber  *ArgUint64   `json:"blockNumber,omitempty"`
+	BlockHash    *common.Hash `json:"blockHash,omitempty"`
+	BatchNumber  *ArgUint64   `json:"batchNumber,omitempty"`
+	FailedReason *string      `json:"failedReason,omitempty"`
+}
+
+// NewPoolTransactionStatus creates the status of a tx that is not included
+// in a L2 block yet from its status in the pool
+func NewPoolTransactionStatus(tx pool.Transaction) TransactionStatus {
+	res := TransactionStatus{Hash: tx.Hash()}
+	switch tx.Status {
+	case pool.TxStatusPending:
+		res.Status = TransactionStatusPending
+		if tx.IsWIP {
+			res.Status = TransactionStatusWIP
+		}
+	case pool.TxStatusSelected:
+		res.Status = TransactionStatusSelected
+	case pool.TxStatusFailed:
+		res.Status = TransactionStatusFailed
+		res.FailedReason = tx.FailedReason
+	case pool.TxStatusInvalid:
+		res.Status = TransactionStatusInvalid
+		res.FailedReason = tx.FailedReason
+	default:
+		res.Status = string(tx.Status)
+	}
+	return res
+}
+
 // ZKCounters counters for the tx
 type ZKCounters struct {
 	GasUsed              ArgUint64 `json:"gasUsed"`
@@ -774,19 +1077,42 @@ func NewZKCountersResponse(zkCounters state.ZKCounters, limits ZKCountersLimits,
 		oocErrMsg = &s
 	}
 	return ZKCountersResponse{
-		CountersUsed: ZKCounters{
-			GasUsed:              ArgUint64(zkCounters.GasUsed),
-			UsedKeccakHashes:     ArgUint64(zkCounters.KeccakHashes),
-			UsedPoseidonHashes:   ArgUint64(zkCounters.PoseidonHashes),
-			UsedPoseidonPaddings: ArgUint64(zkCounters.PoseidonPaddings),
-			UsedMemAligns:        ArgUint64(zkCounters.MemAligns),
-			UsedArithmetics:      ArgUint64(zkCounters.Arithmetics),
-			UsedBinaries:         ArgUint64(zkCounters.Binaries),
-			UsedSteps:            ArgUint64(zkCounters.Steps),
-			UsedSHA256Hashes:     ArgUint64(zkCounters.Sha256Hashes_V2),
-		},
+		CountersUsed:   NewZKCounters(zkCounters),
 		CountersLimits: limits,
 		Revert:         revert,
 		OOCError:       oocErrMsg,
 	}
 }
+
+// NewZKCounters converts the zk counters of the state to be returned by the RPC
+func NewZKCounters(zkCounters state.ZKCounters) ZKCounters {
+	return ZKCounters{
+		GasUsed:              ArgUint64(zkCounters.GasUsed),
+		UsedKeccakHashes:     ArgUint64(zkCounters.KeccakHashes),
+		UsedPoseidonHashes:   ArgUint64(zkCounters.PoseidonHashes),
+		UsedPoseidonPaddings: ArgUint64(zkCounters.PoseidonPaddings),
+		UsedMemAligns:        ArgUint64(zkCounters.MemAligns),
+		UsedArithmetics:      ArgUint64(zkCounters.Arithmetics),
+		UsedBinaries:         ArgUint64(zkCounters.Binaries),
+		UsedSteps:            ArgUint64(zkCounters.Steps),
+		UsedSHA256Hashes:     ArgUint64(zkCounters.Sha256Hashes_V2),
+	}
+}
+
+// ZKCountersBundleTxResponse contains the counters estimated for a tx of a bundle
+type ZKCountersBundleTxResponse struct {
+	CountersUsed           ZKCounters  `json:"countersUsed"`
+	CumulativeCountersUsed ZKCounters  `json:"cumulativeCountersUsed"`
+	Revert                 *RevertInfo `json:"revert,omitempty"`
+}
+
+// ZKCountersBundleResponse returned when the counters of a bundle of txs are
+// estimated. Transactions only contains the txs executed until the first one
+// overflowing the limits, if any, which is the one FirstOverflowIndex points to
+type ZKCountersBundleResponse struct {
+	Transactions       []ZKCountersBundleTxResponse `json:"transactions"`
+	CountersUsed       ZKCounters                   `json:"countersUsed"`
+	CountersLimits     ZKCountersLimits             `json:"countersLimit"`
+	FirstOverflowIndex *ArgUint64                   `json:"firstOverflowIndex,omitempty"`
+	OOCError           *string                      `json:"oocError,omitempty"`
+}
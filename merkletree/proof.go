@@ -0,0 +1,361 @@
+package merkletree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/hashdb"
+	"github.com/ethereum/go-ethereum/common"
+	poseidon "github.com/iden3/go-iden3-crypto/goldenposeidon"
+)
+
+const (
+	// maxKeyLevels is the max depth of the state tree, one level per key bit
+	maxKeyLevels = 256
+	// nodeValueElements is the number of elements of a node hashed as value
+	nodeValueElements = 8
+	// nodeElements is the number of elements of a node, value and capacity
+	nodeElements = 12
+)
+
+var (
+	// ErrInvalidProof indicates that a proof doesn't match the provided state root
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// ValueProof is a proof of the value of a leaf of the state tree, it contains
+// the nodes of the tree from the root to the leaf found in the path of the key.
+// If there is no leaf for the key, the proof ends with an empty node or with the
+// leaf of a different key that shares the path, proving the value is zero.
+type ValueProof struct {
+	// Value is the value of the leaf
+	Value *big.Int
+	// Siblings are the nodes of the path, each one with 8 value elements
+	// followed by 4 capacity elements
+	Siblings [][]uint64
+}
+
+// StorageProof is the proof of the value of a storage position of a contract.
+type StorageProof struct {
+	// Position is the storage position
+	Position *big.Int
+	// Proof is the proof of the value stored at the position
+	Proof *ValueProof
+}
+
+// AccountProof contains the proofs of the balance, nonce, code hash and a set
+// of storage positions of an address.
+type AccountProof struct {
+	// Address is the address of the account
+	Address common.Address
+	// Balance is the proof of the account balance
+	Balance *ValueProof
+	// Nonce is the proof of the account nonce
+	Nonce *ValueProof
+	// CodeHash is the proof of the account code hash
+	CodeHash *ValueProof
+	// Storage are the proofs of the requested storage positions
+	Storage []StorageProof
+}
+
+// GetAccountProof returns the proofs of the balance, nonce, code hash and the
+// provided storage positions of an address.
+func (tree *StateTree) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root []byte) (*AccountProof, error) {
+	r := scalarToh4(new(big.Int).SetBytes(root))
+
+	balanceKey, err := KeyEthAddrBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	balanceProof, err := tree.getProof(ctx, r, balanceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceKey, err := KeyEthAddrNonce(address)
+	if err != nil {
+		return nil, err
+	}
+	nonceProof, err := tree.getProof(ctx, r, nonceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	codeHashKey, err := KeyContractCode(address)
+	if err != nil {
+		return nil, err
+	}
+	codeHashProof, err := tree.getProof(ctx, r, codeHashKey)
+	if err != nil {
+		return nil, err
+	}
+
+	storageProofs := make([]StorageProof, 0, len(positions))
+	for _, position := range positions {
+		storageKey, err := KeyContractStorage(address, position.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		storageProof, err := tree.getProof(ctx, r, storageKey)
+		if err != nil {
+			return nil, err
+		}
+		storageProofs = append(storageProofs, StorageProof{Position: position, Proof: storageProof})
+	}
+
+	return &AccountProof{
+		Address:  address,
+		Balance:  balanceProof,
+		Nonce:    nonceProof,
+		CodeHash: codeHashProof,
+		Storage:  storageProofs,
+	}, nil
+}
+
+func (tree *StateTree) getProof(ctx context.Context, root []uint64, keyBytes []byte) (*ValueProof, error) {
+	key := scalarToh4(new(big.Int).SetBytes(keyBytes))
+	result, err := tree.grpcClient.Get(ctx, &hashdb.GetRequest{
+		Root:    &hashdb.Fea{Fe0: root[0], Fe1: root[1], Fe2: root[2], Fe3: root[3]},
+		Key:     &hashdb.Fea{Fe0: key[0], Fe1: key[1], Fe2: key[2], Fe3: key[3]},
+		Details: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := string2fea(result.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]uint64, 0, len(result.Siblings))
+	for level := range result.Siblings {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	siblings := make([][]uint64, 0, len(levels)+1)
+	for i, level := range levels {
+		if level != uint64(i) {
+			return nil, fmt.Errorf("missing proof node at level %d", i)
+		}
+		siblings = append(siblings, result.Siblings[level].GetSibling())
+	}
+
+	// the node of the leaf found in the path of the key is not always part of
+	// the siblings returned by the hashdb, in that case it is rebuilt from the
+	// found key and value
+	if len(siblings) > 0 && !isLeafNode(siblings[len(siblings)-1]) {
+		level := len(siblings)
+		last := siblings[level-1]
+		bit := splitKey(key)[level-1]
+		if len(last) >= nodeValueElements && !isZeroH4(last[bit*4:bit*4+4]) {
+			leafKey, leafValue := key, value
+			if fea2scalar(value).Sign() == 0 && result.InsKey != nil {
+				leafKey = []uint64{result.InsKey.Fe0, result.InsKey.Fe1, result.InsKey.Fe2, result.InsKey.Fe3}
+				if leafValue, err = string2fea(result.InsValue); err != nil {
+					return nil, err
+				}
+			}
+			leaf, err := leafNode(removeKeyBits(leafKey, level), leafValue)
+			if err != nil {
+				return nil, err
+			}
+			siblings = append(siblings, leaf)
+		}
+	}
+
+	return &ValueProof{
+		Value:    fea2scalar(value),
+		Siblings: siblings,
+	}, nil
+}
+
+// VerifyAccountProof checks the proofs of the balance, nonce, code hash and
+// storage positions of an account against the provided state root.
+func VerifyAccountProof(root []byte, proof *AccountProof) error {
+	if proof == nil || proof.Balance == nil || proof.Nonce == nil || proof.CodeHash == nil {
+		return fmt.Errorf("%w: incomplete account proof", ErrInvalidProof)
+	}
+
+	balanceKey, err := KeyEthAddrBalance(proof.Address)
+	if err != nil {
+		return err
+	}
+	if err := VerifyProof(root, balanceKey, proof.Balance); err != nil {
+		return fmt.Errorf("balance: %w", err)
+	}
+
+	nonceKey, err := KeyEthAddrNonce(proof.Address)
+	if err != nil {
+		return err
+	}
+	if err := VerifyProof(root, nonceKey, proof.Nonce); err != nil {
+		return fmt.Errorf("nonce: %w", err)
+	}
+
+	codeHashKey, err := KeyContractCode(proof.Address)
+	if err != nil {
+		return err
+	}
+	if err := VerifyProof(root, codeHashKey, proof.CodeHash); err != nil {
+		return fmt.Errorf("code hash: %w", err)
+	}
+
+	for _, storageProof := range proof.Storage {
+		if storageProof.Position == nil || storageProof.Proof == nil {
+			return fmt.Errorf("%w: incomplete storage proof", ErrInvalidProof)
+		}
+		storageKey, err := KeyContractStorage(proof.Address, storageProof.Position.Bytes())
+		if err != nil {
+			return err
+		}
+		if err := VerifyProof(root, storageKey, storageProof.Proof); err != nil {
+			return fmt.Errorf("storage position %v: %w", storageProof.Position, err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyProof checks that the proof nodes link the provided state root with
+// the leaf of the key and that the leaf holds the value of the proof. A zero
+// value is proven by a path ending in an empty node or in the leaf of another
+// key, as zero values are not stored in the tree.
+func VerifyProof(root []byte, key []byte, proof *ValueProof) error {
+	if proof == nil || proof.Value == nil {
+		return fmt.Errorf("%w: missing value", ErrInvalidProof)
+	}
+
+	k := scalarToh4(new(big.Int).SetBytes(key))
+	keyBits := splitKey(k)
+	expectedHash := scalarToh4(new(big.Int).SetBytes(root))
+
+	for level := 0; level <= maxKeyLevels; level++ {
+		if isZeroH4(expectedHash) {
+			if level != len(proof.Siblings) {
+				return fmt.Errorf("%w: unexpected nodes after empty node at level %d", ErrInvalidProof, level)
+			}
+			if proof.Value.Sign() != 0 {
+				return fmt.Errorf("%w: value not found in the tree", ErrInvalidProof)
+			}
+			return nil
+		}
+
+		if level >= len(proof.Siblings) || level == maxKeyLevels {
+			return fmt.Errorf("%w: missing node at level %d", ErrInvalidProof, level)
+		}
+		node := proof.Siblings[level]
+		nodeHash, err := hashNode(node)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+		}
+		if !equalH4(nodeHash, expectedHash) {
+			return fmt.Errorf("%w: node hash mismatch at level %d", ErrInvalidProof, level)
+		}
+
+		if isLeafNode(node) {
+			if level != len(proof.Siblings)-1 {
+				return fmt.Errorf("%w: unexpected nodes after leaf at level %d", ErrInvalidProof, level)
+			}
+			if !equalH4(node[0:4], removeKeyBits(k, level)) {
+				// the path ends in the leaf of another key
+				if proof.Value.Sign() != 0 {
+					return fmt.Errorf("%w: value not found in the tree", ErrInvalidProof)
+				}
+				return nil
+			}
+			valueHash, err := poseidon.Hash(toArray8(scalar2fea(proof.Value)), [4]uint64{})
+			if err != nil {
+				return err
+			}
+			if proof.Value.Sign() == 0 || !equalH4(node[4:8], valueHash[:]) {
+				return fmt.Errorf("%w: value mismatch", ErrInvalidProof)
+			}
+			return nil
+		}
+
+		bit := keyBits[level]
+		expectedHash = node[bit*4 : bit*4+4]
+	}
+
+	return fmt.Errorf("%w: max tree depth exceeded", ErrInvalidProof)
+}
+
+// hashNode computes the poseidon hash of a proof node
+func hashNode(node []uint64) ([]uint64, error) {
+	if len(node) != nodeValueElements && len(node) != nodeElements {
+		return nil, fmt.Errorf("invalid node length %d", len(node))
+	}
+	var capacity [4]uint64
+	if len(node) == nodeElements {
+		copy(capacity[:], node[nodeValueElements:])
+	}
+	hash, err := poseidon.Hash(toArray8(node), capacity)
+	if err != nil {
+		return nil, err
+	}
+	return hash[:], nil
+}
+
+// leafNode builds the node of a leaf from its remaining key and its value
+func leafNode(rKey []uint64, value []uint64) ([]uint64, error) {
+	valueHash, err := poseidon.Hash(toArray8(value), [4]uint64{})
+	if err != nil {
+		return nil, err
+	}
+	node := make([]uint64, 0, nodeElements)
+	node = append(node, rKey...)
+	node = append(node, valueHash[:]...)
+	node = append(node, 1, 0, 0, 0)
+	return node, nil
+}
+
+// isLeafNode checks if the first capacity element of a node is set, which
+// identifies the nodes of leaves
+func isLeafNode(node []uint64) bool {
+	return len(node) == nodeElements && node[nodeValueElements] == 1
+}
+
+// splitKey returns the bits of a key in the order they are used to walk the
+// tree: the first bit of each key element, then the second one and so on
+func splitKey(key []uint64) []uint64 {
+	bits := make([]uint64, 0, maxKeyLevels)
+	for i := 0; i < maxKeyLevels/4; i++ {
+		for j := 0; j < 4; j++ {
+			bits = append(bits, (key[j]>>uint(i))&1)
+		}
+	}
+	return bits
+}
+
+// removeKeyBits removes the bits of a key already used to walk the tree until
+// the provided level, returning the remaining key stored in the leaf
+func removeKeyBits(key []uint64, level int) []uint64 {
+	fullLevels := level / 4   // nolint:gomnd
+	rKey := make([]uint64, 4) // nolint:gomnd
+	for i := range rKey {
+		rKey[i] = key[i] >> uint(fullLevels)
+	}
+	for i := 0; i < level%4; i++ {
+		rKey[i] >>= 1
+	}
+	return rKey
+}
+
+func toArray8(v []uint64) [8]uint64 {
+	var a [8]uint64
+	copy(a[:], v)
+	return a
+}
+
+func isZeroH4(h []uint64) bool {
+	return len(h) == 4 && h[0] == 0 && h[1] == 0 && h[2] == 0 && h[3] == 0
+}
+
+func equalH4(a, b []uint64) bool {
+	return len(a) == 4 && len(b) == 4 && a[0] == b[0] && a[1] == b[1] && a[2] == b[2] && a[3] == b[3]
+}
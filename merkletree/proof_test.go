@@ -0,0 +1,118 @@
+package merkletree
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyProof(t *testing.T) {
+	// keys A and C share the first bit and take different branches at
+	// level 4, key B takes the other branch at level 0, key D follows the
+	// path of A and C but it's not in the tree, key E ends in an empty node
+	keyA := []uint64{0, 0, 0, 0}
+	keyC := []uint64{2, 0, 0, 0}
+	keyD := []uint64{0, 8, 0, 0}
+	valueA := big.NewInt(100)
+	valueC := big.NewInt(200)
+
+	leafA, err := leafNode(removeKeyBits(keyA, 5), scalar2fea(valueA))
+	require.NoError(t, err)
+	leafC, err := leafNode(removeKeyBits(keyC, 5), scalar2fea(valueC))
+	require.NoError(t, err)
+	leafAHash, err := hashNode(leafA)
+	require.NoError(t, err)
+	leafCHash, err := hashNode(leafC)
+	require.NoError(t, err)
+
+	// levels 1 to 3 use the first bit of the key elements 1 to 3, which are
+	// zero for keys A and C, so there is an intermediate node for each of them
+	level4 := intermediateNode(leafAHash, leafCHash)
+	nodes := [][]uint64{level4}
+	for level := 3; level >= 0; level-- {
+		childHash, err := hashNode(nodes[0])
+		require.NoError(t, err)
+		nodes = append([][]uint64{intermediateNode(childHash, []uint64{0, 0, 0, 0})}, nodes...)
+	}
+	rootHash, err := hashNode(nodes[0])
+	require.NoError(t, err)
+	root := h4ToFilledByteSlice(rootHash)
+
+	siblingsA := append(append([][]uint64{}, nodes...), leafA)
+	siblingsC := append(append([][]uint64{}, nodes...), leafC)
+
+	var tests = []struct {
+		name          string
+		root          []byte
+		key           []uint64
+		proof         *ValueProof
+		expectedError error
+	}{
+		{"inclusion of key A", root, keyA, &ValueProof{Value: valueA, Siblings: siblingsA}, nil},
+		{"inclusion of key C", root, keyC, &ValueProof{Value: valueC, Siblings: siblingsC}, nil},
+		{"exclusion of key D ending in the leaf of key A", root, keyD, &ValueProof{Value: big.NewInt(0), Siblings: siblingsA}, nil},
+		{"exclusion of key B ending in empty node", root, []uint64{1, 0, 0, 0}, &ValueProof{Value: big.NewInt(0), Siblings: nodes[:1]}, nil},
+		{"exclusion in empty tree", h4ToFilledByteSlice([]uint64{0, 0, 0, 0}), keyA, &ValueProof{Value: big.NewInt(0)}, nil},
+		{"wrong value", root, keyA, &ValueProof{Value: big.NewInt(101), Siblings: siblingsA}, ErrInvalidProof},
+		{"zero value of existing key", root, keyA, &ValueProof{Value: big.NewInt(0), Siblings: siblingsA}, ErrInvalidProof},
+		{"value of missing key", root, keyD, &ValueProof{Value: valueA, Siblings: siblingsA}, ErrInvalidProof},
+		{"wrong root", common.Hash{1}.Bytes(), keyA, &ValueProof{Value: valueA, Siblings: siblingsA}, ErrInvalidProof},
+		{"proof of another key", root, keyC, &ValueProof{Value: valueA, Siblings: siblingsA}, ErrInvalidProof},
+		{"incomplete proof", root, keyA, &ValueProof{Value: valueA, Siblings: nodes}, ErrInvalidProof},
+		{"nodes after leaf", root, keyA, &ValueProof{Value: valueA, Siblings: append(siblingsA, leafA)}, ErrInvalidProof},
+		{"missing value", root, keyA, &ValueProof{Siblings: siblingsA}, ErrInvalidProof},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyProof(tt.root, h4ToFilledByteSlice(tt.key), tt.proof)
+			if tt.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tt.expectedError), "unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	balance := big.NewInt(1000)
+
+	// a tree with the balance as the only leaf, whose node is the root
+	balanceKey, err := KeyEthAddrBalance(address)
+	require.NoError(t, err)
+	leaf, err := leafNode(scalarToh4(new(big.Int).SetBytes(balanceKey)), scalar2fea(balance))
+	require.NoError(t, err)
+	rootHash, err := hashNode(leaf)
+	require.NoError(t, err)
+	root := h4ToFilledByteSlice(rootHash)
+
+	proof := &AccountProof{
+		Address:  address,
+		Balance:  &ValueProof{Value: balance, Siblings: [][]uint64{leaf}},
+		Nonce:    &ValueProof{Value: big.NewInt(0), Siblings: [][]uint64{leaf}},
+		CodeHash: &ValueProof{Value: big.NewInt(0), Siblings: [][]uint64{leaf}},
+		Storage: []StorageProof{
+			{Position: big.NewInt(1), Proof: &ValueProof{Value: big.NewInt(0), Siblings: [][]uint64{leaf}}},
+		},
+	}
+	require.NoError(t, VerifyAccountProof(root, proof))
+
+	proof.Nonce.Value = big.NewInt(1)
+	err = VerifyAccountProof(root, proof)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidProof))
+	assert.Contains(t, err.Error(), "nonce")
+}
+
+func intermediateNode(left, right []uint64) []uint64 {
+	node := make([]uint64, 0, nodeElements)
+	node = append(node, left...)
+	node = append(node, right...)
+	return append(node, 0, 0, 0, 0)
+}
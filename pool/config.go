@@ -38,11 +38,32 @@ type Config struct {
 	// GlobalQueue represents the maximum number of non-executable transaction slots for all accounts
 	GlobalQueue uint64 `mapstructure:"GlobalQueue"`
 
+	// AccountSlots is the number of pending transactions with the lowest nonces of each account
+	// that are never evicted when the pool is full to make room for transactions with a higher gas price
+	AccountSlots uint64 `mapstructure:"AccountSlots"`
+
+	// PriceBump is the minimum percentage the gas price of a transaction must be increased to
+	// replace a pending transaction of the same sender and nonce
+	PriceBump uint64 `mapstructure:"PriceBump"`
+
 	// EffectiveGasPrice is the config for the effective gas price calculation
 	EffectiveGasPrice EffectiveGasPriceCfg `mapstructure:"EffectiveGasPrice"`
 
 	// ForkID is the current fork ID of the chain
 	ForkID uint64 `mapstructure:"ForkID"`
+
+	// ListenPendingTxNotifications makes the pool notify the RPC subscriptions of the pending
+	// txs added by any node sharing the pool DB, received with Postgres LISTEN/NOTIFY, instead
+	// of only the ones added by this node
+	ListenPendingTxNotifications bool `mapstructure:"ListenPendingTxNotifications"`
+
+	// PrivateTxMaxBlocks is the max number of L2 blocks after the last one a private transaction
+	// can wait to be included before it expires, private transactions are rejected if it is 0
+	PrivateTxMaxBlocks uint64 `mapstructure:"PrivateTxMaxBlocks"`
+
+	// MaxBundleTxs is the max number of transactions of a bundle, which are included consecutively
+	// in the same L2 block or not at all, bundles are rejected if it is 0
+	MaxBundleTxs uint64 `mapstructure:"MaxBundleTxs"`
 }
 
 // EffectiveGasPriceCfg contains the configuration properties for the effective gas price
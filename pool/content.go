@@ -0,0 +1,138 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxPoolContent contains the pending txs of the pool grouped by sender and
+// split between the executable ones (pending) and the ones waiting for a nonce
+// gap to be filled (queued)
+type TxPoolContent struct {
+	Pending map[common.Address][]Transaction
+	Queued  map[common.Address][]Transaction
+}
+
+// TxPoolStatus contains the number of executable (pending) and
+// non-executable (queued) txs in the pool
+type TxPoolStatus struct {
+	Pending uint64
+	Queued  uint64
+}
+
+// NewTxPoolContent creates an empty TxPoolContent
+func NewTxPoolContent() *TxPoolContent {
+	return &TxPoolContent{
+		Pending: make(map[common.Address][]Transaction),
+		Queued:  make(map[common.Address][]Transaction),
+	}
+}
+
+// GetContent returns the content of the pool for the pending txs of at most
+// limit senders, skipping the first offset senders sorted by address.
+// if limit = 0, then there is no limit
+func (p *Pool) GetContent(ctx context.Context, offset, limit uint64) (*TxPoolContent, error) {
+	senders, err := p.storage.GetSendersByStatus(ctx, offset, limit, TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	return p.getContentBySenders(ctx, senders)
+}
+
+// GetContentFrom returns the content of the pool for the pending txs sent by
+// the provided address
+func (p *Pool) GetContentFrom(ctx context.Context, address common.Address) (*TxPoolContent, error) {
+	return p.getContentBySenders(ctx, []common.Address{address})
+}
+
+// GetStatus returns the number of pending and queued txs in the pool
+func (p *Pool) GetStatus(ctx context.Context) (TxPoolStatus, error) {
+	pending, queued, err := p.storage.CountPendingTxsByExecutability(ctx)
+	if err != nil {
+		return TxPoolStatus{}, err
+	}
+	return TxPoolStatus{Pending: pending, Queued: queued}, nil
+}
+
+func (p *Pool) getContentBySenders(ctx context.Context, senders []common.Address) (*TxPoolContent, error) {
+	content := NewTxPoolContent()
+	if len(senders) == 0 {
+		return content, nil
+	}
+
+	txs, err := p.storage.GetTxsBySendersAndStatus(ctx, senders, TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	txsBySender := make(map[common.Address][]Transaction, len(senders))
+	for _, tx := range txs {
+		from, err := state.GetSender(tx.Transaction)
+		if err != nil {
+			log.Warnf("failed to get sender of pool tx %v: %v", tx.Hash().String(), err)
+			continue
+		}
+		txsBySender[from] = append(txsBySender[from], tx)
+	}
+
+	if len(txsBySender) == 0 {
+		return content, nil
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for from, senderTxs := range txsBySender {
+		nonce, err := p.state.GetNonce(ctx, from, lastL2Block.Root())
+		if errors.Is(err, state.ErrNotFound) {
+			nonce = 0
+		} else if err != nil {
+			return nil, err
+		}
+
+		pending, queued := splitExecutableTxs(senderTxs, nonce)
+		if len(pending) > 0 {
+			content.Pending[from] = pending
+		}
+		if len(queued) > 0 {
+			content.Queued[from] = queued
+		}
+	}
+
+	return content, nil
+}
+
+// splitExecutableTxs splits the txs of a single sender into the executable ones
+// (pending) and the ones that can't be executed until a nonce gap is filled
+// (queued), sorted by nonce. Only the provided txs close the nonce gaps, so the
+// private txs, which are not provided, are seen as gaps. Txs with a nonce lower
+// than the provided sender nonce are stale, as it has already been used, so they
+// are not included.
+func splitExecutableTxs(txs []Transaction, nonce uint64) (pending []Transaction, queued []Transaction) {
+	sorted := make([]Transaction, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Nonce() < sorted[j].Nonce()
+	})
+
+	nextNonce := nonce
+	for _, tx := range sorted {
+		if tx.Nonce() < nonce {
+			continue
+		}
+		if tx.IsExecutable && tx.Nonce() == nextNonce && len(queued) == 0 {
+			pending = append(pending, tx)
+			nextNonce++
+		} else {
+			queued = append(queued, tx)
+		}
+	}
+	return pending, queued
+}
@@ -0,0 +1,60 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitExecutableTxs(t *testing.T) {
+	newTx := func(nonce uint64, isExecutable bool) Transaction {
+		tx := types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		poolTx := NewTransaction(*tx, "", false)
+		poolTx.IsExecutable = isExecutable
+		return *poolTx
+	}
+
+	var tests = []struct {
+		name            string
+		executable      []uint64
+		queued          []uint64
+		currentNonce    uint64
+		expectedPending []uint64
+		expectedQueued  []uint64
+	}{
+		{"No txs", nil, nil, 0, nil, nil},
+		{"All executable", []uint64{2, 0, 1}, nil, 0, []uint64{0, 1, 2}, nil},
+		{"Gap after executable txs", []uint64{5, 6}, []uint64{9, 8}, 5, []uint64{5, 6}, []uint64{8, 9}},
+		{"Gap before any tx", nil, []uint64{3, 4}, 1, nil, []uint64{3, 4}},
+		{"Executable behind a private tx", []uint64{2, 3}, nil, 1, nil, []uint64{2, 3}},
+		{"Executable with a private tx in between", []uint64{1, 3}, nil, 1, []uint64{1}, []uint64{3}},
+		{"Stale nonces", []uint64{0, 1, 2}, []uint64{0}, 1, []uint64{1, 2}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := make([]Transaction, 0, len(tt.executable)+len(tt.queued))
+			for _, nonce := range tt.executable {
+				txs = append(txs, newTx(nonce, true))
+			}
+			for _, nonce := range tt.queued {
+				txs = append(txs, newTx(nonce, false))
+			}
+
+			pending, queued := splitExecutableTxs(txs, tt.currentNonce)
+
+			var pendingNonces, queuedNonces []uint64
+			for _, tx := range pending {
+				pendingNonces = append(pendingNonces, tx.Nonce())
+			}
+			for _, tx := range queued {
+				queuedNonces = append(queuedNonces, tx.Nonce())
+			}
+			assert.Equal(t, tt.expectedPending, pendingNonces)
+			assert.Equal(t, tt.expectedQueued, queuedNonces)
+		})
+	}
+}
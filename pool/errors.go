@@ -68,6 +68,31 @@ var (
 	// ErrReceivedZeroL1GasPrice is returned if the L1 gas price is 0.
 	ErrReceivedZeroL1GasPrice = errors.New("received L1 gas price 0")
 
+	// ErrPrivateTxsDisabled is returned when a private transaction is sent and
+	// PrivateTxMaxBlocks is 0.
+	ErrPrivateTxsDisabled = errors.New("private transactions are disabled")
+
+	// ErrMaxBlockNumberReached is returned if the max L2 block number of a private
+	// transaction is not after the last L2 block.
+	ErrMaxBlockNumberReached = errors.New("max block number already reached")
+
+	// ErrBundlesDisabled is returned when a bundle is sent and MaxBundleTxs is 0.
+	ErrBundlesDisabled = errors.New("bundles are disabled")
+
+	// ErrEmptyBundle is returned if a bundle doesn't have any transaction.
+	ErrEmptyBundle = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle has more transactions than MaxBundleTxs.
+	ErrBundleTooLarge = errors.New("bundle has too many transactions")
+
+	// ErrBundleNonceNotPending is returned if the first transaction of a sender in a
+	// bundle doesn't have the pending nonce of the sender.
+	ErrBundleNonceNotPending = errors.New("bundle nonce is not the pending nonce of the sender")
+
+	// ErrBundleNonceCollision is returned if a transaction has the same sender and
+	// nonce as a pending bundle transaction, which can't be replaced.
+	ErrBundleNonceCollision = errors.New("nonce already used by a pending bundle transaction")
+
 	// ErrInvalidIP is returned if the IP address is invalid.
 	ErrInvalidIP = errors.New("invalid IP address")
 
@@ -85,4 +110,10 @@ var (
 
 	// ErrSenderDisallowedDeploy is returned when deploy transactions are disallowed by policy
 	ErrSenderDisallowedDeploy = errors.New("sender disallowed deploy by policy")
+
+	// ErrContractDisallowedCall is returned when calls to the contract, or to the called function, are disallowed by policy
+	ErrContractDisallowedCall = errors.New("contract disallowed call by policy")
+
+	// ErrSenderValueExceedsLimit is returned when the value of a transaction exceeds the limit of the sender by policy
+	ErrSenderValueExceedsLimit = errors.New("sender value exceeds limit by policy")
 )
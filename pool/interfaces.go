@@ -13,15 +13,26 @@ import (
 
 type storage interface {
 	AddTx(ctx context.Context, tx Transaction) error
+	AddOrReplaceTx(ctx context.Context, tx Transaction, stateNonce uint64, maxReplacedGasPrice *big.Int, replacedReason string, eviction TxEviction) ([]common.Hash, []common.Hash, error)
+	CountEvictableTxs(ctx context.Context, accountSlots uint64, gasPrice *big.Int) (uint64, error)
+	ExpirePrivateTxs(ctx context.Context, l2BlockNumber uint64, expiredReason string) ([]common.Hash, error)
+	AddBundle(ctx context.Context, txs []Transaction, stateNonces map[common.Address]uint64, eviction TxEviction) ([]common.Hash, error)
+	GetNonWIPBundles(ctx context.Context) ([]Bundle, error)
 	CountTransactionsByStatus(ctx context.Context, status ...TxStatus) (uint64, error)
 	CountTransactionsByFromAndStatus(ctx context.Context, from common.Address, status ...TxStatus) (uint64, error)
+	CountPendingTxsByExecutability(ctx context.Context) (uint64, uint64, error)
+	GetSendersByStatus(ctx context.Context, offset, limit uint64, status ...TxStatus) ([]common.Address, error)
+	GetTxsBySendersAndStatus(ctx context.Context, senders []common.Address, status ...TxStatus) ([]Transaction, error)
 	DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error
 	GetGasPrices(ctx context.Context) (uint64, uint64, error)
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
+	GetNonceWithPrivateTxs(ctx context.Context, address common.Address) (uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
 	GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error)
 	GetTxsByStatus(ctx context.Context, state TxStatus, limit uint64) ([]Transaction, error)
-	GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error)
+	GetNonWIPExecutableTxs(ctx context.Context) ([]Transaction, error)
+	ListenNewExecutableTxs(ctx context.Context, onNewExecutableTxs func()) error
+	DeleteIdleSenderNonces(ctx context.Context) error
 	IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
 	SetGasPrices(ctx context.Context, l2GasPrice uint64, l1GasPrice uint64) error
 	DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Time) error
@@ -29,6 +40,7 @@ type storage interface {
 	UpdateTxsStatus(ctx context.Context, updateInfo []TxStatusUpdateInfo) error
 	UpdateTxStatus(ctx context.Context, updateInfo TxStatusUpdateInfo) error
 	UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error
+	MarkTxInWIPL2Block(ctx context.Context, hash common.Hash) error
 	GetTxs(ctx context.Context, filterStatus TxStatus, minGasPrice, limit uint64) ([]*Transaction, error)
 	GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error)
 	GetTransactionByHash(ctx context.Context, hash common.Hash) (*Transaction, error)
@@ -40,6 +52,7 @@ type storage interface {
 	MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error)
 	policy
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	ListenNewPendingTxs(ctx context.Context, onNewPendingTx func(hash common.Hash)) error
 }
 
 type stateInterface interface {
@@ -51,10 +64,14 @@ type stateInterface interface {
 }
 type policy interface {
 	CheckPolicy(ctx context.Context, policy PolicyName, address common.Address) (bool, error)
+	CheckCallPolicy(ctx context.Context, contract common.Address, selector []byte) (bool, error)
+	CheckValuePolicy(ctx context.Context, address common.Address, value *big.Int) (bool, error)
 	AddAddressesToPolicy(ctx context.Context, policy PolicyName, addresses []common.Address) error
+	AddAcls(ctx context.Context, acls []Acl) error
 	RemoveAddressesFromPolicy(ctx context.Context, policy PolicyName, addresses []common.Address) error
+	RemoveAcls(ctx context.Context, acls []Acl) error
 	ClearPolicy(ctx context.Context, policy PolicyName) error
 	DescribePolicies(ctx context.Context) ([]Policy, error)
 	DescribePolicy(ctx context.Context, name PolicyName) (Policy, error)
-	ListAcl(ctx context.Context, policy PolicyName, query []common.Address) ([]common.Address, error)
+	ListAcl(ctx context.Context, policy PolicyName, query []common.Address) ([]Acl, error)
 }
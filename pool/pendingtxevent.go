@@ -0,0 +1,97 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const newPendingTxsListenRetryInterval = time.Second
+
+// NewPendingTxEventHandler represent a func that will be called by the
+// pool when a NewPendingTxEvent is triggered
+type NewPendingTxEventHandler func(e NewPendingTxEvent)
+
+// NewPendingTxEvent is a struct provided from the pool to the
+// NewPendingTxEventHandler when a pending tx is added to the pool
+type NewPendingTxEvent struct {
+	Tx Transaction
+}
+
+// RegisterNewPendingTxEventHandler add the provided handler to the list of handlers
+// that will be triggered when a new pending tx event is triggered
+func (p *Pool) RegisterNewPendingTxEventHandler(h NewPendingTxEventHandler) {
+	log.Info("new pending tx event handler registered")
+	p.newPendingTxEventHandlersMux.Lock()
+	defer p.newPendingTxEventHandlersMux.Unlock()
+	p.newPendingTxEventHandlers = append(p.newPendingTxEventHandlers, h)
+}
+
+// StartToMonitorNewPendingTxs starts a go routine that will listen the
+// notifications sent by the pool DB for the pending txs added by any node,
+// executing the handlers registered for each one. It does nothing unless
+// ListenPendingTxNotifications is enabled, as otherwise the pool only
+// notifies the txs added by this instance when they are stored.
+// This is used by the RPC WebSocket subscriptions.
+func (p *Pool) StartToMonitorNewPendingTxs() {
+	if !p.cfg.ListenPendingTxNotifications {
+		return
+	}
+	go p.monitorNewPendingTxs(context.Background())
+}
+
+func (p *Pool) monitorNewPendingTxs(ctx context.Context) {
+	for {
+		err := p.storage.ListenNewPendingTxs(ctx, func(hash common.Hash) {
+			p.onNewPendingTxNotification(ctx, hash)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf("failed to listen new pending txs, retrying in %v: %v", newPendingTxsListenRetryInterval, err)
+		time.Sleep(newPendingTxsListenRetryInterval)
+	}
+}
+
+// onNewPendingTxNotification loads the pending tx notified by the pool DB to
+// trigger its event
+func (p *Pool) onNewPendingTxNotification(ctx context.Context, hash common.Hash) {
+	if !p.hasNewPendingTxEventHandlers() {
+		return
+	}
+
+	tx, err := p.storage.GetTransactionByHash(ctx, hash)
+	if errors.Is(err, ErrNotFound) {
+		return
+	} else if err != nil {
+		log.Errorf("failed to load new pending tx %v: %v", hash.String(), err)
+		return
+	}
+	// the tx could be already selected or discarded when the notification arrives
+	if tx.Status != TxStatusPending {
+		return
+	}
+	p.notifyNewPendingTx(*tx)
+}
+
+// notifyNewPendingTx triggers the new pending tx event for the provided tx
+func (p *Pool) notifyNewPendingTx(tx Transaction) {
+	p.newPendingTxEventHandlersMux.RLock()
+	defer p.newPendingTxEventHandlersMux.RUnlock()
+
+	log.Debugf("[notifyNewPendingTx] sending NewPendingTxEvent for tx %v", tx.Hash().String())
+	event := NewPendingTxEvent{Tx: tx}
+	for _, handler := range p.newPendingTxEventHandlers {
+		state.SafeRun(func() { handler(event) }, "failed and recovered in NewPendingTxEventHandler: %v")
+	}
+}
+
+func (p *Pool) hasNewPendingTxEventHandlers() bool {
+	p.newPendingTxEventHandlersMux.RLock()
+	defer p.newPendingTxEventHandlersMux.RUnlock()
+	return len(p.newPendingTxEventHandlers) > 0
+}
@@ -4,18 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math/big"
+	"sort"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// newPendingTxChannel is the channel used to notify the hashes of the new
+// pending transactions to the listeners of all the nodes sharing the pool
+const newPendingTxChannel = "pool_new_pending_tx"
+
+// newExecutableTxsChannel is the channel used to notify the senders with new
+// executable transactions to the sequencer
+const newExecutableTxsChannel = "pool_new_executable_txs"
+
 // PostgresPoolStorage is an implementation of the Pool interface
 // that uses a postgres database to store the data
 type PostgresPoolStorage struct {
@@ -34,8 +46,443 @@ func NewPostgresPoolStorage(cfg db.Config) (*PostgresPoolStorage, error) {
 	}, nil
 }
 
-// AddTx adds a transaction to the pool table with the provided status
+// execQuerier is implemented by the DB pool and the DB transactions
+type execQuerier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (commandTag pgconn.CommandTag, err error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// notify sends a notification to the listeners of the provided channel. Inside
+// a DB tx it runs in a savepoint, so a failed notification doesn't abort the DB
+// tx, and it is only sent when the DB tx is committed
+func notify(ctx context.Context, e execQuerier, channel string, payload string) error {
+	dbTx, ok := e.(pgx.Tx)
+	if !ok {
+		_, err := e.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload)
+		return err
+	}
+
+	savepoint, err := dbTx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := savepoint.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload); err != nil {
+		_ = savepoint.Rollback(ctx)
+		return err
+	}
+	return savepoint.Commit(ctx)
+}
+
+// AddTx adds a transaction to the pool table with the provided status, the
+// transaction is added as executable
 func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) error {
+	return p.addTx(ctx, p.db, tx, true)
+}
+
+// AddOrReplaceTx atomically adds a transaction to the pool table, replacing the
+// pending transactions of its sender with the same nonce, which are set as failed
+// with the provided reason and returned. The bundle transactions are never replaced, so
+// pool.ErrBundleNonceCollision is returned if any of them has the same nonce. If any of them
+// is in the WIP L2 block of the sequencer, pool.ErrReplaceWIP is returned, and if any of them has a gas price higher
+// than maxReplacedGasPrice, pool.ErrReplaceUnderpriced is returned, in all cases
+// nothing is changed. The transaction is added as queued and promoted to executable
+// along with the queued transactions of its sender if it closes their nonce gap.
+// Then the queued transactions cheaper than it are evicted and returned if the pool
+// is over the global queue of the eviction, returning pool.ErrTxPoolOverflow without
+// adding it if not enough of them can be evicted.
+func (p *PostgresPoolStorage) AddOrReplaceTx(ctx context.Context, tx pool.Transaction, stateNonce uint64, maxReplacedGasPrice *big.Int, replacedReason string, eviction pool.TxEviction) (replacedTxs []common.Hash, evictedTxs []common.Hash, err error) {
+	from, err := state.GetSender(tx.Transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+	fromAddress := from.String()
+	hash := tx.Hash().Hex()
+	nonce := tx.Nonce()
+
+	dbTx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// rolling back a committed DB tx does nothing
+	defer func() { _ = dbTx.Rollback(ctx) }()
+
+	// serialize the txs added for the same sender, as they can promote each other
+	const lockSQL = "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))"
+	if _, err := dbTx.Exec(ctx, lockSQL, fromAddress); err != nil {
+		return nil, nil, err
+	}
+
+	// the replaceable txs are locked until the DB tx ends, so the sequencer can't set
+	// them as WIP nor in the WIP L2 block while they are being replaced. The WIP txs
+	// loaded in the worker are replaced there when the sequencer loads the new tx
+	const replaceableSQL = `SELECT COALESCE(BOOL_OR(bundle_hash IS NOT NULL), FALSE), COALESCE(BOOL_OR(is_in_wip_l2_block), FALSE), COALESCE(BOOL_OR(gas_price > $5), FALSE) FROM (
+		SELECT bundle_hash, is_in_wip_l2_block, gas_price FROM pool.transaction
+		WHERE from_address = $1 AND nonce = $2 AND status = $3 AND hash != $4 FOR UPDATE) AS replaceable`
+	var bundle, inWIPL2Block, underpriced bool
+	if err := dbTx.QueryRow(ctx, replaceableSQL, fromAddress, nonce, pool.TxStatusPending, hash, maxReplacedGasPrice.Uint64()).Scan(&bundle, &inWIPL2Block, &underpriced); err != nil {
+		return nil, nil, err
+	}
+	if bundle {
+		return nil, nil, pool.ErrBundleNonceCollision
+	}
+	if inWIPL2Block {
+		return nil, nil, pool.ErrReplaceWIP
+	}
+	if underpriced {
+		return nil, nil, pool.ErrReplaceUnderpriced
+	}
+
+	const replaceSQL = `UPDATE pool.transaction SET status = $1, failed_reason = $2
+		WHERE from_address = $3 AND nonce = $4 AND status = $5 AND hash != $6 AND bundle_hash IS NULL AND is_in_wip_l2_block IS FALSE RETURNING hash`
+	rows, err := dbTx.Query(ctx, replaceSQL, pool.TxStatusFailed, replacedReason, fromAddress, nonce, pool.TxStatusPending, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	replacedTxs = []common.Hash{}
+	for rows.Next() {
+		var replacedHash string
+		if err := rows.Scan(&replacedHash); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		replacedTxs = append(replacedTxs, common.HexToHash(replacedHash))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := p.addTx(ctx, dbTx, tx, false); err != nil {
+		return nil, nil, err
+	}
+
+	if err := p.promoteTxs(ctx, dbTx, fromAddress, stateNonce); err != nil {
+		return nil, nil, err
+	}
+
+	evictedTxs, err = p.evictTxs(ctx, dbTx, tx.GasPrice(), eviction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return replacedTxs, evictedTxs, nil
+}
+
+// promoteTxs sets as executable the pending txs of the sender from its next nonce
+// up to the first nonce gap, moving its next nonce to the gap. The next nonce is
+// never behind the state nonce, so the txs are only promoted once the ones with the
+// previous nonces are executable or already processed. The sequencer is notified
+// when the DB tx is committed if any tx is promoted.
+func (p *PostgresPoolStorage) promoteTxs(ctx context.Context, e execQuerier, fromAddress string, stateNonce uint64) error {
+	nextNonce := stateNonce
+	const nextNonceSQL = "SELECT next_nonce FROM pool.sender_nonce WHERE from_address = $1"
+	var storedNextNonce uint64
+	err := e.QueryRow(ctx, nextNonceSQL, fromAddress).Scan(&storedNextNonce)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	} else if err == nil && storedNextNonce > nextNonce {
+		nextNonce = storedNextNonce
+	}
+
+	// move the next nonce forward while the sender has pending txs with consecutive nonces
+	const noncesSQL = "SELECT DISTINCT nonce FROM pool.transaction WHERE from_address = $1 AND status = $2 AND nonce >= $3 ORDER BY nonce"
+	rows, err := e.Query(ctx, noncesSQL, fromAddress, pool.TxStatusPending, nextNonce)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var nonce uint64
+		if err := rows.Scan(&nonce); err != nil {
+			rows.Close()
+			return err
+		}
+		if nonce != nextNonce {
+			break
+		}
+		nextNonce++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	const promoteSQL = `UPDATE pool.transaction SET is_executable = TRUE
+		WHERE from_address = $1 AND status = $2 AND nonce >= $3 AND nonce < $4 AND is_executable IS FALSE`
+	commandTag, err := e.Exec(ctx, promoteSQL, fromAddress, pool.TxStatusPending, stateNonce, nextNonce)
+	if err != nil {
+		return err
+	}
+
+	const updateNextNonceSQL = `INSERT INTO pool.sender_nonce (from_address, next_nonce) VALUES ($1, $2)
+		ON CONFLICT (from_address) DO UPDATE SET next_nonce = $2`
+	if _, err := e.Exec(ctx, updateNextNonceSQL, fromAddress, nextNonce); err != nil {
+		return err
+	}
+
+	// the sequencer reloads the pool periodically, so a failed notification is only logged
+	if commandTag.RowsAffected() > 0 {
+		if err := notify(ctx, e, newExecutableTxsChannel, fromAddress); err != nil {
+			log.Errorf("failed to notify new executable txs of sender %s: %v", fromAddress, err)
+		}
+	}
+	return nil
+}
+
+// DeleteIdleSenderNonces deletes the next nonces of the senders without pending
+// txs, which start again from their state nonce when they send a new tx
+func (p *PostgresPoolStorage) DeleteIdleSenderNonces(ctx context.Context) error {
+	const sql = `DELETE FROM pool.sender_nonce s WHERE NOT EXISTS (
+		SELECT 1 FROM pool.transaction t WHERE t.from_address = s.from_address AND t.status = $1)`
+	if _, err := p.db.Exec(ctx, sql, pool.TxStatusPending); err != nil {
+		return err
+	}
+	return nil
+}
+
+// evictableTxsSQL selects the hashes of the queued txs that can be evicted to make
+// room for a tx with a higher gas price, the cheapest ones first. The executable txs
+// are never evicted. The txs of each sender are evicted from the highest nonce down,
+// so no nonce gap is left behind, keeping the ones with the lowest nonces in the
+// account slots.
+// WIP txs are never evicted as they are already being processed by the sequencer,
+// nor the bundle txs, as the rest of the txs of their bundle would fail with them.
+// A tx is only evictable with the txs of the same sender with higher nonces, so it
+// is ranked by the highest gas price among them.
+const evictableTxsSQL = `
+	WITH sender_txs AS (
+		SELECT hash, is_executable,
+			ROW_NUMBER() OVER (PARTITION BY from_address ORDER BY nonce DESC) AS tail_position,
+			COUNT(*) OVER (PARTITION BY from_address) AS sender_tx_count,
+			MAX(gas_price) OVER (PARTITION BY from_address ORDER BY nonce DESC
+				ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS eviction_gas_price
+		FROM pool.transaction
+		WHERE status = $1 AND is_wip IS FALSE AND bundle_hash IS NULL
+	)
+	SELECT hash FROM sender_txs
+	WHERE is_executable IS FALSE AND tail_position + $2 <= sender_tx_count AND eviction_gas_price < $3
+	ORDER BY eviction_gas_price, tail_position`
+
+// CountEvictableTxs counts the pending txs that can be evicted to make room for
+// a tx with the provided gas price, keeping accountSlots txs of each sender
+func (p *PostgresPoolStorage) CountEvictableTxs(ctx context.Context, accountSlots uint64, gasPrice *big.Int) (uint64, error) {
+	sql := "SELECT COUNT(*) FROM (" + evictableTxsSQL + ") AS evictable_txs"
+	var counter uint64
+	err := p.db.QueryRow(ctx, sql, pool.TxStatusPending, accountSlots, gasPrice.Uint64()).Scan(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// evictTxs evicts the cheapest queued txs until the number of pending txs is back
+// to the global queue of the eviction, keeping its account slots txs of each sender.
+// Only txs with a gas price lower than the provided one are evicted, which are set
+// as failed with the reason of the eviction and returned. If not enough of them can
+// be evicted, pool.ErrTxPoolOverflow is returned. There is no limit if the global
+// queue is 0.
+func (p *PostgresPoolStorage) evictTxs(ctx context.Context, e execQuerier, gasPrice *big.Int, eviction pool.TxEviction) ([]common.Hash, error) {
+	if eviction.GlobalQueue == 0 {
+		return []common.Hash{}, nil
+	}
+
+	// serialize the evictions until the DB tx ends, so the pending txs counted by
+	// each one include the ones added by the previous ones
+	const lockSQL = "SELECT pg_advisory_xact_lock(hashtextextended('pool_eviction', 0))"
+	if _, err := e.Exec(ctx, lockSQL); err != nil {
+		return nil, err
+	}
+
+	const countSQL = "SELECT COUNT(*) FROM pool.transaction WHERE status = $1"
+	var txCount uint64
+	if err := e.QueryRow(ctx, countSQL, pool.TxStatusPending).Scan(&txCount); err != nil {
+		return nil, err
+	}
+	if txCount <= eviction.GlobalQueue {
+		return []common.Hash{}, nil
+	}
+
+	// the txs locked by other DB txs, e.g. being promoted, are skipped instead of
+	// waiting for them, as they hold the locks of their senders
+	evictSQL := `UPDATE pool.transaction SET status = $4, failed_reason = $5
+		WHERE hash IN (SELECT hash FROM pool.transaction WHERE hash IN (` + evictableTxsSQL + ` LIMIT $6) FOR UPDATE SKIP LOCKED) RETURNING hash`
+	rows, err := e.Query(ctx, evictSQL, pool.TxStatusPending, eviction.AccountSlots, gasPrice.Uint64(), pool.TxStatusFailed, eviction.Reason, txCount-eviction.GlobalQueue)
+	if err != nil {
+		return nil, err
+	}
+	evictedTxs := []common.Hash{}
+	for rows.Next() {
+		var evictedHash string
+		if err := rows.Scan(&evictedHash); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		evictedTxs = append(evictedTxs, common.HexToHash(evictedHash))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if uint64(len(evictedTxs)) < txCount-eviction.GlobalQueue {
+		return nil, pool.ErrTxPoolOverflow
+	}
+	return evictedTxs, nil
+}
+
+// ExpirePrivateTxs sets as failed with the provided reason the pending private txs
+// not being processed by the sequencer whose max L2 block number is not after the
+// provided L2 block number, returning their hashes
+func (p *PostgresPoolStorage) ExpirePrivateTxs(ctx context.Context, l2BlockNumber uint64, expiredReason string) ([]common.Hash, error) {
+	const sql = `UPDATE pool.transaction SET status = $1, failed_reason = $2
+		WHERE is_private IS TRUE AND max_l2_block_number <= $3 AND status = $4 AND is_wip IS FALSE RETURNING hash`
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusFailed, expiredReason, l2BlockNumber, pool.TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expiredTxs := []common.Hash{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		expiredTxs = append(expiredTxs, common.HexToHash(hash))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return expiredTxs, nil
+}
+
+// AddBundle atomically adds the txs of a bundle to the pool table as queued and
+// promotes the pending txs of their senders, whose state nonces are provided, so
+// the txs sent after the bundle ones can become executable. Then the queued txs
+// cheaper than the cheapest bundle tx are evicted and returned if the pool is over
+// the global queue of the eviction, returning pool.ErrTxPoolOverflow without adding
+// the bundle if not enough of them can be evicted. The bundle is not added either if
+// any of its txs has the nonce of a pending tx, returning pool.ErrBundleNonceNotPending.
+// The sequencer is notified of the new bundle when the DB tx is committed.
+func (p *PostgresPoolStorage) AddBundle(ctx context.Context, txs []pool.Transaction, stateNonces map[common.Address]uint64, eviction pool.TxEviction) ([]common.Hash, error) {
+	senders := make([]string, 0, len(stateNonces))
+	for from := range stateNonces {
+		senders = append(senders, from.String())
+	}
+	// the senders are locked always in the same order, so concurrent bundles don't deadlock
+	sort.Strings(senders)
+
+	dbTx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// rolling back a committed DB tx does nothing
+	defer func() { _ = dbTx.Rollback(ctx) }()
+
+	// serialize the txs added for the same sender, as they can promote each other
+	const lockSQL = "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))"
+	for _, fromAddress := range senders {
+		if _, err := dbTx.Exec(ctx, lockSQL, fromAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	// the bundle txs are never replaced, so they can't share the nonce of a pending tx,
+	// which may have been added since the bundle nonces were checked
+	const collisionSQL = "SELECT EXISTS (SELECT 1 FROM pool.transaction WHERE from_address = $1 AND nonce = $2 AND status = $3)"
+	for _, tx := range txs {
+		from, err := state.GetSender(tx.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		var collision bool
+		if err := dbTx.QueryRow(ctx, collisionSQL, from.String(), tx.Nonce(), pool.TxStatusPending).Scan(&collision); err != nil {
+			return nil, err
+		}
+		if collision {
+			return nil, pool.ErrBundleNonceNotPending
+		}
+	}
+
+	for _, tx := range txs {
+		if err := p.addTx(ctx, dbTx, tx, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for from, stateNonce := range stateNonces {
+		if err := p.promoteTxs(ctx, dbTx, from.String(), stateNonce); err != nil {
+			return nil, err
+		}
+	}
+
+	minGasPrice := txs[0].GasPrice()
+	for _, tx := range txs[1:] {
+		if gasPrice := tx.GasPrice(); gasPrice.Cmp(minGasPrice) < 0 {
+			minGasPrice = gasPrice
+		}
+	}
+	evictedTxs, err := p.evictTxs(ctx, dbTx, minGasPrice, eviction)
+	if err != nil {
+		return nil, err
+	}
+
+	// the sequencer reloads the pool periodically, so a failed notification is only logged
+	if err := notify(ctx, dbTx, newExecutableTxsChannel, txs[0].BundleHash.String()); err != nil {
+		log.Errorf("failed to notify new bundle %s: %v", txs[0].BundleHash.String(), err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return evictedTxs, nil
+}
+
+// GetNonWIPBundles returns the bundles whose txs are all pending and not being
+// processed by the sequencer yet, in the order they were received, with their
+// txs in the bundle order
+func (p *PostgresPoolStorage) GetNonWIPBundles(ctx context.Context) ([]pool.Bundle, error) {
+	const sql = `
+		WITH bundles AS (
+			SELECT bundle_hash FROM pool.transaction
+			WHERE bundle_hash IN (SELECT bundle_hash FROM pool.transaction WHERE bundle_hash IS NOT NULL AND status = $1 AND is_wip IS FALSE)
+			GROUP BY bundle_hash
+			HAVING bool_and(status = $1 AND is_wip IS FALSE)
+		)
+		SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+			used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_private, max_l2_block_number, bundle_hash, bundle_index, is_executable
+		FROM pool.transaction WHERE bundle_hash IN (SELECT bundle_hash FROM bundles)
+		ORDER BY received_at, bundle_hash, bundle_index`
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bundles := []pool.Bundle{}
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		// the txs of a bundle are received at the same time, so they are consecutive
+		if len(bundles) == 0 || bundles[len(bundles)-1].Hash != *tx.BundleHash {
+			bundles = append(bundles, pool.Bundle{Hash: *tx.BundleHash, ReceivedAt: tx.ReceivedAt})
+		}
+		bundles[len(bundles)-1].Txs = append(bundles[len(bundles)-1].Txs, *tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// addTx adds a transaction to the pool table using the provided execQuerier,
+// the notification of a public pending tx is sent when the DB tx is committed
+func (p *PostgresPoolStorage) addTx(ctx context.Context, e execQuerier, tx pool.Transaction, isExecutable bool) error {
 	hash := tx.Hash().Hex()
 
 	b, err := tx.MarshalBinary()
@@ -76,10 +523,15 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip,
 			ip,
 			failed_reason,
-			reserved_zkcounters
+			reserved_zkcounters,
+			is_executable,
+			is_private,
+			max_l2_block_number,
+			bundle_hash,
+			bundle_index
 		) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20, $21, $22, $23, $24, $25)
 			ON CONFLICT (hash) DO UPDATE SET 
 			encoded = $2,
 			decoded = $3,
@@ -100,7 +552,12 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip = $18,
 			ip = $19,
 			failed_reason = NULL,
-			reserved_zkcounters = $20
+			reserved_zkcounters = $20,
+			is_executable = $21,
+			is_private = $22,
+			max_l2_block_number = $23,
+			bundle_hash = $24,
+			bundle_index = $25
 	`
 
 	// Get FromAddress from the JSON data
@@ -110,7 +567,14 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 	}
 	fromAddress := data.String()
 
-	if _, err := p.db.Exec(ctx, sql,
+	var bundleHash *string
+	var bundleIndex *uint64
+	if tx.BundleHash != nil {
+		hash, index := tx.BundleHash.String(), tx.BundleIndex
+		bundleHash, bundleIndex = &hash, &index
+	}
+
+	if _, err := e.Exec(ctx, sql,
 		hash,
 		encoded,
 		decoded,
@@ -130,12 +594,78 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 		fromAddress,
 		tx.IsWIP,
 		tx.IP,
-		tx.ReservedZKCounters); err != nil {
+		tx.ReservedZKCounters,
+		isExecutable,
+		tx.IsPrivate,
+		tx.MaxL2BlockNumber,
+		bundleHash,
+		bundleIndex); err != nil {
 		return err
 	}
+
+	// the notification runs in a savepoint, so the tx is stored even if it fails,
+	// which is not reported to the sender
+	if tx.Status == pool.TxStatusPending && !tx.IsPrivate {
+		if err := notify(ctx, e, newPendingTxChannel, hash); err != nil {
+			log.Errorf("failed to notify new pending tx %v: %v", hash, err)
+		}
+	}
 	return nil
 }
 
+// ListenNewPendingTxs listens the notifications sent by AddTx for the new
+// pending transactions, calling the provided func with the hash of each one
+// until the context is done or the connection fails
+func (p *PostgresPoolStorage) ListenNewPendingTxs(ctx context.Context, onNewPendingTx func(hash common.Hash)) error {
+	poolConn, err := p.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection is taken out of the pool so it is closed instead of
+	// being reused by other queries while it is still listening
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background()) //nolint:errcheck
+
+	if _, err := conn.Exec(ctx, "LISTEN "+newPendingTxChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onNewPendingTx(common.HexToHash(notification.Payload))
+	}
+}
+
+// ListenNewExecutableTxs listens the notifications sent when pending txs are
+// promoted to executable, calling the provided func once it starts listening
+// and then for each notification until the context is done or the connection fails
+func (p *PostgresPoolStorage) ListenNewExecutableTxs(ctx context.Context, onNewExecutableTxs func()) error {
+	poolConn, err := p.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection is taken out of the pool so it is closed instead of
+	// being reused by other queries while it is still listening
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background()) //nolint:errcheck
+
+	if _, err := conn.Exec(ctx, "LISTEN "+newExecutableTxsChannel); err != nil {
+		return err
+	}
+
+	// the txs promoted before listening are not notified
+	onNewExecutableTxs()
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		onNewExecutableTxs()
+	}
+}
+
 // GetTxsByStatus returns an array of transactions filtered by status
 // limit parameter is used to limit amount txs from the db,
 // if limit = 0, then there is no limit
@@ -147,11 +677,11 @@ func (p *PostgresPoolStorage) GetTxsByStatus(ctx context.Context, status pool.Tx
 	)
 	if limit == 0 {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC`
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_private, max_l2_block_number, bundle_hash, bundle_index, is_executable FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC`
 		rows, err = p.db.Query(ctx, sql, status.String())
 	} else {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC LIMIT $2`
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_private, max_l2_block_number, bundle_hash, bundle_index, is_executable FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC LIMIT $2`
 		rows, err = p.db.Query(ctx, sql, status.String(), limit)
 	}
 	if err != nil {
@@ -171,8 +701,9 @@ func (p *PostgresPoolStorage) GetTxsByStatus(ctx context.Context, status pool.Tx
 	return txs, nil
 }
 
-// GetNonWIPPendingTxs returns an array of transactions
-func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error) {
+// GetNonWIPExecutableTxs returns the pending txs that are executable and
+// not being processed by the sequencer yet, the bundle txs are not included
+func (p *PostgresPoolStorage) GetNonWIPExecutableTxs(ctx context.Context) ([]pool.Transaction, error) {
 	var (
 		rows pgx.Rows
 		err  error
@@ -180,7 +711,7 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.T
 	)
 
 	sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1`
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_private, max_l2_block_number, bundle_hash, bundle_index, is_executable FROM pool.transaction WHERE is_wip IS FALSE AND is_executable IS TRUE AND status = $1 AND bundle_hash IS NULL`
 	rows, err = p.db.Query(ctx, sql, pool.TxStatusPending)
 
 	if err != nil {
@@ -200,9 +731,9 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.T
 	return txs, nil
 }
 
-// GetPendingTxHashesSince returns the pending tx since the given time.
+// GetPendingTxHashesSince returns the public pending tx since the given time.
 func (p *PostgresPoolStorage) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
-	sql := "SELECT hash FROM pool.transaction WHERE status = $1 AND received_at >= $2"
+	sql := "SELECT hash FROM pool.transaction WHERE status = $1 AND received_at >= $2 AND is_private IS FALSE"
 	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending, since)
 	if err != nil {
 		return nil, err
@@ -383,6 +914,117 @@ func (p *PostgresPoolStorage) CountTransactionsByFromAndStatus(ctx context.Conte
 	return counter, nil
 }
 
+// CountPendingTxsByExecutability returns the number of public pending txs that
+// are executable and the number of the ones waiting for a nonce gap to be filled.
+// The private txs are seen as gaps, so the public txs after them are not executable.
+func (p *PostgresPoolStorage) CountPendingTxsByExecutability(ctx context.Context) (uint64, uint64, error) {
+	const sql = `SELECT t.is_executable AND NOT EXISTS (
+	                        SELECT 1 FROM pool.transaction p
+	                         WHERE p.from_address = t.from_address AND p.status = $1 AND p.is_private IS TRUE AND p.nonce < t.nonce),
+	                    COUNT(*)
+	               FROM pool.transaction t
+	              WHERE t.status = $1
+	                AND t.is_private IS FALSE
+	              GROUP BY 1`
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var executable, queued uint64
+	for rows.Next() {
+		var (
+			isExecutable bool
+			counter      uint64
+		)
+		if err := rows.Scan(&isExecutable, &counter); err != nil {
+			return 0, 0, err
+		}
+		if isExecutable {
+			executable = counter
+		} else {
+			queued = counter
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+	return executable, queued, nil
+}
+
+// GetSendersByStatus returns the distinct sender addresses of the public txs in
+// the provided statuses, sorted by address, skipping the first offset addresses.
+// if limit = 0, then there is no limit
+func (p *PostgresPoolStorage) GetSendersByStatus(ctx context.Context, offset, limit uint64, status ...pool.TxStatus) ([]common.Address, error) {
+	sql := `SELECT DISTINCT from_address
+	          FROM pool.transaction
+	         WHERE status = ANY ($1)
+	           AND is_private IS FALSE
+	         ORDER BY from_address ASC
+	        OFFSET $2`
+	args := []interface{}{status, offset}
+	if limit > 0 {
+		sql += " LIMIT $3"
+		args = append(args, limit)
+	}
+
+	rows, err := p.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	senders := make([]common.Address, 0, len(rows.RawValues()))
+	for rows.Next() {
+		var from string
+		if err := rows.Scan(&from); err != nil {
+			return nil, err
+		}
+		senders = append(senders, common.HexToAddress(from))
+	}
+
+	return senders, nil
+}
+
+// GetTxsBySendersAndStatus returns the txs sent by any of the provided senders
+// in the provided statuses, sorted by sender and nonce. The private txs are not
+// included.
+func (p *PostgresPoolStorage) GetTxsBySendersAndStatus(ctx context.Context, senders []common.Address, status ...pool.TxStatus) ([]pool.Transaction, error) {
+	if len(senders) == 0 {
+		return []pool.Transaction{}, nil
+	}
+
+	from := make([]string, 0, len(senders))
+	for _, sender := range senders {
+		from = append(from, sender.String())
+	}
+
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+				   used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_private, max_l2_block_number, bundle_hash, bundle_index, is_executable
+	          FROM pool.transaction
+	         WHERE from_address = ANY ($1)
+	           AND status = ANY ($2)
+	           AND is_private IS FALSE
+	         ORDER BY from_address ASC, nonce ASC, received_at ASC`
+	rows, err := p.db.Query(ctx, sql, from, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
 // UpdateTxStatus updates a transaction status accordingly to the
 // provided status and hash
 func (p *PostgresPoolStorage) UpdateTxStatus(ctx context.Context, updateInfo pool.TxStatusUpdateInfo) error {
@@ -519,7 +1161,7 @@ func (p *PostgresPoolStorage) IsTxPending(ctx context.Context, hash common.Hash)
 // GetTxsByFromAndNonce get all the transactions from the pool with the same from and nonce
 func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
 	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, 
-				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters
+				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, is_private, max_l2_block_number, bundle_hash, bundle_index, is_executable
 	          FROM pool.transaction
 			 WHERE from_address = $1
 			   AND nonce = $2`
@@ -562,13 +1204,25 @@ func (p *PostgresPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, ha
 	return common.HexToAddress(fromAddr), nonce, nil
 }
 
-// GetNonce gets the nonce to the provided address accordingly to the txs in the pool
+// GetNonce gets the nonce to the provided address accordingly to the public txs in
+// the pool, the private txs are not included
 func (p *PostgresPoolStorage) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
+	return p.getNonce(ctx, address, false)
+}
+
+// GetNonceWithPrivateTxs gets the nonce to the provided address accordingly to all
+// the txs in the pool, including the private ones
+func (p *PostgresPoolStorage) GetNonceWithPrivateTxs(ctx context.Context, address common.Address) (uint64, error) {
+	return p.getNonce(ctx, address, true)
+}
+
+func (p *PostgresPoolStorage) getNonce(ctx context.Context, address common.Address, withPrivateTxs bool) (uint64, error) {
 	sql := `SELECT MAX(nonce)
               FROM pool.transaction
              WHERE from_address = $1
-               AND status IN  ($2, $3)`
-	rows, err := p.db.Query(ctx, sql, address.String(), pool.TxStatusPending, pool.TxStatusSelected)
+               AND status IN  ($2, $3)
+               AND (is_private IS FALSE OR $4)`
+	rows, err := p.db.Query(ctx, sql, address.String(), pool.TxStatusPending, pool.TxStatusSelected, withPrivateTxs)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return 0, nil
 	} else if err != nil {
@@ -603,12 +1257,13 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 		encoded, status, ip string
 		receivedAt          time.Time
 		isWIP               bool
+		failedReason        *string
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, failed_reason
 	          FROM pool.transaction
 			 WHERE hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &failedReason)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -626,11 +1281,12 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 	}
 
 	poolTx := &pool.Transaction{
-		ReceivedAt:  receivedAt,
-		Status:      pool.TxStatus(status),
-		Transaction: *tx,
-		IsWIP:       isWIP,
-		IP:          ip,
+		ReceivedAt:   receivedAt,
+		Status:       pool.TxStatus(status),
+		Transaction:  *tx,
+		IsWIP:        isWIP,
+		IP:           ip,
+		FailedReason: failedReason,
 	}
 
 	return poolTx, nil
@@ -642,12 +1298,13 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 		encoded, status, ip string
 		receivedAt          time.Time
 		isWIP               bool
+		failedReason        *string
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, failed_reason
 	          FROM pool.transaction
 			 WHERE l2_hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &failedReason)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -665,11 +1322,12 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 	}
 
 	poolTx := &pool.Transaction{
-		ReceivedAt:  receivedAt,
-		Status:      pool.TxStatus(status),
-		Transaction: *tx,
-		IsWIP:       isWIP,
-		IP:          ip,
+		ReceivedAt:   receivedAt,
+		Status:       pool.TxStatus(status),
+		Transaction:  *tx,
+		IsWIP:        isWIP,
+		IP:           ip,
+		FailedReason: failedReason,
 	}
 
 	return poolTx, nil
@@ -691,10 +1349,16 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 		usedSHA256Hashes     uint32
 		failedReason         *string
 		reservedZKCounters   state.ZKCounters
+		isPrivate            bool
+		maxL2BlockNumber     *uint64
+		bundleHash           *string
+		bundleIndex          *uint64
+		isExecutable         bool
 	)
 
 	if err := rows.Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &cumulativeGasUsed, &usedKeccakHashes, &usedPoseidonHashes,
-		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters); err != nil {
+		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters,
+		&isPrivate, &maxL2BlockNumber, &bundleHash, &bundleIndex, &isExecutable); err != nil {
 		return nil, err
 	}
 
@@ -724,6 +1388,16 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 	tx.ZKCounters.Sha256Hashes_V2 = usedSHA256Hashes
 	tx.FailedReason = failedReason
 	tx.ReservedZKCounters = reservedZKCounters
+	tx.IsPrivate = isPrivate
+	tx.MaxL2BlockNumber = maxL2BlockNumber
+	if bundleHash != nil {
+		hash := common.HexToHash(*bundleHash)
+		tx.BundleHash = &hash
+	}
+	if bundleIndex != nil {
+		tx.BundleIndex = *bundleIndex
+	}
+	tx.IsExecutable = isExecutable
 
 	return tx, nil
 }
@@ -758,7 +1432,7 @@ func (p *PostgresPoolStorage) GetTxZkCountersByHash(ctx context.Context, hash co
 
 // MarkWIPTxsAsPending updates WIP status to non WIP
 func (p *PostgresPoolStorage) MarkWIPTxsAsPending(ctx context.Context) error {
-	const query = `UPDATE pool.transaction SET is_wip = false WHERE is_wip = true`
+	const query = `UPDATE pool.transaction SET is_wip = false, is_in_wip_l2_block = false WHERE is_wip = true OR is_in_wip_l2_block = true`
 	if _, err := p.db.Exec(ctx, query); err != nil {
 		return err
 	}
@@ -766,12 +1440,33 @@ func (p *PostgresPoolStorage) MarkWIPTxsAsPending(ctx context.Context) error {
 }
 
 // UpdateTxWIPStatus updates a transaction wip status accordingly to the
-// provided WIP status and hash
+// provided WIP status and hash, pool.ErrNotFound is returned when setting as
+// WIP a transaction that is no longer pending
 func (p *PostgresPoolStorage) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
-	sql := "UPDATE pool.transaction SET is_wip = $1 WHERE hash = $2"
-	if _, err := p.db.Exec(ctx, sql, isWIP, hash.Hex()); err != nil {
+	// only the pending txs are set as WIP, as the replaced ones are already failed
+	sql := "UPDATE pool.transaction SET is_wip = $1 WHERE hash = $2 AND (NOT $1 OR status = $3)"
+	commandTag, err := p.db.Exec(ctx, sql, isWIP, hash.Hex(), pool.TxStatusPending)
+	if err != nil {
 		return err
 	}
+	if commandTag.RowsAffected() == 0 {
+		return pool.ErrNotFound
+	}
+	return nil
+}
+
+// MarkTxInWIPL2Block sets a pending transaction as processed in the WIP L2 block
+// of the sequencer, so it is no longer replaced, pool.ErrNotFound is returned when
+// the transaction is no longer pending
+func (p *PostgresPoolStorage) MarkTxInWIPL2Block(ctx context.Context, hash common.Hash) error {
+	sql := "UPDATE pool.transaction SET is_in_wip_l2_block = true WHERE hash = $1 AND status = $2"
+	commandTag, err := p.db.Exec(ctx, sql, hash.Hex(), pool.TxStatusPending)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return pool.ErrNotFound
+	}
 	return nil
 }
 
@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"math/big"
 
+	"github.com/0xPolygonHermez/zkevm-node/encoding"
+	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jackc/pgx/v4"
 )
 
+// activeAclSQL filters the exceptions of pool.acl aliased as a to the ones whose time window includes the current time
+const activeAclSQL = "(a.valid_from IS NULL OR a.valid_from <= NOW()) AND (a.valid_until IS NULL OR a.valid_until > NOW())"
+
 // CheckPolicy returns the rule for the named policy and address. If the address is associated with the policy, the rule
 // will be the setting for the policy. If the address is no associated with the policy, the rule will be the opposite of
-// the policy setting.
+// the policy setting. The exceptions out of their time window are ignored.
 func (p *PostgresPoolStorage) CheckPolicy(ctx context.Context, policy pool.PolicyName, address common.Address) (bool, error) {
 	sql := `SELECT 
 				CASE WHEN a.address is null THEN 
@@ -25,9 +31,63 @@ func (p *PostgresPoolStorage) CheckPolicy(ctx context.Context, policy pool.Polic
 				LEFT JOIN pool.acl a 
 					ON p.name = a.policy 
 					AND a.address = $1 
+					AND a.selector = '' 
+					AND ` + activeAclSQL + ` 
+			WHERE p.name = $2`
+
+	return p.checkPolicyRule(ctx, sql, address.Hex(), policy)
+}
+
+// CheckCallPolicy returns the rule of the call policy for the contract and function selector. If the contract is
+// associated with the policy without selector, or with the given selector, the rule will be the setting for the policy.
+// Otherwise, the rule will be the opposite of the policy setting. The exceptions out of their time window are ignored.
+func (p *PostgresPoolStorage) CheckCallPolicy(ctx context.Context, contract common.Address, selector []byte) (bool, error) {
+	sql := `SELECT 
+				CASE WHEN COUNT(a.address) = 0 THEN 
+					NOT p.allow 
+				ELSE 
+					p.allow 
+				END 
+			FROM pool.policy p 
+				LEFT JOIN pool.acl a 
+					ON p.name = a.policy 
+					AND a.address = $1 
+					AND (a.selector = '' OR a.selector = $3) 
+					AND ` + activeAclSQL + ` 
+			WHERE p.name = $2 
+			GROUP BY p.allow`
+
+	return p.checkPolicyRule(ctx, sql, contract.Hex(), pool.Call, encodeSelector(selector))
+}
+
+// CheckValuePolicy returns the rule of the value policy for the address and the transferred value. If the address is
+// associated with the policy with a max value, the value must not exceed it. If the address is associated with the
+// policy without a max value, any value is allowed when the policy allows and only zero is allowed when the policy
+// denies. If the address is not associated with the policy, any value is allowed when the policy denies and only
+// zero is allowed when the policy allows. The exceptions out of their time window are ignored.
+func (p *PostgresPoolStorage) CheckValuePolicy(ctx context.Context, address common.Address, value *big.Int) (bool, error) {
+	sql := `SELECT 
+				CASE WHEN a.address is null THEN 
+					NOT p.allow OR $3::DECIMAL = 0 
+				WHEN a.max_value IS NULL THEN 
+					p.allow OR $3::DECIMAL = 0 
+				ELSE 
+					a.max_value >= $3::DECIMAL 
+				END 
+			FROM pool.policy p 
+				LEFT JOIN pool.acl a 
+					ON p.name = a.policy 
+					AND a.address = $1 
+					AND a.selector = '' 
+					AND ` + activeAclSQL + ` 
 			WHERE p.name = $2`
 
-	rows, err := p.db.Query(ctx, sql, address.Hex(), policy)
+	return p.checkPolicyRule(ctx, sql, address.Hex(), pool.Value, value.String())
+}
+
+// checkPolicyRule runs a query returning the rule of a policy, which is denied if the policy doesn't exist
+func (p *PostgresPoolStorage) checkPolicyRule(ctx context.Context, sql string, args ...interface{}) (bool, error) {
+	rows, err := p.db.Query(ctx, sql, args...)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return false, pool.ErrNotFound
@@ -82,6 +142,32 @@ func (p *PostgresPoolStorage) AddAddressesToPolicy(ctx context.Context, policy p
 	return nil
 }
 
+// AddAcls adds the exceptions to their named policies, replacing the max value and time window of the existing ones
+func (p *PostgresPoolStorage) AddAcls(ctx context.Context, acls []pool.Acl) error {
+	sql := `INSERT INTO pool.acl (policy, address, selector, max_value, valid_from, valid_until) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (address, policy, selector) DO UPDATE SET
+				max_value = EXCLUDED.max_value, valid_from = EXCLUDED.valid_from, valid_until = EXCLUDED.valid_until`
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, a := range acls {
+		var maxValue *string
+		if a.MaxValue != nil {
+			maxValue = state.Ptr(a.MaxValue.String())
+		}
+		_, err = tx.Exec(ctx, sql, a.PolicyName, a.Address.Hex(), encodeSelector(a.Selector), maxValue, a.ValidFrom, a.ValidUntil)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 // RemoveAddressesFromPolicy removes addresses from the named policy
 func (p *PostgresPoolStorage) RemoveAddressesFromPolicy(ctx context.Context, policy pool.PolicyName, addresses []common.Address) error {
 	sql := "DELETE FROM pool.acl WHERE policy = $1 AND address = $2"
@@ -106,6 +192,26 @@ func (p *PostgresPoolStorage) RemoveAddressesFromPolicy(ctx context.Context, pol
 	return nil
 }
 
+// RemoveAcls removes the exceptions from their named policies, matching them by address and selector
+func (p *PostgresPoolStorage) RemoveAcls(ctx context.Context, acls []pool.Acl) error {
+	sql := "DELETE FROM pool.acl WHERE policy = $1 AND address = $2 AND selector = $3"
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, a := range acls {
+		_, err = tx.Exec(ctx, sql, a.PolicyName, a.Address.Hex(), encodeSelector(a.Selector))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 // ClearPolicy removes _all_ addresses from the named policy
 func (p *PostgresPoolStorage) ClearPolicy(ctx context.Context, policy pool.PolicyName) error {
 	sql := "DELETE FROM pool.acl WHERE policy = $1"
@@ -166,20 +272,23 @@ func (p *PostgresPoolStorage) DescribePolicy(ctx context.Context, name pool.Poli
 	}, nil
 }
 
-// ListAcl returns a list of the addresses associated with the policy
+// ListAcl returns a list of the exceptions associated with the policy, including the ones out of their time window
 func (p *PostgresPoolStorage) ListAcl(
-	ctx context.Context, policy pool.PolicyName, query []common.Address) ([]common.Address, error) {
-	sql := "SELECT address FROM pool.acl WHERE policy = $1"
+	ctx context.Context, policy pool.PolicyName, query []common.Address) ([]pool.Acl, error) {
+	sql := `SELECT address, selector, max_value::TEXT, valid_from, valid_until FROM pool.acl WHERE policy = $1`
+	args := []interface{}{string(policy)}
 
 	if len(query) > 0 {
 		var addrs []string
 		for _, a := range query {
 			addrs = append(addrs, a.Hex())
 		}
-		sql = sql + fmt.Sprintf(" IN (%v)", strings.Join(addrs, ","))
+		sql = sql + " AND address = ANY($2)"
+		args = append(args, addrs)
 	}
+	sql = sql + " ORDER BY address, selector"
 
-	rows, err := p.db.Query(ctx, sql, string(policy))
+	rows, err := p.db.Query(ctx, sql, args...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -189,14 +298,39 @@ func (p *PostgresPoolStorage) ListAcl(
 	}
 	defer rows.Close()
 
-	var addresses []common.Address
+	var acls []pool.Acl
 	for rows.Next() {
-		var addr string
-		err = rows.Scan(&addr)
+		var (
+			addr, selector string
+			maxValue       *string
+			acl            = pool.Acl{PolicyName: policy}
+		)
+		err = rows.Scan(&addr, &selector, &maxValue, &acl.ValidFrom, &acl.ValidUntil)
 		if err != nil {
 			return nil, err
 		}
-		addresses = append(addresses, common.HexToAddress(addr))
+		acl.Address = common.HexToAddress(addr)
+		if selector != "" {
+			if acl.Selector, err = hex.DecodeHex(selector); err != nil {
+				return nil, err
+			}
+		}
+		if maxValue != nil {
+			value, ok := new(big.Int).SetString(*maxValue, encoding.Base10)
+			if !ok {
+				return nil, fmt.Errorf("invalid max value %s of %s", *maxValue, addr)
+			}
+			acl.MaxValue = value
+		}
+		acls = append(acls, acl)
+	}
+	return acls, nil
+}
+
+// encodeSelector returns the representation of a function selector in pool.acl, empty for no selector
+func encodeSelector(selector []byte) string {
+	if len(selector) == 0 {
+		return ""
 	}
-	return addresses, nil
+	return hex.EncodeToHex(selector)
 }
@@ -1,6 +1,11 @@
 package pool
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 // PolicyName is a named policy
 type PolicyName string
@@ -10,8 +15,15 @@ const (
 	SendTx PolicyName = "send_tx"
 	// Deploy is the name of the policy that governs that an address may deploy a contract
 	Deploy PolicyName = "deploy"
+	// Call is the name of the policy that governs that a contract, or some of its functions, may be called
+	Call PolicyName = "call"
+	// Value is the name of the policy that governs the value an address may transfer in a transaction
+	Value PolicyName = "value"
 )
 
+// SelectorLength is the length of the function selector of a contract call
+const SelectorLength = 4
+
 // Policy describes state of a named policy
 type Policy struct {
 	Name  PolicyName
@@ -30,14 +42,32 @@ func (p *Policy) Desc() string {
 type Acl struct {
 	PolicyName PolicyName
 	Address    common.Address
+	// Selector restricts an exception to the Call policy to a function of the contract,
+	// the exception applies to any call to the contract when it is empty
+	Selector []byte
+	// MaxValue is the max value the address may transfer in a transaction for an exception
+	// to the Value policy, there is no limit when it is nil
+	MaxValue *big.Int
+	// ValidFrom and ValidUntil limit the time window in which the exception applies,
+	// the window is unbounded on the nil sides
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
 }
 
 // IsPolicy tests if a string represents a known named Policy
 func IsPolicy(name string) bool {
-	for _, p := range []PolicyName{SendTx, Deploy} {
+	for _, p := range []PolicyName{SendTx, Deploy, Call, Value} {
 		if name == string(p) {
 			return true
 		}
 	}
 	return false
 }
+
+// CallSelector returns the function selector of the tx data, or nil if the data is too short to have one
+func CallSelector(data []byte) []byte {
+	if len(data) < SelectorLength {
+		return nil
+	}
+	return data[:SelectorLength]
+}
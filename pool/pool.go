@@ -30,6 +30,22 @@ var (
 	// with a different one without the required price bump.
 	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
 
+	// ErrReplaceWIP is returned if a transaction is attempted to be replaced
+	// while the sequencer is already processing it in the WIP L2 block.
+	ErrReplaceWIP = errors.New("replaced transaction is already being processed")
+
+	// ErrReplacedTransaction is the failed reason of a transaction replaced by
+	// another one with the same sender and nonce and a higher gas price.
+	ErrReplacedTransaction = errors.New("replaced transaction")
+
+	// ErrEvictedTransaction is the failed reason of a transaction evicted from
+	// the full pool to make room for another one with a higher gas price.
+	ErrEvictedTransaction = errors.New("evicted transaction")
+
+	// ErrExpiredPrivateTransaction is the failed reason of a private transaction
+	// not included in an L2 block up to its max L2 block number.
+	ErrExpiredPrivateTransaction = errors.New("private transaction expired")
+
 	// ErrEffectiveGasPriceGasPriceTooLow the tx gas price is lower than breakEvenGasPrice and lower than L2GasPrice
 	ErrEffectiveGasPriceGasPriceTooLow = errors.New("effective gas price: gas price too low")
 )
@@ -50,6 +66,9 @@ type Pool struct {
 	gasPrices               GasPrices
 	gasPricesMux            *sync.RWMutex
 	effectiveGasPrice       *EffectiveGasPrice
+
+	newPendingTxEventHandlers    []NewPendingTxEventHandler
+	newPendingTxEventHandlersMux *sync.RWMutex
 }
 
 type preExecutionResponse struct {
@@ -85,6 +104,8 @@ func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storag
 		gasPrices:               GasPrices{0, 0},
 		gasPricesMux:            new(sync.RWMutex),
 		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+
+		newPendingTxEventHandlersMux: new(sync.RWMutex),
 	}
 	p.refreshGasPrices()
 	go func(cfg *Config, p *Pool) {
@@ -184,11 +205,199 @@ func (p *Pool) AddTx(ctx context.Context, tx types.Transaction, ip string) error
 		return err
 	}
 
-	return p.StoreTx(ctx, tx, ip, false)
+	return p.storeTx(ctx, poolTx)
+}
+
+// AddPrivateTx adds a private transaction to the pool with the pending state. It is
+// hidden from the pool introspection and the pending tx notifications and expires if
+// it is not included in an L2 block up to the provided max L2 block number, which is
+// limited to PrivateTxMaxBlocks blocks after the last L2 block, and is used when nil.
+func (p *Pool) AddPrivateTx(ctx context.Context, tx types.Transaction, ip string, maxL2BlockNumber *uint64) error {
+	if p.cfg.PrivateTxMaxBlocks == 0 {
+		return ErrPrivateTxsDisabled
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return err
+	}
+	lastL2BlockNumber := lastL2Block.NumberU64()
+	deadline := lastL2BlockNumber + p.cfg.PrivateTxMaxBlocks
+	if maxL2BlockNumber != nil {
+		if *maxL2BlockNumber <= lastL2BlockNumber {
+			return ErrMaxBlockNumberReached
+		}
+		if *maxL2BlockNumber < deadline {
+			deadline = *maxL2BlockNumber
+		}
+	}
+
+	poolTx := NewTransaction(tx, ip, false)
+	poolTx.IsPrivate = true
+	poolTx.MaxL2BlockNumber = &deadline
+	if err := p.validateTx(ctx, *poolTx); err != nil {
+		return err
+	}
+
+	return p.storeTx(ctx, poolTx)
+}
+
+// ExpirePrivateTxs sets as failed the pending private txs not being processed by the
+// sequencer whose max L2 block number is not after the provided L2 block number,
+// returning their hashes
+func (p *Pool) ExpirePrivateTxs(ctx context.Context, l2BlockNumber uint64) ([]common.Hash, error) {
+	return p.storage.ExpirePrivateTxs(ctx, l2BlockNumber, ErrExpiredPrivateTransaction.Error())
 }
 
 // StoreTx adds a transaction to the pool with the pending state
 func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isWIP bool) error {
+	return p.storeTx(ctx, NewTransaction(tx, ip, isWIP))
+}
+
+// AddBundle adds the transactions of a bundle to the pool with the pending state,
+// which are processed by the sequencer consecutively in the same L2 block or not at
+// all. The bundle transactions are never replaced nor evicted by other transactions,
+// and their reserved zkCounters must fit in an empty batch. It returns the bundle hash.
+func (p *Pool) AddBundle(ctx context.Context, txs []types.Transaction, ip string) (common.Hash, error) {
+	if p.cfg.MaxBundleTxs == 0 {
+		return common.Hash{}, ErrBundlesDisabled
+	}
+	if len(txs) == 0 {
+		return common.Hash{}, ErrEmptyBundle
+	}
+	if uint64(len(txs)) > p.cfg.MaxBundleTxs {
+		return common.Hash{}, ErrBundleTooLarge
+	}
+
+	bundleHash := BundleHash(txs)
+	receivedAt := time.Now()
+	poolTxs := make([]Transaction, 0, len(txs))
+	txHashes := make(map[common.Hash]struct{}, len(txs))
+	reservedZKCounters := state.ZKCounters{}
+	for i, tx := range txs {
+		if _, found := txHashes[tx.Hash()]; found {
+			return common.Hash{}, fmt.Errorf("bundle tx %d: %w", i, ErrAlreadyKnown)
+		}
+		txHashes[tx.Hash()] = struct{}{}
+
+		// the bundle txs are received at the same time, so they are loaded together
+		poolTx := NewTransaction(tx, ip, false)
+		poolTx.ReceivedAt = receivedAt
+		poolTx.BundleHash = &bundleHash
+		poolTx.BundleIndex = uint64(i)
+		if err := p.validateTx(ctx, *poolTx); err != nil {
+			return common.Hash{}, fmt.Errorf("bundle tx %d: %w", i, err)
+		}
+		if err := p.preExecuteAndCheckTx(ctx, poolTx); err != nil {
+			return common.Hash{}, fmt.Errorf("bundle tx %d: %w", i, err)
+		}
+		reservedZKCounters.SumUp(poolTx.ReservedZKCounters)
+		poolTxs = append(poolTxs, *poolTx)
+	}
+
+	// the bundle is processed in a single execution, so it must fit in an empty batch
+	if !p.batchConstraintsCfg.IsWithinConstraints(reservedZKCounters) {
+		return common.Hash{}, ErrOutOfCounters
+	}
+
+	// the state nonces are needed to promote the txs sent after the bundle ones
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	stateNonces := map[common.Address]uint64{}
+	for i, poolTx := range poolTxs {
+		from, err := state.GetSender(poolTx.Transaction)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if _, found := stateNonces[from]; found {
+			continue
+		}
+		stateNonces[from], err = p.state.GetNonce(ctx, from, lastL2Block.Root())
+		if err != nil {
+			return common.Hash{}, err
+		}
+
+		// the sequencer processes the bundle once the nonce of each sender reaches
+		// its first tx in the bundle, so it must follow the pending txs of the sender,
+		// including the private ones
+		pendingNonce, err := p.storage.GetNonceWithPrivateTxs(ctx, from)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if stateNonces[from] > pendingNonce {
+			pendingNonce = stateNonces[from]
+		}
+		if poolTx.Nonce() != pendingNonce {
+			return common.Hash{}, fmt.Errorf("bundle tx %d: %w", i, ErrBundleNonceNotPending)
+		}
+	}
+
+	evictedTxs, err := p.storage.AddBundle(ctx, poolTxs, stateNonces, p.txEviction())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	p.logEvictedTxs(ctx, evictedTxs, fmt.Sprintf("bundle %s", bundleHash.String()), ip)
+	for _, poolTx := range poolTxs {
+		// when listening the pool DB notifications, the tx is notified with them
+		if !p.cfg.ListenPendingTxNotifications {
+			p.notifyNewPendingTx(poolTx)
+		}
+	}
+	return bundleHash, nil
+}
+
+// storeTx pre-executes the provided transaction and adds it to the pool
+func (p *Pool) storeTx(ctx context.Context, poolTx *Transaction) error {
+	tx, ip := poolTx.Transaction, poolTx.IP
+
+	if err := p.preExecuteAndCheckTx(ctx, poolTx); err != nil {
+		return err
+	}
+
+	// the WIP txs are already being processed by the sequencer, so they don't replace other txs
+	if poolTx.IsWIP {
+		if err := p.storage.AddTx(ctx, *poolTx); err != nil {
+			return err
+		}
+	} else {
+		// the state nonce is needed to know if the tx is executable or queued
+		from, err := state.GetSender(tx)
+		if err != nil {
+			return err
+		}
+		lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+		if err != nil {
+			return err
+		}
+		stateNonce, err := p.state.GetNonce(ctx, from, lastL2Block.Root())
+		if err != nil {
+			return err
+		}
+
+		replacedTxs, evictedTxs, err := p.storage.AddOrReplaceTx(ctx, *poolTx, stateNonce, p.maxReplaceableGasPrice(tx.GasPrice()), ErrReplacedTransaction.Error(), p.txEviction())
+		if err != nil {
+			return err
+		}
+		for _, replacedTx := range replacedTxs {
+			log.Infof("tx %s replaced by tx %s", replacedTx.String(), tx.Hash().String())
+		}
+		p.logEvictedTxs(ctx, evictedTxs, fmt.Sprintf("tx %s", tx.Hash().String()), ip)
+	}
+
+	// when listening the pool DB notifications, the tx is notified with them
+	if !p.cfg.ListenPendingTxNotifications && !poolTx.IsPrivate {
+		p.notifyNewPendingTx(*poolTx)
+	}
+	return nil
+}
+
+// preExecuteAndCheckTx pre-executes the provided transaction to calculate its zkCounters
+// and checks its gas price against the break even gas price
+func (p *Pool) preExecuteAndCheckTx(ctx context.Context, poolTx *Transaction) error {
+	tx, ip := poolTx.Transaction, poolTx.IP
+
 	// Execute transaction to calculate its zkCounters
 	preExecutionResponse, err := p.preExecuteTx(ctx, tx)
 	if errors.Is(err, runtime.ErrIntrinsicInvalidBatchGasLimit) {
@@ -245,11 +454,39 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 		return err
 	}
 
-	poolTx := NewTransaction(tx, ip, isWIP)
 	poolTx.ZKCounters = preExecutionResponse.usedZKCounters
 	poolTx.ReservedZKCounters = preExecutionResponse.reservedZKCounters
 
-	return p.storage.AddTx(ctx, *poolTx)
+	return nil
+}
+
+// txEviction returns the limits to evict the queued txs when the pool is full
+func (p *Pool) txEviction() TxEviction {
+	return TxEviction{
+		GlobalQueue:  p.cfg.GlobalQueue,
+		AccountSlots: p.cfg.AccountSlots,
+		Reason:       ErrEvictedTransaction.Error(),
+	}
+}
+
+// logEvictedTxs logs an event for each tx evicted from the full pool to make room
+// for the provided tx or bundle
+func (p *Pool) logEvictedTxs(ctx context.Context, evictedTxs []common.Hash, evictedBy string, ip string) {
+	for _, evictedTx := range evictedTxs {
+		log.Infof("tx %s evicted by %s", evictedTx.String(), evictedBy)
+		event := &event.Event{
+			ReceivedAt:  time.Now(),
+			IPAddress:   ip,
+			Source:      event.Source_Node,
+			Component:   event.Component_Pool,
+			Level:       event.Level_Info,
+			EventID:     event.EventID_PoolTxEvicted,
+			Description: fmt.Sprintf("tx %s evicted by %s", evictedTx.String(), evictedBy),
+		}
+		if err := p.eventLog.LogEvent(ctx, event); err != nil {
+			log.Errorf("error adding event: %v", err)
+		}
+	}
 }
 
 // ValidateBreakEvenGasPrice validates the effective gas price
@@ -360,9 +597,28 @@ func (p *Pool) GetPendingTxs(ctx context.Context, limit uint64) ([]Transaction,
 	return p.storage.GetTxsByStatus(ctx, TxStatusPending, limit)
 }
 
-// GetNonWIPPendingTxs from the pool
-func (p *Pool) GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error) {
-	return p.storage.GetNonWIPPendingTxs(ctx)
+// GetNonWIPExecutableTxs returns the executable txs of the pool that are not
+// being processed by the sequencer yet
+func (p *Pool) GetNonWIPExecutableTxs(ctx context.Context) ([]Transaction, error) {
+	return p.storage.GetNonWIPExecutableTxs(ctx)
+}
+
+// GetNonWIPBundles returns the bundles of the pool whose txs are all pending and
+// not being processed by the sequencer yet
+func (p *Pool) GetNonWIPBundles(ctx context.Context) ([]Bundle, error) {
+	return p.storage.GetNonWIPBundles(ctx)
+}
+
+// ListenNewExecutableTxs calls the provided func each time pending txs of any
+// node sharing the pool DB become executable, and once when it starts listening,
+// until the context is done or the connection to the pool DB fails
+func (p *Pool) ListenNewExecutableTxs(ctx context.Context, onNewExecutableTxs func()) error {
+	return p.storage.ListenNewExecutableTxs(ctx, onNewExecutableTxs)
+}
+
+// DeleteIdleSenderNonces deletes the next nonces kept for the senders without pending txs
+func (p *Pool) DeleteIdleSenderNonces(ctx context.Context) error {
+	return p.storage.DeleteIdleSenderNonces(ctx)
 }
 
 // GetSelectedTxs gets selected txs from the pool db
@@ -370,7 +626,8 @@ func (p *Pool) GetSelectedTxs(ctx context.Context, limit uint64) ([]Transaction,
 	return p.storage.GetTxsByStatus(ctx, TxStatusSelected, limit)
 }
 
-// GetPendingTxHashesSince returns the hashes of pending tx since the given date.
+// GetPendingTxHashesSince returns the hashes of pending tx since the given date,
+// the private txs are not included.
 func (p *Pool) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
 	return p.storage.GetPendingTxHashesSince(ctx, since)
 }
@@ -419,6 +676,14 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrInvalidIP
 	}
 
+	// Accept only legacy transactions, the batch encoding can't carry typed
+	// transactions and converting them to legacy would invalidate the signature.
+	// This is checked before the signature, otherwise typed transactions are
+	// reported as signed by an invalid sender.
+	if poolTx.Type() != types.LegacyTxType {
+		return ErrTxTypeNotSupported
+	}
+
 	// Make sure the transaction is signed properly.
 	if err := state.CheckSignature(poolTx.Transaction); err != nil {
 		return ErrInvalidSender
@@ -430,11 +695,6 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrInvalidChainID
 	}
 
-	// Accept only legacy transactions until EIP-2718/2930 activates.
-	if poolTx.Type() != types.LegacyTxType {
-		return ErrTxTypeNotSupported
-	}
-
 	// check Pre EIP155 txs signature
 	if txChainID == 0 && !state.IsPreEIP155Tx(poolTx.Transaction) {
 		return ErrInvalidSender
@@ -511,7 +771,16 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 			return err
 		}
 		if txCount >= p.cfg.GlobalQueue {
-			return ErrTxPoolOverflow
+			// the tx is only accepted if enough cheaper queued txs can be evicted to make room
+			// for it, which is checked again when it is stored
+			evictableTxCount, err := p.storage.CountEvictableTxs(ctx, p.cfg.AccountSlots, poolTx.GasPrice())
+			if err != nil {
+				log.Errorf("failed to count evictable pool txs while adding tx to the pool", err)
+				return err
+			}
+			if evictableTxCount < txCount-p.cfg.GlobalQueue+1 {
+				return ErrTxPoolOverflow
+			}
 		}
 	}
 
@@ -555,24 +824,27 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return err
 	}
 
-	// check if the new transaction has more gas than all the other txs in the pool
-	// with the same from and nonce to be able to replace the current txs by the new
-	// when being selected
+	// check if the gas price of the new transaction is increased at least by the
+	// price bump over the other txs in the pool with the same from and nonce to
+	// replace them, which is checked again when it is stored
+	maxReplaceableGasPrice := p.maxReplaceableGasPrice(poolTx.GasPrice())
 	for _, oldTx := range oldTxs {
 		// discard invalid txs
 		if oldTx.Status == TxStatusInvalid || oldTx.Status == TxStatusFailed {
 			continue
 		}
 
-		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
-		txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
-
 		if oldTx.Hash() == poolTx.Hash() {
 			return ErrAlreadyKnown
 		}
 
-		// if old Tx Price is higher than the new poolTx price, it returns an error
-		if oldTxPrice.Cmp(txPrice) > 0 {
+		// the bundle txs are never replaced, which is checked again when it is stored
+		if oldTx.BundleHash != nil {
+			return ErrBundleNonceCollision
+		}
+
+		// if old Tx gas price is higher than the replaceable one, it returns an error
+		if oldTx.GasPrice().Cmp(maxReplaceableGasPrice) > 0 {
 			return ErrReplaceUnderpriced
 		}
 	}
@@ -585,6 +857,15 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 	return nil
 }
 
+// maxReplaceableGasPrice returns the max gas price of the txs that can be
+// replaced by a tx with the provided gas price, which must be increased at
+// least by the configured price bump percentage
+func (p *Pool) maxReplaceableGasPrice(gasPrice *big.Int) *big.Int {
+	const percent = 100
+	maxGasPrice := new(big.Int).Mul(gasPrice, big.NewInt(percent))
+	return maxGasPrice.Div(maxGasPrice, new(big.Int).SetUint64(percent+p.cfg.PriceBump))
+}
+
 // pollMinSuggestedGasPrice polls the minimum L2 gas price since the previous
 // check accordingly to the configured interval and tries to update it
 func (p *Pool) pollMinSuggestedGasPrice(ctx context.Context) {
@@ -660,11 +941,19 @@ func (p *Pool) DeleteReorgedTransactions(ctx context.Context, transactions []*ty
 }
 
 // UpdateTxWIPStatus updates a transaction wip status accordingly to the
-// provided WIP status and hash
+// provided WIP status and hash, ErrNotFound is returned when setting as WIP
+// a transaction that is no longer pending, e.g. because it has been replaced
 func (p *Pool) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
 	return p.storage.UpdateTxWIPStatus(ctx, hash, isWIP)
 }
 
+// MarkTxInWIPL2Block sets a transaction as processed in the WIP L2 block of the
+// sequencer, so it is no longer replaced, ErrNotFound is returned when the
+// transaction is no longer pending, e.g. because it has been replaced
+func (p *Pool) MarkTxInWIPL2Block(ctx context.Context, hash common.Hash) error {
+	return p.storage.MarkTxInWIPL2Block(ctx, hash)
+}
+
 // GetDefaultMinGasPriceAllowed return the configured DefaultMinGasPriceAllowed value
 func (p *Pool) GetDefaultMinGasPriceAllowed() uint64 {
 	return p.cfg.DefaultMinGasPriceAllowed
@@ -696,6 +985,12 @@ func (p *Pool) CalculateEffectiveGasPricePercentage(gasPrice *big.Int, effective
 	return p.effectiveGasPrice.CalculateEffectiveGasPricePercentage(gasPrice, effectiveGasPrice)
 }
 
+// MaxBundleTxs returns the max number of transactions of a bundle, zero when
+// the bundles are disabled
+func (p *Pool) MaxBundleTxs() uint64 {
+	return p.cfg.MaxBundleTxs
+}
+
 // EffectiveGasPriceEnabled returns if effective gas price calculation is enabled or not
 func (p *Pool) EffectiveGasPriceEnabled() bool {
 	return p.effectiveGasPrice.IsEnabled()
@@ -740,3 +1035,13 @@ func IntrinsicGas(tx types.Transaction) (uint64, error) {
 func (p *Pool) CheckPolicy(ctx context.Context, policy PolicyName, address common.Address) (bool, error) {
 	return p.storage.CheckPolicy(ctx, policy, address)
 }
+
+// CheckCallPolicy checks if a call to the function of a contract identified by the selector is allowed by the call policy
+func (p *Pool) CheckCallPolicy(ctx context.Context, contract common.Address, selector []byte) (bool, error) {
+	return p.storage.CheckCallPolicy(ctx, contract, selector)
+}
+
+// CheckValuePolicy checks if an address is allowed to transfer the value by the value policy
+func (p *Pool) CheckValuePolicy(ctx context.Context, address common.Address, value *big.Int) (bool, error) {
+	return p.storage.CheckValuePolicy(ctx, address, value)
+}
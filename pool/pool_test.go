@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/big"
 	"os"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -34,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -742,6 +744,12 @@ func Test_UpdateTxStatus(t *testing.T) {
 
 	assert.Equal(t, pool.TxStatusInvalid, pool.TxStatus(state))
 	assert.Equal(t, expectedFailedReason, failedReason)
+
+	poolTx, err := p.GetTransactionByHash(ctx, signedTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusInvalid, poolTx.Status)
+	require.NotNil(t, poolTx.FailedReason)
+	assert.Equal(t, expectedFailedReason, *poolTx.FailedReason)
 }
 
 func Test_SetAndGetGasPrice(t *testing.T) {
@@ -1085,6 +1093,43 @@ func Test_TryAddIncompatibleTxs(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("chain id higher than allowed, max allowed is %v", uint64(math.MaxUint64)),
 		},
+		{
+			name: "access list tx",
+			createIncompatibleTx: func() ethTypes.Transaction {
+				to := common.HexToAddress("0x1")
+				tx := ethTypes.NewTx(&ethTypes.AccessListTx{
+					ChainID:  big.NewInt(0).SetUint64(operations.DefaultL2ChainID),
+					Nonce:    uint64(0),
+					GasPrice: gasPrice,
+					Gas:      gasLimit,
+					To:       &to,
+					Value:    big.NewInt(1),
+				})
+				signedTx, err := auth.Signer(auth.From, tx)
+				require.NoError(t, err)
+				return *signedTx
+			},
+			expectedError: pool.ErrTxTypeNotSupported,
+		},
+		{
+			name: "dynamic fee tx",
+			createIncompatibleTx: func() ethTypes.Transaction {
+				to := common.HexToAddress("0x1")
+				tx := ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+					ChainID:   big.NewInt(0).SetUint64(operations.DefaultL2ChainID),
+					Nonce:     uint64(0),
+					GasTipCap: gasPrice,
+					GasFeeCap: gasPrice,
+					Gas:       gasLimit,
+					To:        &to,
+					Value:     big.NewInt(1),
+				})
+				signedTx, err := auth.Signer(auth.From, tx)
+				require.NoError(t, err)
+				return *signedTx
+			},
+			expectedError: pool.ErrTxTypeNotSupported,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -1828,6 +1873,123 @@ func Test_AddTx_GlobalQueueLimit(t *testing.T) {
 	require.Error(t, err, pool.ErrTxPoolOverflow)
 }
 
+func Test_AddTx_GlobalQueueEviction(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	// the cheap txs are sent by the first account and the expensive ones by the rest
+	privateKeys := []*ecdsa.PrivateKey{}
+	genesisActions := []*state.GenesisAction{}
+	for i := 0; i < 3; i++ {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		privateKeys = append(privateKeys, privateKey)
+		genesisActions = append(genesisActions, &state.GenesisAction{
+			Address: crypto.PubkeyToAddress(privateKey.PublicKey).String(),
+			Type:    int(merkletree.LeafTypeBalance),
+			Value:   "1000000000000000000000",
+		})
+	}
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: genesisActions,
+	}
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	evictionCfg := cfg
+	evictionCfg.GlobalQueue = 4
+	evictionCfg.AccountSlots = 1
+	p := setupPool(t, evictionCfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	signTx := func(privateKey *ecdsa.PrivateKey, nonce uint64, gasPrice *big.Int) *ethTypes.Transaction {
+		auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+		require.NoError(t, err)
+		tx := ethTypes.NewTransaction(nonce, common.Address{}, big.NewInt(0), gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return signedTx
+	}
+	assertEvicted := func(tx *ethTypes.Transaction) {
+		poolTx, err := p.GetTransactionByHash(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusFailed, poolTx.Status)
+		require.NotNil(t, poolTx.FailedReason)
+		assert.Equal(t, pool.ErrEvictedTransaction.Error(), *poolTx.FailedReason)
+	}
+	doubleGasPrice := new(big.Int).Mul(gasPrice, big.NewInt(2))
+
+	// fill the pool with cheap queued txs, as the nonce 0 is missing
+	cheapTxs := []*ethTypes.Transaction{}
+	for nonce := uint64(1); nonce <= evictionCfg.GlobalQueue; nonce++ {
+		tx := signTx(privateKeys[0], nonce, gasPrice)
+		require.NoError(t, p.AddTx(ctx, *tx, ip))
+		cheapTxs = append(cheapTxs, tx)
+	}
+
+	// a tx without a higher gas price doesn't evict any tx
+	err = p.AddTx(ctx, *signTx(privateKeys[1], 0, gasPrice), ip)
+	require.ErrorIs(t, err, pool.ErrTxPoolOverflow)
+
+	// the cheap txs are evicted from the highest nonce down
+	require.NoError(t, p.AddTx(ctx, *signTx(privateKeys[1], 0, doubleGasPrice), ip))
+	assertEvicted(cheapTxs[3])
+	require.NoError(t, p.AddTx(ctx, *signTx(privateKeys[1], 1, doubleGasPrice), ip))
+	assertEvicted(cheapTxs[2])
+	require.NoError(t, p.AddTx(ctx, *signTx(privateKeys[2], 0, doubleGasPrice), ip))
+	assertEvicted(cheapTxs[1])
+
+	// the queued tx in the account slot of the sender and the executable txs,
+	// even if cheaper, are kept
+	expensiveTx := signTx(privateKeys[2], 1, new(big.Int).Mul(gasPrice, big.NewInt(4)))
+	err = p.AddTx(ctx, *expensiveTx, ip)
+	require.ErrorIs(t, err, pool.ErrTxPoolOverflow)
+
+	// the storage rejects the tx without adding it if no tx can be evicted
+	eviction := pool.TxEviction{GlobalQueue: evictionCfg.GlobalQueue, AccountSlots: evictionCfg.AccountSlots, Reason: pool.ErrEvictedTransaction.Error()}
+	_, _, err = s.AddOrReplaceTx(ctx, *pool.NewTransaction(*expensiveTx, ip, false), 1, gasPrice, pool.ErrReplacedTransaction.Error(), eviction)
+	require.ErrorIs(t, err, pool.ErrTxPoolOverflow)
+	_, err = p.GetTransactionByHash(ctx, expensiveTx.Hash())
+	require.ErrorIs(t, err, pool.ErrNotFound)
+
+	pendingTxCount, err := p.CountPendingTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, evictionCfg.GlobalQueue, pendingTxCount)
+
+	poolTx, err := p.GetTransactionByHash(ctx, cheapTxs[0].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusPending, poolTx.Status)
+}
+
 func Test_AddTx_NonceTooHigh(t *testing.T) {
 	eventStorage, err := nileventstorage.NewNilEventStorage()
 	if err != nil {
@@ -1897,6 +2059,519 @@ func Test_AddTx_NonceTooHigh(t *testing.T) {
 	require.Error(t, err, pool.ErrNonceTooHigh)
 }
 
+func Test_AddTx_Replacement(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	replacementCfg := cfg
+	replacementCfg.PriceBump = 10
+	p := setupPool(t, replacementCfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signTx := func(value int64, gasPrice *big.Int) *ethTypes.Transaction {
+		tx := ethTypes.NewTransaction(uint64(0), common.Address{}, big.NewInt(value), gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return signedTx
+	}
+
+	originalTx := signTx(10, gasPrice)
+	require.NoError(t, p.AddTx(ctx, *originalTx, ip))
+
+	// the same tx is already known
+	err = p.AddTx(ctx, *originalTx, ip)
+	require.ErrorIs(t, err, pool.ErrAlreadyKnown)
+
+	// the gas price is not increased by the price bump
+	underpricedTx := signTx(0, new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(109)), big.NewInt(100)))
+	err = p.AddTx(ctx, *underpricedTx, ip)
+	require.ErrorIs(t, err, pool.ErrReplaceUnderpriced)
+
+	// the storage checks the price bump again when replacing the txs
+	_, _, err = s.AddOrReplaceTx(ctx, *pool.NewTransaction(*underpricedTx, ip, false), 0, big.NewInt(0).Sub(gasPrice, big.NewInt(1)), pool.ErrReplacedTransaction.Error(), pool.TxEviction{})
+	require.ErrorIs(t, err, pool.ErrReplaceUnderpriced)
+
+	replacementTx := signTx(0, new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(110)), big.NewInt(100)))
+	require.NoError(t, p.AddTx(ctx, *replacementTx, ip))
+
+	originalPoolTx, err := p.GetTransactionByHash(ctx, originalTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusFailed, originalPoolTx.Status)
+	require.NotNil(t, originalPoolTx.FailedReason)
+	assert.Equal(t, pool.ErrReplacedTransaction.Error(), *originalPoolTx.FailedReason)
+
+	replacementPoolTx, err := p.GetTransactionByHash(ctx, replacementTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusPending, replacementPoolTx.Status)
+
+	_, err = p.GetTransactionByHash(ctx, underpricedTx.Hash())
+	require.ErrorIs(t, err, pool.ErrNotFound)
+
+	pendingTxs, err := p.GetNonWIPExecutableTxs(ctx)
+	require.NoError(t, err)
+	require.Len(t, pendingTxs, 1)
+	assert.Equal(t, replacementTx.Hash(), pendingTxs[0].Hash())
+
+	// the replaced tx can't be set as WIP, so the sequencer deletes it from the worker
+	err = p.UpdateTxWIPStatus(ctx, originalTx.Hash(), true)
+	require.ErrorIs(t, err, pool.ErrNotFound)
+
+	// the WIP tx loaded in the worker is replaced, the sequencer replaces it in the worker
+	require.NoError(t, p.UpdateTxWIPStatus(ctx, replacementTx.Hash(), true))
+	wipReplacementTx := signTx(0, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+	require.NoError(t, p.AddTx(ctx, *wipReplacementTx, ip))
+
+	replacementPoolTx, err = p.GetTransactionByHash(ctx, replacementTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusFailed, replacementPoolTx.Status)
+	require.NotNil(t, replacementPoolTx.FailedReason)
+	assert.Equal(t, pool.ErrReplacedTransaction.Error(), *replacementPoolTx.FailedReason)
+
+	pendingTxs, err = p.GetNonWIPExecutableTxs(ctx)
+	require.NoError(t, err)
+	require.Len(t, pendingTxs, 1)
+	assert.Equal(t, wipReplacementTx.Hash(), pendingTxs[0].Hash())
+
+	// the replaced tx can't be set in the WIP L2 block
+	err = p.MarkTxInWIPL2Block(ctx, replacementTx.Hash())
+	require.ErrorIs(t, err, pool.ErrNotFound)
+
+	// the tx processed in the WIP L2 block is not replaced
+	require.NoError(t, p.UpdateTxWIPStatus(ctx, wipReplacementTx.Hash(), true))
+	require.NoError(t, p.MarkTxInWIPL2Block(ctx, wipReplacementTx.Hash()))
+	l2BlockReplacementTx := signTx(0, new(big.Int).Mul(gasPrice, big.NewInt(3)))
+	err = p.AddTx(ctx, *l2BlockReplacementTx, ip)
+	require.ErrorIs(t, err, pool.ErrReplaceWIP)
+
+	wipReplacementPoolTx, err := p.GetTransactionByHash(ctx, wipReplacementTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusPending, wipReplacementPoolTx.Status)
+	assert.True(t, wipReplacementPoolTx.IsWIP)
+}
+
+func Test_AddTx_PromoteQueuedTxs(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	p := setupPool(t, cfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signTx := func(nonce uint64) *ethTypes.Transaction {
+		tx := ethTypes.NewTransaction(nonce, common.Address{}, big.NewInt(0), gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return signedTx
+	}
+	executableNonces := func() []uint64 {
+		txs, err := p.GetNonWIPExecutableTxs(ctx)
+		require.NoError(t, err)
+		nonces := []uint64{}
+		for _, tx := range txs {
+			nonces = append(nonces, tx.Nonce())
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		return nonces
+	}
+
+	// the txs after a nonce gap are queued
+	require.NoError(t, p.AddTx(ctx, *signTx(1), ip))
+	require.NoError(t, p.AddTx(ctx, *signTx(3), ip))
+	assert.Empty(t, executableNonces())
+
+	// closing the first gap promotes the queued txs up to the next gap
+	require.NoError(t, p.AddTx(ctx, *signTx(0), ip))
+	assert.Equal(t, []uint64{0, 1}, executableNonces())
+
+	// the pool status and content are split by the same executability
+	status, err := p.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxPoolStatus{Pending: 2, Queued: 1}, status)
+	content, err := p.GetContentFrom(ctx, common.HexToAddress(senderAddress))
+	require.NoError(t, err)
+	require.Len(t, content.Pending[common.HexToAddress(senderAddress)], 2)
+	require.Len(t, content.Queued[common.HexToAddress(senderAddress)], 1)
+	assert.Equal(t, uint64(3), content.Queued[common.HexToAddress(senderAddress)][0].Nonce())
+
+	// closing the last gap promotes the rest of the queued txs
+	require.NoError(t, p.AddTx(ctx, *signTx(2), ip))
+	assert.Equal(t, []uint64{0, 1, 2, 3}, executableNonces())
+
+	// the txs selected by the sequencer keep the next nonce of the sender
+	for _, nonce := range []uint64{0, 1, 2, 3} {
+		require.NoError(t, p.UpdateTxStatus(ctx, signTx(nonce).Hash(), pool.TxStatusSelected, false, nil))
+	}
+	require.NoError(t, p.AddTx(ctx, *signTx(4), ip))
+	assert.Equal(t, []uint64{4}, executableNonces())
+
+	// the next nonce is only deleted once the sender has no pending txs
+	require.NoError(t, p.DeleteIdleSenderNonces(ctx))
+	var nextNonce uint64
+	err = poolSqlDB.QueryRow(ctx, "SELECT next_nonce FROM pool.sender_nonce WHERE from_address = $1", common.HexToAddress(senderAddress).String()).Scan(&nextNonce)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), nextNonce)
+	require.NoError(t, p.UpdateTxStatus(ctx, signTx(4).Hash(), pool.TxStatusSelected, false, nil))
+	require.NoError(t, p.DeleteIdleSenderNonces(ctx))
+	err = poolSqlDB.QueryRow(ctx, "SELECT next_nonce FROM pool.sender_nonce WHERE from_address = $1", common.HexToAddress(senderAddress).String()).Scan(&nextNonce)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func Test_AddPrivateTx(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	privateCfg := cfg
+	privateCfg.PrivateTxMaxBlocks = 10
+	p := setupPool(t, privateCfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signTx := func(nonce uint64) *ethTypes.Transaction {
+		tx := ethTypes.NewTransaction(nonce, common.Address{}, big.NewInt(0), gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return signedTx
+	}
+
+	// the max L2 block number must be after the last L2 block
+	err = p.AddPrivateTx(ctx, *signTx(0), ip, state.Ptr(uint64(0)))
+	require.ErrorIs(t, err, pool.ErrMaxBlockNumberReached)
+
+	// the max L2 block number is limited to PrivateTxMaxBlocks after the last L2 block
+	since := time.Now()
+	require.NoError(t, p.AddPrivateTx(ctx, *signTx(0), ip, state.Ptr(uint64(100))))
+
+	// the private tx is not included in the public pending nonce
+	pendingNonce, err := p.GetNonce(ctx, common.HexToAddress(senderAddress))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), pendingNonce)
+
+	require.NoError(t, p.AddTx(ctx, *signTx(1), ip))
+
+	// the private tx is hidden from the pool introspection and the pending tx filters,
+	// so the public tx after it is seen as queued
+	content, err := p.GetContent(ctx, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, content.Pending[common.HexToAddress(senderAddress)])
+	require.Len(t, content.Queued[common.HexToAddress(senderAddress)], 1)
+	assert.Equal(t, signTx(1).Hash(), content.Queued[common.HexToAddress(senderAddress)][0].Hash())
+
+	status, err := p.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxPoolStatus{Pending: 0, Queued: 1}, status)
+
+	hashes, err := p.GetPendingTxHashesSince(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{signTx(1).Hash()}, hashes)
+
+	// the sequencer still loads the private tx with its max L2 block number
+	txs, err := p.GetNonWIPExecutableTxs(ctx)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	for _, tx := range txs {
+		if tx.Hash() == signTx(0).Hash() {
+			assert.True(t, tx.IsPrivate)
+			require.NotNil(t, tx.MaxL2BlockNumber)
+			assert.Equal(t, privateCfg.PrivateTxMaxBlocks, *tx.MaxL2BlockNumber)
+		} else {
+			assert.False(t, tx.IsPrivate)
+			assert.Nil(t, tx.MaxL2BlockNumber)
+		}
+	}
+
+	// the private tx expires once its max L2 block number is stored without it
+	expiredTxs, err := p.ExpirePrivateTxs(ctx, privateCfg.PrivateTxMaxBlocks-1)
+	require.NoError(t, err)
+	assert.Empty(t, expiredTxs)
+	expiredTxs, err = p.ExpirePrivateTxs(ctx, privateCfg.PrivateTxMaxBlocks)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{signTx(0).Hash()}, expiredTxs)
+
+	tx, err := p.GetTransactionByHash(ctx, signTx(0).Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusFailed, tx.Status)
+	require.NotNil(t, tx.FailedReason)
+	assert.Equal(t, pool.ErrExpiredPrivateTransaction.Error(), *tx.FailedReason)
+
+	// the private txs are rejected when PrivateTxMaxBlocks is 0
+	p = setupPool(t, cfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+	err = p.AddPrivateTx(ctx, *signTx(2), ip, nil)
+	require.ErrorIs(t, err, pool.ErrPrivateTxsDisabled)
+}
+
+func Test_AddBundle(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	bundleCfg := cfg
+	bundleCfg.MaxBundleTxs = 2
+	bundleCfg.PrivateTxMaxBlocks = 10
+	p := setupPool(t, bundleCfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signTxWithGasPrice := func(nonce uint64, gasPrice *big.Int) ethTypes.Transaction {
+		tx := ethTypes.NewTransaction(nonce, common.Address{}, big.NewInt(0), gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		return *signedTx
+	}
+	signTx := func(nonce uint64) ethTypes.Transaction {
+		return signTxWithGasPrice(nonce, gasPrice)
+	}
+
+	// the bundle size is limited to MaxBundleTxs
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{}, ip)
+	require.ErrorIs(t, err, pool.ErrEmptyBundle)
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{signTx(0), signTx(1), signTx(2)}, ip)
+	require.ErrorIs(t, err, pool.ErrBundleTooLarge)
+
+	// the same tx can't be included twice in a bundle
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{signTx(0), signTx(0)}, ip)
+	require.ErrorIs(t, err, pool.ErrAlreadyKnown)
+
+	// the first tx of the sender in the bundle must have its pending nonce
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{signTx(1)}, ip)
+	require.ErrorIs(t, err, pool.ErrBundleNonceNotPending)
+
+	bundleTxs := []ethTypes.Transaction{signTx(0), signTx(1)}
+	bundleHash, err := p.AddBundle(ctx, bundleTxs, ip)
+	require.NoError(t, err)
+	assert.Equal(t, pool.BundleHash(bundleTxs), bundleHash)
+
+	// the bundle txs are not loaded as regular executable txs
+	txs, err := p.GetNonWIPExecutableTxs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+
+	bundles, err := p.GetNonWIPBundles(ctx)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.Equal(t, bundleHash, bundles[0].Hash)
+	require.Len(t, bundles[0].Txs, 2)
+	for i, tx := range bundles[0].Txs {
+		assert.Equal(t, bundleTxs[i].Hash(), tx.Hash())
+		require.NotNil(t, tx.BundleHash)
+		assert.Equal(t, bundleHash, *tx.BundleHash)
+		assert.Equal(t, uint64(i), tx.BundleIndex)
+	}
+
+	// the next bundle must follow the pending txs of the sender
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{signTx(3)}, ip)
+	require.ErrorIs(t, err, pool.ErrBundleNonceNotPending)
+
+	// the bundle txs are never replaced, so a tx with the nonce of a pending bundle tx is rejected
+	collidingTx := signTxWithGasPrice(1, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+	err = p.AddTx(ctx, collidingTx, ip)
+	require.ErrorIs(t, err, pool.ErrBundleNonceCollision)
+	_, _, err = s.AddOrReplaceTx(ctx, *pool.NewTransaction(collidingTx, ip, false), 0, new(big.Int).Mul(gasPrice, big.NewInt(2)), pool.ErrReplacedTransaction.Error(), pool.TxEviction{})
+	require.ErrorIs(t, err, pool.ErrBundleNonceCollision)
+	_, err = p.GetTransactionByHash(ctx, collidingTx.Hash())
+	require.ErrorIs(t, err, pool.ErrNotFound)
+
+	// the next bundle must follow the pending private txs of the sender too
+	require.NoError(t, p.AddPrivateTx(ctx, signTx(2), ip, nil))
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{signTx(2)}, ip)
+	require.ErrorIs(t, err, pool.ErrBundleNonceNotPending)
+
+	// the storage checks the collisions again when the bundle is added
+	_, err = s.AddBundle(ctx, []pool.Transaction{*pool.NewTransaction(signTxWithGasPrice(2, new(big.Int).Mul(gasPrice, big.NewInt(2))), ip, false)}, map[common.Address]uint64{auth.From: 0}, pool.TxEviction{})
+	require.ErrorIs(t, err, pool.ErrBundleNonceNotPending)
+
+	nextBundleTxs := []ethTypes.Transaction{signTx(3)}
+	_, err = p.AddBundle(ctx, nextBundleTxs, ip)
+	require.NoError(t, err)
+
+	// the bundle is not loaded again while its txs are WIP
+	require.NoError(t, p.UpdateTxWIPStatus(ctx, bundleTxs[0].Hash(), true))
+	bundles, err = p.GetNonWIPBundles(ctx)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.Equal(t, pool.BundleHash(nextBundleTxs), bundles[0].Hash)
+
+	// the bundles are rejected when MaxBundleTxs is 0
+	p = setupPool(t, cfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+	_, err = p.AddBundle(ctx, []ethTypes.Transaction{signTx(4)}, ip)
+	require.ErrorIs(t, err, pool.ErrBundlesDisabled)
+}
+
 func Test_AddTx_IPValidation(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -2078,7 +2753,7 @@ func Test_PolicyAcl(t *testing.T) {
 	}
 
 	// change policies to allow by acl
-	ctag, err := poolSqlDB.Exec(ctx, "UPDATE pool.policy SET allow = true")
+	ctag, err := poolSqlDB.Exec(ctx, "UPDATE pool.policy SET allow = true WHERE name IN ('send_tx', 'deploy')")
 	require.NoError(t, err)
 	require.Equal(t, int64(2), ctag.RowsAffected())
 
@@ -2098,3 +2773,178 @@ func Test_PolicyAcl(t *testing.T) {
 		}
 	}
 }
+
+func Test_PolicyRules(t *testing.T) {
+	initOrResetDB(t)
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	ctx := context.Background()
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	p := pool.NewPool(cfg, bc, s, nil, uint64(1), nil)
+
+	contract := common.HexToAddress("0x1")
+	sender := common.HexToAddress("0x2")
+	transfer := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	approve := []byte{0x09, 0x5e, 0xa7, 0xb3}
+
+	// the call and value policies start out as deny lists without addresses
+	for _, selector := range [][]byte{nil, transfer} {
+		allow, err := p.CheckCallPolicy(ctx, contract, selector)
+		require.NoError(t, err)
+		assert.True(t, allow)
+	}
+	allow, err := p.CheckValuePolicy(ctx, sender, big.NewInt(1000))
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	unlimited := common.HexToAddress("0x4")
+	require.NoError(t, s.AddAcls(ctx, []pool.Acl{
+		{PolicyName: pool.Call, Address: contract, Selector: transfer},
+		{PolicyName: pool.Value, Address: sender, MaxValue: big.NewInt(100)},
+		{PolicyName: pool.Value, Address: unlimited},
+		// out of their time window
+		{PolicyName: pool.Call, Address: contract, Selector: approve, ValidFrom: &future},
+		{PolicyName: pool.SendTx, Address: sender, ValidUntil: &past},
+	}))
+
+	acls, err := s.ListAcl(ctx, pool.Call, nil)
+	require.NoError(t, err)
+	require.Len(t, acls, 2)
+	assert.Equal(t, approve, acls[0].Selector)
+	require.NotNil(t, acls[0].ValidFrom)
+	assert.Equal(t, future.Unix(), acls[0].ValidFrom.Unix())
+	assert.Equal(t, transfer, acls[1].Selector)
+
+	acls, err = s.ListAcl(ctx, pool.Value, []common.Address{sender})
+	require.NoError(t, err)
+	require.Len(t, acls, 1)
+	assert.Equal(t, big.NewInt(100), acls[0].MaxValue)
+
+	// the address listed without max value is denied to transfer value
+	allow, err = p.CheckValuePolicy(ctx, unlimited, big.NewInt(0))
+	require.NoError(t, err)
+	assert.True(t, allow)
+	allow, err = p.CheckValuePolicy(ctx, unlimited, big.NewInt(1))
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// only the listed function of the contract is denied
+	allow, err = p.CheckCallPolicy(ctx, contract, transfer)
+	require.NoError(t, err)
+	assert.False(t, allow)
+	allow, err = p.CheckCallPolicy(ctx, contract, approve)
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	// the sender is limited to its max value
+	allow, err = p.CheckValuePolicy(ctx, sender, big.NewInt(100))
+	require.NoError(t, err)
+	assert.True(t, allow)
+	allow, err = p.CheckValuePolicy(ctx, sender, big.NewInt(101))
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// the expired exception doesn't deny the sender
+	allow, err = p.CheckPolicy(ctx, pool.SendTx, sender)
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	// change the call and value policies to allow by acl
+	ctag, err := poolSqlDB.Exec(ctx, "UPDATE pool.policy SET allow = true WHERE name IN ('call', 'value')")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), ctag.RowsAffected())
+
+	// only the listed function of the contract is allowed, the exception not yet valid is ignored
+	for _, tc := range []struct {
+		selector []byte
+		expected bool
+	}{{nil, false}, {transfer, true}, {approve, false}} {
+		allow, err = p.CheckCallPolicy(ctx, contract, tc.selector)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, allow)
+	}
+
+	// a whole contract can be allowed
+	require.NoError(t, s.AddAcls(ctx, []pool.Acl{{PolicyName: pool.Call, Address: contract}}))
+	allow, err = p.CheckCallPolicy(ctx, contract, approve)
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	// the addresses not listed may only send txs without value
+	other := common.HexToAddress("0x3")
+	allow, err = p.CheckValuePolicy(ctx, other, big.NewInt(0))
+	require.NoError(t, err)
+	assert.True(t, allow)
+	allow, err = p.CheckValuePolicy(ctx, other, big.NewInt(1))
+	require.NoError(t, err)
+	assert.False(t, allow)
+	allow, err = p.CheckValuePolicy(ctx, sender, big.NewInt(100))
+	require.NoError(t, err)
+	assert.True(t, allow)
+	allow, err = p.CheckValuePolicy(ctx, sender, big.NewInt(101))
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// the address listed without max value is allowed to transfer any value
+	allow, err = p.CheckValuePolicy(ctx, unlimited, big.NewInt(1000))
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	// removing the selector exception keeps the whole contract one
+	require.NoError(t, s.RemoveAcls(ctx, []pool.Acl{{PolicyName: pool.Call, Address: contract, Selector: transfer}}))
+	acls, err = s.ListAcl(ctx, pool.Call, []common.Address{contract})
+	require.NoError(t, err)
+	require.Len(t, acls, 2)
+	assert.Empty(t, acls[0].Selector)
+	assert.Equal(t, approve, acls[1].Selector)
+}
+
+func Test_NewPendingTxEvents(t *testing.T) {
+	ctx := context.Background()
+
+	data := prepareToExecuteTx(t, chainID.Uint64())
+	defer data.stateSqlDB.Close() //nolint:gosec,errcheck
+	defer data.poolSqlDB.Close()  //nolint:gosec,errcheck
+
+	// a second node sharing the pool DB listening the pending tx notifications
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+	listenerCfg := cfg
+	listenerCfg.ListenPendingTxNotifications = true
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	listener := setupPool(t, listenerCfg, bc, s, data.st, chainID.Uint64(), ctx, event.NewEventLog(event.Config{}, eventStorage))
+
+	localEvents := make(chan pool.NewPendingTxEvent, 1)
+	data.pool.RegisterNewPendingTxEventHandler(func(e pool.NewPendingTxEvent) { localEvents <- e })
+	listenedEvents := make(chan pool.NewPendingTxEvent, 1)
+	listener.RegisterNewPendingTxEventHandler(func(e pool.NewPendingTxEvent) { listenedEvents <- e })
+	listener.StartToMonitorNewPendingTxs()
+	// wait for the listener to be connected
+	time.Sleep(time.Second)
+
+	tx := ethTypes.NewTransaction(0, common.HexToAddress(senderAddress), big.NewInt(0), gasLimit, gasPrice, nil)
+	auth, err := operations.GetAuth(senderPrivateKey, chainID.Uint64())
+	require.NoError(t, err)
+	signedTx, err := auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+
+	err = data.pool.AddTx(ctx, *signedTx, ip)
+	require.NoError(t, err)
+
+	for _, events := range []chan pool.NewPendingTxEvent{localEvents, listenedEvents} {
+		select {
+		case e := <-events:
+			assert.Equal(t, signedTx.Hash(), e.Tx.Hash())
+			assert.Equal(t, pool.TxStatusPending, e.Tx.Status)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "new pending tx event not received")
+		}
+	}
+}
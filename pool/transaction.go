@@ -6,6 +6,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -35,6 +36,16 @@ type TxStatusUpdateInfo struct {
 	FailedReason *string
 }
 
+// TxEviction represents the limits to evict pending txs to make room for a new tx
+type TxEviction struct {
+	// GlobalQueue is the max number of pending txs, there is no limit if it is 0
+	GlobalQueue uint64
+	// AccountSlots is the number of pending txs of each sender never evicted
+	AccountSlots uint64
+	// Reason is the failed reason of the evicted txs
+	Reason string
+}
+
 // Transaction represents a pool tx
 type Transaction struct {
 	types.Transaction
@@ -46,6 +57,18 @@ type Transaction struct {
 	IsWIP                 bool
 	IP                    string
 	FailedReason          *string
+	// IsPrivate is set for the txs hidden from the pool introspection and the
+	// pending tx notifications, which expire if they are not included in an
+	// L2 block up to MaxL2BlockNumber
+	IsPrivate        bool
+	MaxL2BlockNumber *uint64
+	// BundleHash is set for the txs of a bundle, which are processed by the
+	// sequencer consecutively in BundleIndex order in the same L2 block or not at all
+	BundleHash  *common.Hash
+	BundleIndex uint64
+	// IsExecutable is set for the pending txs whose previous nonces are all
+	// executable or already processed, the rest are queued behind a nonce gap
+	IsExecutable bool
 }
 
 // NewTransaction creates a new transaction
@@ -60,3 +83,21 @@ func NewTransaction(tx types.Transaction, ip string, isWIP bool) *Transaction {
 
 	return &poolTx
 }
+
+// Bundle represents the pool txs that must be processed consecutively in the
+// same L2 block or not at all
+type Bundle struct {
+	Hash       common.Hash
+	Txs        []Transaction
+	ReceivedAt time.Time
+}
+
+// BundleHash returns the hash of a bundle, which is the keccak256 hash of the
+// concatenated hashes of its txs
+func BundleHash(txs []types.Transaction) common.Hash {
+	data := make([]byte, 0, len(txs)*common.HashLength)
+	for _, tx := range txs {
+		data = append(data, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(data)
+}
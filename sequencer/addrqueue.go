@@ -115,6 +115,31 @@ func (a *addrQueue) ExpireTransactions(maxTime time.Duration) ([]*TxTracker, *Tx
 	return txs, prevReadyTx
 }
 
+// ExpirePrivateTransactions removes the private txs whose max L2 block number is not after the provided one
+func (a *addrQueue) ExpirePrivateTransactions(l2BlockNumber uint64) ([]*TxTracker, *TxTracker) {
+	var (
+		txs         []*TxTracker
+		prevReadyTx *TxTracker
+	)
+
+	for _, txTracker := range a.notReadyTxs {
+		if txTracker.MaxL2BlockNumber != nil && *txTracker.MaxL2BlockNumber <= l2BlockNumber {
+			txs = append(txs, txTracker)
+			delete(a.notReadyTxs, txTracker.Nonce)
+			log.Debugf("deleting expired private notReadyTx %s from addrQueue %s", txTracker.HashStr, a.fromStr)
+		}
+	}
+
+	if a.readyTx != nil && a.readyTx.MaxL2BlockNumber != nil && *a.readyTx.MaxL2BlockNumber <= l2BlockNumber {
+		prevReadyTx = a.readyTx
+		txs = append(txs, a.readyTx)
+		a.readyTx = nil
+		log.Debugf("deleting expired private readyTx %s from addrQueue %s", prevReadyTx.HashStr, a.fromStr)
+	}
+
+	return txs, prevReadyTx
+}
+
 // IsEmpty returns true if the addrQueue is empty
 func (a *addrQueue) IsEmpty() bool {
 	return a.readyTx == nil && len(a.notReadyTxs) == 0 && len(a.forcedTxs) == 0 && len(a.pendingTxsToStore) == 0
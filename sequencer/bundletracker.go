@@ -0,0 +1,45 @@
+package sequencer
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BundleTracker is a struct that contains the txs of a bundle, which are processed
+// consecutively in the same L2 block or not at all
+type BundleTracker struct {
+	Hash               common.Hash
+	HashStr            string
+	Txs                []*TxTracker
+	Bytes              uint64
+	ReservedZKCounters state.ZKCounters
+	ReceivedAt         time.Time // To check if it has been in the worker for too long
+	firstNonces        map[string]uint64
+}
+
+// newBundleTracker creates and inits a BundleTracker, its resources are the sum of the ones of its txs
+func newBundleTracker(hash common.Hash, txs []*TxTracker) *BundleTracker {
+	bundle := &BundleTracker{
+		Hash:        hash,
+		HashStr:     hash.String(),
+		Txs:         txs,
+		ReceivedAt:  time.Now(),
+		firstNonces: make(map[string]uint64),
+	}
+	for _, tx := range txs {
+		bundle.Bytes += tx.Bytes
+		bundle.ReservedZKCounters.SumUp(tx.ReservedZKCounters)
+		if _, found := bundle.firstNonces[tx.FromStr]; !found {
+			bundle.firstNonces[tx.FromStr] = tx.Nonce
+		}
+	}
+
+	return bundle
+}
+
+// reservedResources returns the batch resources reserved to process the bundle
+func (b *BundleTracker) reservedResources() state.BatchResources {
+	return state.BatchResources{ZKCounters: b.ReservedZKCounters, Bytes: b.Bytes}
+}
@@ -3,6 +3,7 @@ package sequencer
 import (
 	"github.com/0xPolygonHermez/zkevm-data-streamer/log"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Config represents the configuration of a sequencer
@@ -93,6 +94,23 @@ type FinalizerCfg struct {
 
 	// Metrics is the config for the sequencer metrics
 	Metrics MetricsCfg `mapstructure:"Metrics"`
+
+	// TxOrdering is the config of the order in which the ready txs are processed
+	TxOrdering TxOrderingCfg `mapstructure:"TxOrdering"`
+}
+
+// TxOrderingCfg contains the configuration of the order in which the ready txs are processed
+type TxOrderingCfg struct {
+	// Policy defines the order of the ready txs:
+	// - gasprice: the txs with the highest gas price first
+	// - fifo: the txs received earlier by the pool first
+	// - zkefficiency: the txs paying the highest fee per reserved ZK counters first, weighted by the
+	// counter that takes the largest share of its batch limit
+	Policy string `mapstructure:"Policy" jsonschema:"enum=gasprice,enum=fifo,enum=zkefficiency"`
+
+	// PriorityAddresses are the senders whose txs are processed before the rest, the txs of each lane
+	// are ordered by the policy
+	PriorityAddresses []common.Address `mapstructure:"PriorityAddresses"`
 }
 
 // MetricsCfg contains the sequencer metrics configuration properties
@@ -1,6 +1,10 @@
 package sequencer
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
 
 var (
 	// ErrExpiredTransaction happens when the transaction is expired
@@ -10,8 +14,15 @@ var (
 	// ErrDuplicatedNonce is returned when adding a new tx to the worker and there is an existing tx
 	// with the same nonce and higher gasPrice (in this case we keep the existing tx)
 	ErrDuplicatedNonce = errors.New("duplicated nonce")
-	// ErrReplacedTransaction is returned when an existing tx is replaced by a new tx with the same nonce and higher gasPrice
-	ErrReplacedTransaction = errors.New("replaced transaction")
+	// ErrReplacedTransaction is returned when an existing tx is replaced by a new tx with the same nonce and higher gasPrice,
+	// it is the same failed reason the pool sets for the txs it replaces
+	ErrReplacedTransaction = pool.ErrReplacedTransaction
+	// ErrExpiredPrivateTransaction is returned when a private tx is not included in an L2 block up to its max L2 block number,
+	// it is the same failed reason the pool sets for the private txs it expires
+	ErrExpiredPrivateTransaction = pool.ErrExpiredPrivateTransaction
+	// ErrBundleFailed is the failed reason of the txs of a bundle when any of them fails or the bundle
+	// can't be processed, as the bundle txs are processed consecutively in the same L2 block or not at all
+	ErrBundleFailed = errors.New("bundle failed")
 	// ErrGetBatchByNumber happens when we get an error trying to get a batch by number (GetBatchByNumber)
 	ErrGetBatchByNumber = errors.New("get batch by number error")
 	// ErrUpdateBatchAsChecked happens when we get an error trying to update a batch as checked (UpdateBatchAsChecked)
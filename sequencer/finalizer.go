@@ -303,15 +303,30 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 			f.finalizeWIPL2Block(ctx)
 		}
 
-		tx, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources)
-
-		// If we have txs pending to process but none of them fits into the wip batch, we close the wip batch and open a new one
-		if err == ErrNoFittingTransaction {
-			f.finalizeWIPBatch(ctx, state.NoTxFitsClosingReason)
-			continue
+		// The bundles are processed before the single txs, in the order they were added to the worker
+		var tx *TxTracker
+		bundle, bundleErr := f.workerIntf.GetBestFittingBundle(f.wipBatch.imRemainingResources)
+		if bundle == nil {
+			var err error
+			tx, err = f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources)
+
+			// If we have txs or bundles pending to process but none of them fits into the wip batch, we close the wip batch and open a new one
+			if err == ErrNoFittingTransaction || (err == ErrTransactionsListEmpty && bundleErr == ErrNoFittingTransaction) {
+				f.finalizeWIPBatch(ctx, state.NoTxFitsClosingReason)
+				continue
+			}
 		}
 
-		if tx != nil {
+		if bundle != nil {
+			showNotFoundTxLog = true
+
+			err := f.processBundle(ctx, bundle)
+			if err == ErrBatchResourceOverFlow {
+				log.Infof("skipping bundle %s due to a batch resource overflow", bundle.HashStr)
+			} else if err != nil {
+				log.Errorf("failed to process bundle %s, error: %v", bundle.HashStr, err)
+			}
+		} else if tx != nil {
 			showNotFoundTxLog = true
 
 			firstTxProcess := true
@@ -509,6 +524,167 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 	return nil, nil
 }
 
+// processBundle processes the txs of a bundle consecutively in the WIP L2 block. The bundle txs are
+// only added to the L2 block if all of them are executed successfully and the bundle reserved resources
+// fit in the remaining batch resources, otherwise the WIP state root is kept as it was before processing
+// the bundle. The bundle txs are processed with their full gas price, as reprocessing a tx to adjust its
+// effective gas price could change the execution of the next txs of the bundle.
+func (f *finalizer) processBundle(ctx context.Context, bundle *BundleTracker) error {
+	start := time.Now()
+
+	log.Infof("processing bundle %s with %d txs, batchNumber: %d, l2Block: [%d], oldStateRoot: %s, L1InfoRootIndex: %d",
+		bundle.HashStr, len(bundle.Txs), f.wipBatch.batchNumber, f.wipL2Block.trackingNum, f.wipBatch.imStateRoot, f.wipL2Block.l1InfoTreeExitRoot.L1InfoTreeIndex)
+
+	l1GasPrice, l2GasPrice := f.poolIntf.GetL1AndL2GasPrice()
+	batchL2Data := []byte{}
+	for _, tx := range bundle.Txs {
+		tx.L1GasPrice, tx.L2GasPrice = l1GasPrice, l2GasPrice
+		tx.EffectiveGasPrice.Set(tx.GasPrice)
+		tx.EGPPercentage = state.MaxEffectivePercentage
+		tx.IsLastExecution = true
+
+		batchL2Data = append(batchL2Data, tx.RawTx...)
+		batchL2Data = append(batchL2Data, tx.EGPPercentage)
+	}
+
+	batchRequest := state.ProcessRequest{
+		BatchNumber:               f.wipBatch.batchNumber,
+		OldStateRoot:              f.wipBatch.imStateRoot,
+		Coinbase:                  f.wipBatch.coinbase,
+		L1InfoRoot_V2:             state.GetMockL1InfoRoot(),
+		TimestampLimit_V2:         f.wipL2Block.timestamp,
+		Caller:                    stateMetrics.DiscardCallerLabel,
+		ForkID:                    f.stateIntf.GetForkIDByBatchNumber(f.wipBatch.batchNumber),
+		Transactions:              batchL2Data,
+		SkipFirstChangeL2Block_V2: true,
+		SkipWriteBlockInfoRoot_V2: true,
+		SkipVerifyL1InfoRoot_V2:   true,
+		L1InfoTreeData_V2:         map[uint32]state.L1DataV2{},
+		ExecutionMode:             executor.ExecutionMode0,
+	}
+
+	executionStart := time.Now()
+	batchResponse, err := f.stateIntf.ProcessBatchV2(ctx, batchRequest, false)
+	executionTime := time.Since(executionStart)
+	f.wipL2Block.metrics.transactionsTimes.executor += executionTime
+
+	if err != nil && (errors.Is(err, runtime.ErrExecutorDBError) || errors.Is(err, runtime.ErrInvalidTxChangeL2BlockMinTimestamp)) {
+		log.Errorf("failed to process bundle %s, error: %v", bundle.HashStr, err)
+		return err
+	} else if err == nil && !batchResponse.IsRomLevelError && len(batchResponse.BlockResponses) == 0 {
+		err = fmt.Errorf("executor returned no errors and no responses for bundle %s", bundle.HashStr)
+		f.Halt(ctx, err, false)
+	} else if err != nil {
+		log.Errorf("error received from executor, error: %v", err)
+		f.failBundle(ctx, bundle, pool.TxStatusInvalid, err.Error())
+		return err
+	}
+
+	// Update metrics
+	f.wipL2Block.metrics.processedTxsCount += int64(len(bundle.Txs))
+
+	// If any tx fails the whole bundle fails, so the WIP state root is not updated
+	var txResponses []*state.ProcessTransactionResponse
+	if len(batchResponse.BlockResponses) > 0 {
+		txResponses = batchResponse.BlockResponses[0].TransactionResponses
+	}
+	if len(txResponses) != len(bundle.Txs) {
+		f.failBundle(ctx, bundle, pool.TxStatusFailed, fmt.Sprintf("executor returned %d responses for %d txs", len(txResponses), len(bundle.Txs)))
+		return ErrBundleFailed
+	}
+	for i, txResponse := range txResponses {
+		if txResponse.RomError != nil {
+			log.Infof("rom error in tx %s of bundle %s, error: %v", bundle.Txs[i].HashStr, bundle.HashStr, txResponse.RomError)
+			status := pool.TxStatusFailed
+			if executor.IsROMOutOfCountersError(executor.RomErrorCode(txResponse.RomError)) {
+				status = pool.TxStatusInvalid
+			}
+			f.failBundle(ctx, bundle, status, fmt.Sprintf("tx %s: %v", bundle.Txs[i].HashStr, txResponse.RomError))
+			return ErrBundleFailed
+		}
+	}
+
+	// Check if reserved resources of the bundle fits in the remaining batch resources
+	fits, overflowResource := f.wipBatch.imRemainingResources.Fits(state.BatchResources{ZKCounters: batchResponse.ReservedZkCounters, Bytes: bundle.Bytes})
+	if !fits {
+		log.Infof("current bundle %s reserved resources exceeds the remaining batch resources, overflow resource: %s, updating metadata for bundle in worker and continuing. Batch counters: %s, bundle reserved counters: %s",
+			bundle.HashStr, overflowResource, f.logZKCounters(f.wipBatch.imRemainingResources.ZKCounters), f.logZKCounters(batchResponse.ReservedZkCounters))
+		if !f.batchConstraints.IsWithinConstraints(batchResponse.ReservedZkCounters) {
+			log.Infof("current bundle %s reserved resources exceeds the max limit for batch resources (node OOC), setting bundle txs as invalid in the pool", bundle.HashStr)
+
+			f.LogEvent(ctx, event.Level_Info, event.EventID_NodeOOC,
+				fmt.Sprintf("bundle %s exceeds node max limit batch resources (node OOC)", bundle.HashStr), nil)
+
+			f.failBundle(ctx, bundle, pool.TxStatusInvalid, "node OOC")
+			return ErrBatchResourceOverFlow
+		}
+
+		f.workerIntf.UpdateBundleZKCounters(bundle.Hash, batchResponse.ReservedZkCounters)
+		return ErrBatchResourceOverFlow
+	}
+
+	// Subtract the used resources from the batch
+	subOverflow, overflowResource := f.wipBatch.imRemainingResources.Sub(state.BatchResources{ZKCounters: batchResponse.UsedZkCounters, Bytes: bundle.Bytes})
+	if subOverflow { // Sanity check, this cannot happen as reservedZKCounters should be >= that usedZKCounters
+		sLog := fmt.Sprintf("bundle %s used resources exceeds the remaining batch resources, overflow resource: %s, updating metadata for bundle in worker and continuing. Batch counters: %s, bundle used counters: %s",
+			bundle.HashStr, overflowResource, f.logZKCounters(f.wipBatch.imRemainingResources.ZKCounters), f.logZKCounters(batchResponse.UsedZkCounters))
+
+		log.Errorf(sLog)
+
+		f.LogEvent(ctx, event.Level_Error, event.EventID_UsedZKCountersOverflow, sLog, nil)
+
+		f.workerIntf.UpdateBundleZKCounters(bundle.Hash, batchResponse.ReservedZkCounters)
+		return ErrBatchResourceOverFlow
+	}
+
+	f.workerIntf.DeleteBundle(bundle.Hash)
+
+	egpEnabled := f.effectiveGasPrice.IsEnabled()
+	for i, tx := range bundle.Txs {
+		txResponse := txResponses[i]
+
+		// Save Enabled, GasPriceOC, BalanceOC and final effective gas price for later logging
+		tx.EGPLog.Enabled = egpEnabled
+		tx.EGPLog.GasPriceOC = txResponse.HasGaspriceOpcode
+		tx.EGPLog.BalanceOC = txResponse.HasBalanceOpcode
+		tx.EGPLog.ValueFinal.Set(tx.EffectiveGasPrice)
+		tx.EGPLog.Percentage = tx.EGPPercentage
+
+		f.wipL2Block.addTx(tx)
+
+		f.wipBatch.countOfTxs++
+
+		f.updateWorkerAfterSuccessfulProcessing(ctx, tx.Hash, tx.From, false, batchResponse)
+
+		// Update metrics
+		f.wipL2Block.metrics.gas += txResponse.GasUsed
+	}
+
+	// Update imStateRoot
+	oldStateRoot := f.wipBatch.imStateRoot
+	f.wipBatch.imStateRoot = batchResponse.NewStateRoot
+
+	log.Infof("processed bundle %s, batchNumber: %d, l2Block: [%d], newStateRoot: %s, oldStateRoot: %s, time: {process: %v, executor: %v}, used counters: %s, reserved counters: %s",
+		bundle.HashStr, batchRequest.BatchNumber, f.wipL2Block.trackingNum, batchResponse.NewStateRoot.String(), oldStateRoot.String(),
+		time.Since(start), executionTime, f.logZKCounters(batchResponse.UsedZkCounters), f.logZKCounters(batchResponse.ReservedZkCounters))
+
+	return nil
+}
+
+// failBundle deletes a bundle from the worker and sets its txs with the provided status in the pool,
+// the failed reason is the same for all of them as they are not included if any of them fails
+func (f *finalizer) failBundle(ctx context.Context, bundle *BundleTracker, status pool.TxStatus, reason string) {
+	f.workerIntf.DeleteBundle(bundle.Hash)
+
+	failedReason := fmt.Sprintf("%s: %s", ErrBundleFailed.Error(), reason)
+	for _, tx := range bundle.Txs {
+		err := f.poolIntf.UpdateTxStatus(ctx, tx.Hash, status, false, &failedReason)
+		if err != nil {
+			log.Errorf("failed to update status to %s in the pool for tx %s of bundle %s, error: %v", status, tx.HashStr, bundle.HashStr, err)
+		}
+	}
+}
+
 // handleProcessTransactionResponse handles the response of transaction processing.
 func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *TxTracker, result *state.ProcessBatchResponse, oldStateRoot common.Hash) (errWg *sync.WaitGroup, err error) {
 	txResponse := result.BlockResponses[0].TransactionResponses[0]
@@ -622,6 +798,13 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 
 	f.wipL2Block.addTx(tx)
 
+	// The tx can't be replaced in the pool once it is in the WIP L2 block. If it has been replaced while it was
+	// processed, it is set as selected anyway when the L2 block is stored and the replacement tx fails in the worker
+	err = f.poolIntf.MarkTxInWIPL2Block(ctx, tx.Hash)
+	if err != nil {
+		log.Warnf("failed to mark tx %s in the WIP L2 block in the pool, error: %v", tx.HashStr, err)
+	}
+
 	f.wipBatch.countOfTxs++
 
 	f.updateWorkerAfterSuccessfulProcessing(ctx, tx.Hash, tx.From, false, result)
@@ -3,6 +3,7 @@ package sequencer
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -1770,6 +1772,113 @@ func TestFinalizer_updateWorkerAfterSuccessfulProcessing(t *testing.T) {
 	}
 }
 
+func TestFinalizer_processBundle(t *testing.T) {
+	bundleHash := common.HexToHash("0xb0")
+	tx1Hash, tx2Hash := common.HexToHash("0x11"), common.HexToHash("0x12")
+	resultStateRoot := common.HexToHash("0x03")
+
+	testCases := []struct {
+		name                   string
+		txResponses            []*state.ProcessTransactionResponse
+		reservedZKCounters     state.ZKCounters
+		remainingResources     *state.BatchResources
+		expectedErr            error
+		expectedFailReason     string
+		expectedBundleTxs      int
+		expectedUpdateCounters bool
+	}{
+		{
+			name:               "Successful bundle",
+			txResponses:        []*state.ProcessTransactionResponse{{TxHash: tx1Hash, GasUsed: 21000}, {TxHash: tx2Hash, GasUsed: 21000}},
+			reservedZKCounters: state.ZKCounters{GasUsed: 42000},
+			expectedBundleTxs:  2,
+		},
+		{
+			name:               "Bundle rolled back when a tx fails",
+			txResponses:        []*state.ProcessTransactionResponse{{TxHash: tx1Hash, GasUsed: 21000}, {TxHash: tx2Hash, RomError: runtime.ErrExecutionReverted}},
+			reservedZKCounters: state.ZKCounters{GasUsed: 42000},
+			expectedErr:        ErrBundleFailed,
+			expectedFailReason: fmt.Sprintf("%s: tx %s: %s", ErrBundleFailed, tx2Hash.String(), runtime.ErrExecutionReverted),
+		},
+		{
+			name:                   "Bundle rolled back when it doesn't fit in the remaining batch resources",
+			txResponses:            []*state.ProcessTransactionResponse{{TxHash: tx1Hash, GasUsed: 21000}, {TxHash: tx2Hash, GasUsed: 21000}},
+			reservedZKCounters:     state.ZKCounters{GasUsed: 42000},
+			remainingResources:     &state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 21000}, Bytes: 1000},
+			expectedErr:            ErrBatchResourceOverFlow,
+			expectedUpdateCounters: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			f = setupFinalizer(true)
+			f.wipL2Block = &L2Block{}
+			if tc.remainingResources != nil {
+				f.wipBatch.imRemainingResources = *tc.remainingResources
+			}
+			ctx = context.Background()
+
+			txs := []*TxTracker{
+				{Hash: tx1Hash, HashStr: tx1Hash.String(), From: senderAddr, RawTx: []byte{0x01}, Bytes: 2, GasPrice: big.NewInt(1), EffectiveGasPrice: big.NewInt(0), EGPLog: state.EffectiveGasPriceLog{ValueFinal: big.NewInt(0)}},
+				{Hash: tx2Hash, HashStr: tx2Hash.String(), From: senderAddr, RawTx: []byte{0x02}, Bytes: 2, GasPrice: big.NewInt(1), EffectiveGasPrice: big.NewInt(0), EGPLog: state.EffectiveGasPriceLog{ValueFinal: big.NewInt(0)}},
+			}
+			bundle := newBundleTracker(bundleHash, txs)
+
+			poolMock.On("GetL1AndL2GasPrice").Return(uint64(1), uint64(1))
+			stateMock.On("GetForkIDByBatchNumber", f.wipBatch.batchNumber).Return(uint64(state.FORKID_ETROG))
+			// the bundle txs are executed together with the full gas price
+			expectedBatchL2Data := []byte{0x01, state.MaxEffectivePercentage, 0x02, state.MaxEffectivePercentage}
+			batchResponse := &state.ProcessBatchResponse{
+				NewStateRoot:       resultStateRoot,
+				UsedZkCounters:     tc.reservedZKCounters,
+				ReservedZkCounters: tc.reservedZKCounters,
+				BlockResponses:     []*state.ProcessBlockResponse{{TransactionResponses: tc.txResponses}},
+				ReadWriteAddresses: map[common.Address]*state.InfoReadWrite{senderAddr: {Address: senderAddr, Nonce: &nonce2}},
+			}
+			stateMock.On("ProcessBatchV2", ctx, mock.MatchedBy(func(request state.ProcessRequest) bool {
+				return request.OldStateRoot == newHash && assert.ObjectsAreEqual(expectedBatchL2Data, request.Transactions)
+			}), false).Return(batchResponse, nil).Once()
+
+			if tc.expectedErr == nil {
+				workerMock.On("DeleteBundle", bundleHash).Once()
+				for _, tx := range txs {
+					workerMock.On("DeleteTx", tx.Hash, tx.From).Once()
+				}
+				workerMock.On("UpdateAfterSingleSuccessfulTxExecution", senderAddr, batchResponse.ReadWriteAddresses).Return([]*TxTracker{}).Twice()
+			} else if tc.expectedUpdateCounters {
+				workerMock.On("UpdateBundleZKCounters", bundleHash, tc.reservedZKCounters).Once()
+			} else {
+				workerMock.On("DeleteBundle", bundleHash).Once()
+				for _, tx := range txs {
+					poolMock.On("UpdateTxStatus", ctx, tx.Hash, pool.TxStatusFailed, false, &tc.expectedFailReason).Return(nil).Once()
+				}
+			}
+
+			// act
+			err := f.processBundle(ctx, bundle)
+
+			// assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, newHash, f.wipBatch.imStateRoot)
+				assert.Empty(t, f.wipL2Block.transactions)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, resultStateRoot, f.wipBatch.imStateRoot)
+				require.Len(t, f.wipL2Block.transactions, tc.expectedBundleTxs)
+				assert.Equal(t, tx1Hash, f.wipL2Block.transactions[0].Hash)
+				assert.Equal(t, tx2Hash, f.wipL2Block.transactions[1].Hash)
+				assert.Equal(t, 2, f.wipBatch.countOfTxs)
+			}
+			workerMock.AssertExpectations(t)
+			poolMock.AssertExpectations(t)
+			stateMock.AssertExpectations(t)
+		})
+	}
+}
+
 /*func TestFinalizer_reprocessFullBatch(t *testing.T) {
 	successfulResult := &state.ProcessBatchResponse{
 		NewStateRoot: newHash,
@@ -20,10 +20,15 @@ type txPool interface {
 	DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error
 	DeleteTransactionByHash(ctx context.Context, hash common.Hash) error
 	MarkWIPTxsAsPending(ctx context.Context) error
-	GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error)
+	GetNonWIPExecutableTxs(ctx context.Context) ([]pool.Transaction, error)
+	GetNonWIPBundles(ctx context.Context) ([]pool.Bundle, error)
+	ListenNewExecutableTxs(ctx context.Context, onNewExecutableTxs func()) error
+	DeleteIdleSenderNonces(ctx context.Context) error
+	ExpirePrivateTxs(ctx context.Context, l2BlockNumber uint64) ([]common.Hash, error)
 	UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error
 	GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error)
 	UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error
+	MarkTxInWIPL2Block(ctx context.Context, hash common.Hash) error
 	GetGasPrices(ctx context.Context) (pool.GasPrices, error)
 	GetDefaultMinGasPriceAllowed() uint64
 	GetL1AndL2GasPrice() (uint64, uint64)
@@ -92,4 +97,8 @@ type workerInterface interface {
 	NewTxTracker(tx types.Transaction, usedZKcounters state.ZKCounters, reservedZKCouners state.ZKCounters, ip string) (*TxTracker, error)
 	AddForcedTx(txHash common.Hash, addr common.Address)
 	DeleteForcedTx(txHash common.Hash, addr common.Address)
+	ExpirePrivateTransactions(l2BlockNumber uint64) []*TxTracker
+	GetBestFittingBundle(resources state.BatchResources) (*BundleTracker, error)
+	UpdateBundleZKCounters(bundleHash common.Hash, reservedZKCounters state.ZKCounters)
+	DeleteBundle(bundleHash common.Hash)
 }
@@ -434,6 +434,8 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		f.workerIntf.DeletePendingTxToStore(tx.Hash, tx.From)
 	}
 
+	f.expirePrivateTxs(ctx, blockResponse.BlockNumber)
+
 	endStoring := time.Now()
 
 	log.Infof("stored L2 block %d [%d], batch: %d, deltaTimestamp: %d, timestamp: %d, l1InfoTreeIndex: %d, l1InfoTreeIndexChanged: %v, txs: %d/%d, blockHash: %s, infoRoot: %s, time: %v",
@@ -443,6 +445,29 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 	return nil
 }
 
+// expirePrivateTxs sets as failed the private txs in the worker and the pool that were not
+// included in an L2 block up to their max L2 block number, once the L2 block with the provided
+// number is stored. The txs already selected for the next L2 blocks are not expired.
+func (f *finalizer) expirePrivateTxs(ctx context.Context, l2BlockNumber uint64) {
+	failedReason := ErrExpiredPrivateTransaction.Error()
+	for _, txTracker := range f.workerIntf.ExpirePrivateTransactions(l2BlockNumber) {
+		log.Infof("private tx %s expired at L2 block %d", txTracker.HashStr, l2BlockNumber)
+		err := f.poolIntf.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason)
+		if err != nil {
+			log.Errorf("failed to update status of expired private tx %s, error: %v", txTracker.HashStr, err)
+		}
+	}
+
+	expiredTxs, err := f.poolIntf.ExpirePrivateTxs(ctx, l2BlockNumber)
+	if err != nil {
+		log.Errorf("failed to expire private txs in the pool at L2 block %d, error: %v", l2BlockNumber, err)
+		return
+	}
+	for _, txHash := range expiredTxs {
+		log.Infof("private tx %s expired at L2 block %d", txHash.String(), l2BlockNumber)
+	}
+}
+
 // finalizeWIPL2Block closes the wip L2 block and opens a new one
 func (f *finalizer) finalizeWIPL2Block(ctx context.Context) {
 	log.Debugf("finalizing WIP L2 block [%d]", f.wipL2Block.trackingNum)
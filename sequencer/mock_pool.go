@@ -39,6 +39,24 @@ func (_m *PoolMock) DeleteFailedTransactionsOlderThan(ctx context.Context, date
 	return r0
 }
 
+// DeleteIdleSenderNonces provides a mock function with given fields: ctx
+func (_m *PoolMock) DeleteIdleSenderNonces(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdleSenderNonces")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTransactionByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
 	ret := _m.Called(ctx, hash)
@@ -75,6 +93,36 @@ func (_m *PoolMock) DeleteTransactionsByHashes(ctx context.Context, hashes []com
 	return r0
 }
 
+// ExpirePrivateTxs provides a mock function with given fields: ctx, l2BlockNumber
+func (_m *PoolMock) ExpirePrivateTxs(ctx context.Context, l2BlockNumber uint64) ([]common.Hash, error) {
+	ret := _m.Called(ctx, l2BlockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePrivateTxs")
+	}
+
+	var r0 []common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]common.Hash, error)); ok {
+		return rf(ctx, l2BlockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []common.Hash); ok {
+		r0 = rf(ctx, l2BlockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, l2BlockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDefaultMinGasPriceAllowed provides a mock function with given fields:
 func (_m *PoolMock) GetDefaultMinGasPriceAllowed() uint64 {
 	ret := _m.Called()
@@ -179,12 +227,42 @@ func (_m *PoolMock) GetL1AndL2GasPrice() (uint64, uint64) {
 	return r0, r1
 }
 
-// GetNonWIPPendingTxs provides a mock function with given fields: ctx
-func (_m *PoolMock) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error) {
+// GetNonWIPBundles provides a mock function with given fields: ctx
+func (_m *PoolMock) GetNonWIPBundles(ctx context.Context) ([]pool.Bundle, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNonWIPPendingTxs")
+		panic("no return value specified for GetNonWIPBundles")
+	}
+
+	var r0 []pool.Bundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pool.Bundle, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []pool.Bundle); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Bundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNonWIPExecutableTxs provides a mock function with given fields: ctx
+func (_m *PoolMock) GetNonWIPExecutableTxs(ctx context.Context) ([]pool.Transaction, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNonWIPExecutableTxs")
 	}
 
 	var r0 []pool.Transaction
@@ -248,6 +326,42 @@ func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash)
 	return r0, r1, r2
 }
 
+// ListenNewExecutableTxs provides a mock function with given fields: ctx, onNewExecutableTxs
+func (_m *PoolMock) ListenNewExecutableTxs(ctx context.Context, onNewExecutableTxs func()) error {
+	ret := _m.Called(ctx, onNewExecutableTxs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListenNewExecutableTxs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func()) error); ok {
+		r0 = rf(ctx, onNewExecutableTxs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkTxInWIPL2Block provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) MarkTxInWIPL2Block(ctx context.Context, hash common.Hash) error {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkTxInWIPL2Block")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) error); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MarkWIPTxsAsPending provides a mock function with given fields: ctx
 func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -60,6 +60,11 @@ func (_m *WorkerMock) AddTxTracker(ctx context.Context, txTracker *TxTracker) (*
 	return r0, r1
 }
 
+// DeleteBundle provides a mock function with given fields: bundleHash
+func (_m *WorkerMock) DeleteBundle(bundleHash common.Hash) {
+	_m.Called(bundleHash)
+}
+
 // DeleteForcedTx provides a mock function with given fields: txHash, addr
 func (_m *WorkerMock) DeleteForcedTx(txHash common.Hash, addr common.Address) {
 	_m.Called(txHash, addr)
@@ -75,6 +80,56 @@ func (_m *WorkerMock) DeleteTx(txHash common.Hash, from common.Address) {
 	_m.Called(txHash, from)
 }
 
+// ExpirePrivateTransactions provides a mock function with given fields: l2BlockNumber
+func (_m *WorkerMock) ExpirePrivateTransactions(l2BlockNumber uint64) []*TxTracker {
+	ret := _m.Called(l2BlockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePrivateTransactions")
+	}
+
+	var r0 []*TxTracker
+	if rf, ok := ret.Get(0).(func(uint64) []*TxTracker); ok {
+		r0 = rf(l2BlockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TxTracker)
+		}
+	}
+
+	return r0
+}
+
+// GetBestFittingBundle provides a mock function with given fields: resources
+func (_m *WorkerMock) GetBestFittingBundle(resources state.BatchResources) (*BundleTracker, error) {
+	ret := _m.Called(resources)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBestFittingBundle")
+	}
+
+	var r0 *BundleTracker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(state.BatchResources) (*BundleTracker, error)); ok {
+		return rf(resources)
+	}
+	if rf, ok := ret.Get(0).(func(state.BatchResources) *BundleTracker); ok {
+		r0 = rf(resources)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BundleTracker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(state.BatchResources) error); ok {
+		r1 = rf(resources)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBestFittingTx provides a mock function with given fields: resources
 func (_m *WorkerMock) GetBestFittingTx(resources state.BatchResources) (*TxTracker, error) {
 	ret := _m.Called(resources)
@@ -175,6 +230,11 @@ func (_m *WorkerMock) UpdateAfterSingleSuccessfulTxExecution(from common.Address
 	return r0
 }
 
+// UpdateBundleZKCounters provides a mock function with given fields: bundleHash, reservedZKCounters
+func (_m *WorkerMock) UpdateBundleZKCounters(bundleHash common.Hash, reservedZKCounters state.ZKCounters) {
+	_m.Called(bundleHash, reservedZKCounters)
+}
+
 // UpdateTxZKCounters provides a mock function with given fields: txHash, from, usedZKCounters, reservedZKCounters
 func (_m *WorkerMock) UpdateTxZKCounters(txHash common.Hash, from common.Address, usedZKCounters state.ZKCounters, reservedZKCounters state.ZKCounters) {
 	_m.Called(txHash, from, usedZKCounters, reservedZKCounters)
@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -16,6 +17,9 @@ import (
 
 const (
 	datastreamChannelMultiplier = 2
+	// loadPoolTxsRetryInterval is the time the sequencer waits to load the txs
+	// from the pool again, or to listen its notifications, after a failure
+	loadPoolTxsRetryInterval = time.Second
 )
 
 // Sequencer represents a sequencer
@@ -100,7 +104,11 @@ func (s *Sequencer) Start(ctx context.Context) {
 	}
 
 	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
-	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
+	txOrdering, err := NewTxOrdering(s.cfg.Finalizer.TxOrdering, s.batchCfg.Constraints)
+	if err != nil {
+		log.Fatalf("failed to create tx ordering, error: %v", err)
+	}
+	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond, txOrdering)
 	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.address, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
 	go s.finalizer.Start(ctx)
 
@@ -175,6 +183,11 @@ func (s *Sequencer) deleteOldPoolTxs(ctx context.Context) {
 			continue
 		}
 		log.Infof("failed txs deleted from the pool")
+
+		err = s.pool.DeleteIdleSenderNonces(ctx)
+		if err != nil {
+			log.Errorf("failed to delete idle sender nonces from the pool, error: %v", err)
+		}
 	}
 }
 
@@ -192,12 +205,30 @@ func (s *Sequencer) expireOldWorkerTxs(ctx context.Context) {
 	}
 }
 
-// loadFromPool keeps loading transactions from the pool
+// loadFromPool loads the executable transactions and the bundles from the pool each
+// time the pool notifies that new ones are available, the queued ones are loaded
+// once their nonce gap is closed. They are also loaded every LoadPoolTxsCheckInterval
+// in case a notification is lost.
 func (s *Sequencer) loadFromPool(ctx context.Context) {
+	newExecutableTxs := make(chan struct{}, 1)
+	go s.listenNewExecutableTxs(ctx, newExecutableTxs)
+
+	ticker := time.NewTicker(s.cfg.LoadPoolTxsCheckInterval.Duration)
+	defer ticker.Stop()
+
 	for {
-		poolTransactions, err := s.pool.GetNonWIPPendingTxs(ctx)
-		if err != nil && err != pool.ErrNotFound {
-			log.Errorf("error loading txs from pool, error: %v", err)
+		select {
+		case <-newExecutableTxs:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		poolTransactions, err := s.pool.GetNonWIPExecutableTxs(ctx)
+		for err != nil && err != pool.ErrNotFound {
+			log.Errorf("error loading txs from pool, retrying in %v, error: %v", loadPoolTxsRetryInterval, err)
+			time.Sleep(loadPoolTxsRetryInterval)
+			poolTransactions, err = s.pool.GetNonWIPExecutableTxs(ctx)
 		}
 
 		for _, tx := range poolTransactions {
@@ -207,9 +238,39 @@ func (s *Sequencer) loadFromPool(ctx context.Context) {
 			}
 		}
 
-		if len(poolTransactions) == 0 {
-			time.Sleep(s.cfg.LoadPoolTxsCheckInterval.Duration)
+		poolBundles, err := s.pool.GetNonWIPBundles(ctx)
+		for err != nil && err != pool.ErrNotFound {
+			log.Errorf("error loading bundles from pool, retrying in %v, error: %v", loadPoolTxsRetryInterval, err)
+			time.Sleep(loadPoolTxsRetryInterval)
+			poolBundles, err = s.pool.GetNonWIPBundles(ctx)
+		}
+
+		for _, bundle := range poolBundles {
+			err := s.addBundleToWorker(ctx, bundle)
+			if err != nil {
+				log.Errorf("error adding bundle to worker, error: %v", err)
+			}
+		}
+	}
+}
+
+// listenNewExecutableTxs signals the provided channel each time the pool notifies
+// new executable txs, and each time it starts listening as the notifications sent
+// before are lost
+func (s *Sequencer) listenNewExecutableTxs(ctx context.Context, newExecutableTxs chan<- struct{}) {
+	for {
+		err := s.pool.ListenNewExecutableTxs(ctx, func() {
+			select {
+			case newExecutableTxs <- struct{}{}:
+			default:
+				// a load of the pool is already pending
+			}
+		})
+		if ctx.Err() != nil {
+			return
 		}
+		log.Errorf("failed to listen new executable txs from pool, retrying in %v, error: %v", loadPoolTxsRetryInterval, err)
+		time.Sleep(loadPoolTxsRetryInterval)
 	}
 }
 
@@ -218,6 +279,8 @@ func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) erro
 	if err != nil {
 		return err
 	}
+	txTracker.PoolReceivedAt = tx.ReceivedAt
+	txTracker.MaxL2BlockNumber = tx.MaxL2BlockNumber
 	replacedTx, dropReason := s.worker.AddTxTracker(ctx, txTracker)
 	if dropReason != nil {
 		failedReason := dropReason.Error()
@@ -230,8 +293,56 @@ func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) erro
 				log.Warnf("error when setting as failed replacedTx %s, error: %v", replacedTx.HashStr, err)
 			}
 		}
-		return s.pool.UpdateTxWIPStatus(ctx, tx.Hash(), true)
+		err := s.pool.UpdateTxWIPStatus(ctx, tx.Hash(), true)
+		if errors.Is(err, pool.ErrNotFound) {
+			// the tx has been replaced in the pool since it was loaded
+			log.Infof("tx %s is no longer pending in the pool, deleting it from the worker", txTracker.HashStr)
+			s.worker.DeleteTx(txTracker.Hash, txTracker.From)
+			return nil
+		}
+		return err
+	}
+}
+
+// addBundleToWorker adds the txs of a bundle to the worker as a whole, they are set as
+// failed in the pool if the worker drops the bundle
+func (s *Sequencer) addBundleToWorker(ctx context.Context, bundle pool.Bundle) error {
+	txTrackers := make([]*TxTracker, 0, len(bundle.Txs))
+	for _, tx := range bundle.Txs {
+		txTracker, err := s.worker.NewTxTracker(tx.Transaction, tx.ZKCounters, tx.ReservedZKCounters, tx.IP)
+		if err != nil {
+			return err
+		}
+		txTracker.PoolReceivedAt = tx.ReceivedAt
+		txTrackers = append(txTrackers, txTracker)
+	}
+
+	bundleTracker := s.worker.NewBundleTracker(bundle.Hash, txTrackers)
+	if dropReason := s.worker.AddBundleTracker(ctx, bundleTracker); dropReason != nil {
+		failedReason := fmt.Sprintf("%s: %s", ErrBundleFailed.Error(), dropReason.Error())
+		for _, txTracker := range txTrackers {
+			err := s.pool.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason)
+			if err != nil {
+				log.Errorf("failed to update status to failed in the pool for tx %s of bundle %s, error: %v", txTracker.HashStr, bundleTracker.HashStr, err)
+			}
+		}
+		return dropReason
+	}
+
+	// the bundle is set as WIP as a whole, so it is loaded again if any of its txs fails
+	for i, txTracker := range txTrackers {
+		err := s.pool.UpdateTxWIPStatus(ctx, txTracker.Hash, true)
+		if err != nil {
+			s.worker.DeleteBundle(bundleTracker.Hash)
+			for _, wipTxTracker := range txTrackers[:i] {
+				if err := s.pool.UpdateTxWIPStatus(ctx, wipTxTracker.Hash, false); err != nil {
+					log.Errorf("failed to rollback the WIP status in the pool for tx %s of bundle %s, error: %v", wipTxTracker.HashStr, bundleTracker.HashStr, err)
+				}
+			}
+			return err
+		}
 	}
+	return nil
 }
 
 // sendDataToStreamer sends data to the data stream server
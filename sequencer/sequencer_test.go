@@ -0,0 +1,205 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequencer_addTxToWorker(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := ethTypes.LatestSignerForChainID(big.NewInt(1000))
+	to := common.HexToAddress("0x1")
+	tx, err := ethTypes.SignNewTx(privateKey, signer, &ethTypes.LegacyTx{Nonce: 0, GasPrice: big.NewInt(1), Gas: 21000, To: &to})
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	poolTx := pool.Transaction{Transaction: *tx}
+
+	testCases := []struct {
+		name          string
+		wipErr        error
+		expectedReady bool
+	}{
+		{
+			name:          "pending tx is set as WIP",
+			expectedReady: true,
+		},
+		{
+			name:          "tx replaced in the pool is deleted from the worker",
+			wipErr:        pool.ErrNotFound,
+			expectedReady: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			stateMock := NewStateMock(t)
+			poolMock := NewPoolMock(t)
+			worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
+			s := &Sequencer{pool: poolMock, worker: worker}
+
+			stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+			stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(big.NewInt(0), nil)
+			stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(big.NewInt(1000000), nil)
+			poolMock.On("UpdateTxWIPStatus", ctx, tx.Hash(), true).Return(tc.wipErr).Once()
+
+			require.NoError(t, s.addTxToWorker(ctx, poolTx))
+
+			addr, found := worker.pool[from.String()]
+			require.True(t, found)
+			assert.Equal(t, tc.expectedReady, addr.readyTx != nil)
+			_, err := worker.GetBestFittingTx(state.BatchResources{ZKCounters: state.ZKCounters{}, Bytes: 1000})
+			if tc.expectedReady {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+			}
+		})
+	}
+}
+
+func TestSequencer_addTxToWorker_ReplaceWIPTx(t *testing.T) {
+	ctx := context.Background()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := ethTypes.LatestSignerForChainID(big.NewInt(1000))
+	to := common.HexToAddress("0x1")
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	originalTx, err := ethTypes.SignNewTx(privateKey, signer, &ethTypes.LegacyTx{Nonce: 0, GasPrice: big.NewInt(1), Gas: 21000, To: &to})
+	require.NoError(t, err)
+	replacementTx, err := ethTypes.SignNewTx(privateKey, signer, &ethTypes.LegacyTx{Nonce: 0, GasPrice: big.NewInt(2), Gas: 21000, To: &to})
+	require.NoError(t, err)
+
+	stateMock := NewStateMock(t)
+	poolMock := NewPoolMock(t)
+	worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
+	s := &Sequencer{pool: poolMock, worker: worker}
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+	stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(big.NewInt(0), nil)
+	stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(big.NewInt(1000000), nil)
+	poolMock.On("UpdateTxWIPStatus", ctx, originalTx.Hash(), true).Return(nil).Once()
+	require.NoError(t, s.addTxToWorker(ctx, pool.Transaction{Transaction: *originalTx}))
+
+	// the WIP tx replaced in the pool is replaced in the worker when the replacement tx is loaded
+	failedReason := ErrReplacedTransaction.Error()
+	poolMock.On("UpdateTxStatus", ctx, originalTx.Hash(), pool.TxStatusFailed, false, &failedReason).Return(nil).Once()
+	poolMock.On("UpdateTxWIPStatus", ctx, replacementTx.Hash(), true).Return(nil).Once()
+	require.NoError(t, s.addTxToWorker(ctx, pool.Transaction{Transaction: *replacementTx}))
+
+	readyTx, err := worker.GetBestFittingTx(state.BatchResources{ZKCounters: state.ZKCounters{}, Bytes: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, replacementTx.Hash(), readyTx.Hash)
+}
+
+func TestSequencer_loadFromPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poolMock := NewPoolMock(t)
+	s := &Sequencer{
+		cfg:  Config{LoadPoolTxsCheckInterval: types.NewDuration(10 * time.Millisecond)},
+		pool: poolMock,
+	}
+
+	// the pool is loaded periodically even if no new executable txs are notified
+	poolMock.On("ListenNewExecutableTxs", mock.Anything, mock.Anything).Return(func(ctx context.Context, _ func()) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	loaded := make(chan struct{}, 2)
+	poolMock.On("GetNonWIPExecutableTxs", mock.Anything).Return([]pool.Transaction{}, nil)
+	poolMock.On("GetNonWIPBundles", mock.Anything).Run(func(mock.Arguments) {
+		select {
+		case loaded <- struct{}{}:
+		default:
+		}
+	}).Return([]pool.Bundle{}, nil)
+
+	go s.loadFromPool(ctx)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-loaded:
+		case <-time.After(time.Second):
+			t.Fatal("the pool was not loaded periodically")
+		}
+	}
+}
+
+func TestSequencer_addBundleToWorker(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := ethTypes.LatestSignerForChainID(big.NewInt(1000))
+	to := common.HexToAddress("0x1")
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	bundle := pool.Bundle{Hash: common.Hash{0xb1}}
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, err := ethTypes.SignNewTx(privateKey, signer, &ethTypes.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(1), Gas: 21000, To: &to})
+		require.NoError(t, err)
+		bundle.Txs = append(bundle.Txs, pool.Transaction{Transaction: *tx})
+	}
+	errWIP := errors.New("failed to update WIP status")
+
+	testCases := []struct {
+		name          string
+		wipErr        error
+		expectedReady bool
+	}{
+		{
+			name:          "bundle txs are set as WIP",
+			expectedReady: true,
+		},
+		{
+			name:          "WIP status is rolled back if a bundle tx fails to be set as WIP",
+			wipErr:        errWIP,
+			expectedReady: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			stateMock := NewStateMock(t)
+			poolMock := NewPoolMock(t)
+			worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
+			s := &Sequencer{pool: poolMock, worker: worker}
+
+			stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+			stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(big.NewInt(0), nil)
+			stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(big.NewInt(1000000), nil)
+			poolMock.On("UpdateTxWIPStatus", ctx, bundle.Txs[0].Hash(), true).Return(nil).Once()
+			poolMock.On("UpdateTxWIPStatus", ctx, bundle.Txs[1].Hash(), true).Return(tc.wipErr).Once()
+			if tc.wipErr != nil {
+				poolMock.On("UpdateTxWIPStatus", ctx, bundle.Txs[0].Hash(), false).Return(nil).Once()
+			}
+
+			err := s.addBundleToWorker(ctx, bundle)
+			if tc.wipErr != nil {
+				assert.ErrorIs(t, err, tc.wipErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			readyBundle, err := worker.GetBestFittingBundle(state.BatchResources{ZKCounters: state.ZKCounters{}, Bytes: 1000})
+			if tc.expectedReady {
+				require.NoError(t, err)
+				assert.Equal(t, bundle.Hash, readyBundle.Hash)
+			} else {
+				assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package sequencer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// TxOrderingGasPrice is the value for TxOrdering.Policy to process first the txs with the highest gas price
+	TxOrderingGasPrice = "gasprice"
+	// TxOrderingFIFO is the value for TxOrdering.Policy to process first the txs received earlier by the pool
+	TxOrderingFIFO = "fifo"
+	// TxOrderingZKEfficiency is the value for TxOrdering.Policy to process first the txs paying the highest fee per reserved ZK counters
+	TxOrderingZKEfficiency = "zkefficiency"
+)
+
+// TxOrdering decides the order in which the ready txs of the worker are offered to the
+// finalizer, which processes the first one that fits in the remaining batch resources.
+// The order must only depend on tx fields that don't change while the tx is ready,
+// except for its ZK counters, as the tx is sorted again when they are updated.
+type TxOrdering interface {
+	// Before returns true if tx1 must be processed before tx2
+	Before(tx1 *TxTracker, tx2 *TxTracker) bool
+}
+
+// NewTxOrdering creates the TxOrdering of the provided config, the batch constraints are
+// used to weight the ZK counters of the txs
+func NewTxOrdering(cfg TxOrderingCfg, constraints state.BatchConstraintsCfg) (TxOrdering, error) {
+	var ordering TxOrdering
+	switch cfg.Policy {
+	case TxOrderingGasPrice, "":
+		ordering = gasPriceTxOrdering{}
+	case TxOrderingFIFO:
+		ordering = fifoTxOrdering{}
+	case TxOrderingZKEfficiency:
+		ordering = zkEfficiencyTxOrdering{constraints: constraints}
+	default:
+		return nil, fmt.Errorf("unknown tx ordering policy %s", cfg.Policy)
+	}
+
+	if len(cfg.PriorityAddresses) > 0 {
+		ordering = newPriorityTxOrdering(cfg.PriorityAddresses, ordering)
+	}
+	return ordering, nil
+}
+
+// gasPriceTxOrdering processes first the txs with the highest gas price
+type gasPriceTxOrdering struct{}
+
+// Before returns true if tx1 has a higher gas price than tx2
+func (gasPriceTxOrdering) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	return tx1.GasPrice.Cmp(tx2.GasPrice) == 1
+}
+
+// fifoTxOrdering processes the txs in the order they were received by the pool, so
+// the senders can't jump ahead of the txs sent before by paying a higher gas price
+type fifoTxOrdering struct{}
+
+// Before returns true if tx1 was received before tx2
+func (fifoTxOrdering) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	return tx1.PoolReceivedAt.Before(tx2.PoolReceivedAt)
+}
+
+// zkEfficiencyTxOrdering processes first the txs paying the highest fee per reserved
+// ZK counters. Each tx is weighted by the counter that takes the largest share of its
+// batch limit, as it is the one that limits the number of txs that fit in a batch.
+type zkEfficiencyTxOrdering struct {
+	constraints state.BatchConstraintsCfg
+}
+
+// Before returns true if tx1 pays a higher fee per reserved ZK counters than tx2
+func (o zkEfficiencyTxOrdering) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	return o.efficiency(tx1).Cmp(o.efficiency(tx2)) == 1
+}
+
+// efficiency returns the fee of the tx divided by the largest share of a batch limit
+// taken by its reserved ZK counters or its bytes
+func (o zkEfficiencyTxOrdering) efficiency(tx *TxTracker) *big.Float {
+	fee := new(big.Float).SetInt(new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.ReservedZKCounters.GasUsed)))
+
+	usage := maxShare(0, float64(tx.ReservedZKCounters.GasUsed), float64(o.constraints.MaxCumulativeGasUsed))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.KeccakHashes), float64(o.constraints.MaxKeccakHashes))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.PoseidonHashes), float64(o.constraints.MaxPoseidonHashes))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.PoseidonPaddings), float64(o.constraints.MaxPoseidonPaddings))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.MemAligns), float64(o.constraints.MaxMemAligns))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.Arithmetics), float64(o.constraints.MaxArithmetics))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.Binaries), float64(o.constraints.MaxBinaries))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.Steps), float64(o.constraints.MaxSteps))
+	usage = maxShare(usage, float64(tx.ReservedZKCounters.Sha256Hashes_V2), float64(o.constraints.MaxSHA256Hashes))
+	usage = maxShare(usage, float64(tx.Bytes), float64(o.constraints.MaxBatchBytesSize))
+	if usage == 0 {
+		return fee
+	}
+	return fee.Quo(fee, big.NewFloat(usage))
+}
+
+// maxShare returns the highest between the provided share and the share of the limit taken by the value
+func maxShare(share float64, value float64, limit float64) float64 {
+	if limit == 0 {
+		return share
+	}
+	if valueShare := value / limit; valueShare > share {
+		return valueShare
+	}
+	return share
+}
+
+// priorityTxOrdering processes the txs of the priority senders before the rest, the
+// txs in each lane are ordered with the provided ordering
+type priorityTxOrdering struct {
+	priorityAddresses map[common.Address]struct{}
+	ordering          TxOrdering
+}
+
+func newPriorityTxOrdering(priorityAddresses []common.Address, ordering TxOrdering) priorityTxOrdering {
+	o := priorityTxOrdering{
+		priorityAddresses: make(map[common.Address]struct{}, len(priorityAddresses)),
+		ordering:          ordering,
+	}
+	for _, address := range priorityAddresses {
+		o.priorityAddresses[address] = struct{}{}
+	}
+	return o
+}
+
+// Before returns true if only tx1 is sent by a priority sender or, when both are in the same lane, if tx1 is ordered before tx2
+func (o priorityTxOrdering) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	_, isPriority1 := o.priorityAddresses[tx1.From]
+	_, isPriority2 := o.priorityAddresses[tx2.From]
+	if isPriority1 != isPriority2 {
+		return isPriority1
+	}
+	return o.ordering.Before(tx1, tx2)
+}
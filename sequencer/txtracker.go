@@ -24,6 +24,8 @@ type TxTracker struct {
 	ReservedZKCounters state.ZKCounters
 	RawTx              []byte
 	ReceivedAt         time.Time // To check if it has been in the txSortedList for too long
+	PoolReceivedAt     time.Time // To order the txs by the time they were received by the pool
+	MaxL2BlockNumber   *uint64   // Max L2 block number a private tx can be included in before it expires
 	IP                 string    // IP of the tx sender
 	FailedReason       *string   // FailedReason is the reason why the tx failed, if it failed
 	EffectiveGasPrice  *big.Int
@@ -60,6 +62,7 @@ func newTxTracker(tx types.Transaction, usedZKCounters state.ZKCounters, reserve
 		ReservedZKCounters: reservedZKCounters,
 		RawTx:              rawTx,
 		ReceivedAt:         time.Now(),
+		PoolReceivedAt:     time.Now(),
 		IP:                 ip,
 		EffectiveGasPrice:  new(big.Int).SetUint64(0),
 		EGPLog: state.EffectiveGasPriceLog{
@@ -19,17 +19,18 @@ import (
 type Worker struct {
 	pool             map[string]*addrQueue
 	txSortedList     *txSortedList
+	bundles          []*BundleTracker
 	workerMutex      sync.Mutex
 	state            stateInterface
 	batchConstraints state.BatchConstraintsCfg
 	readyTxsCond     *timeoutCond
 }
 
-// NewWorker creates an init a worker
-func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond) *Worker {
+// NewWorker creates an init a worker, the ready txs are offered to the finalizer in the order of the provided TxOrdering
+func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond, txOrdering TxOrdering) *Worker {
 	w := Worker{
 		pool:             make(map[string]*addrQueue),
-		txSortedList:     newTxSortedList(),
+		txSortedList:     newTxSortedList(txOrdering),
 		state:            state,
 		batchConstraints: constraints,
 		readyTxsCond:     readyTxsCond,
@@ -65,32 +66,16 @@ func (w *Worker) AddTxTracker(ctx context.Context, tx *TxTracker) (replacedTx *T
 		// Unlock the worker to let execute other worker functions while creating the new AddrQueue
 		w.workerMutex.Unlock()
 
-		root, err := w.state.GetLastStateRoot(ctx, nil)
-		if err != nil {
-			dropReason = fmt.Errorf("error getting last state root from hashdb service, error: %v", err)
-			log.Error(dropReason)
-			return nil, dropReason
-		}
-		nonce, err := w.state.GetNonceByStateRoot(ctx, tx.From, root)
-		if err != nil {
-			dropReason = fmt.Errorf("error getting nonce for address %s from hashdb service, error: %v", tx.From, err)
-			log.Error(dropReason)
-			return nil, dropReason
-		}
-		balance, err := w.state.GetBalanceByStateRoot(ctx, tx.From, root)
-		if err != nil {
-			dropReason = fmt.Errorf("error getting balance for address %s from hashdb service, error: %v", tx.From, err)
-			log.Error(dropReason)
+		addr, dropReason = w.newAddrQueueFromState(ctx, tx.From)
+		if dropReason != nil {
 			return nil, dropReason
 		}
 
-		addr = newAddrQueue(tx.From, nonce.Uint64(), balance)
-
 		// Lock again the worker
 		w.workerMutex.Lock()
 
 		w.pool[tx.FromStr] = addr
-		log.Debugf("new addrQueue %s created (nonce: %d, balance: %s)", tx.FromStr, nonce.Uint64(), balance.String())
+		log.Debugf("new addrQueue %s created (nonce: %d, balance: %s)", tx.FromStr, addr.currentNonce, addr.currentBalance.String())
 	}
 
 	// Add the txTracker to Addr and get the newReadyTx and prevReadyTx
@@ -121,6 +106,153 @@ func (w *Worker) AddTxTracker(ctx context.Context, tx *TxTracker) (replacedTx *T
 	return repTx, nil
 }
 
+// newAddrQueueFromState creates an addrQueue with the nonce and balance of the address in the last state root,
+// the worker must be unlocked as the state is queried
+func (w *Worker) newAddrQueueFromState(ctx context.Context, from common.Address) (*addrQueue, error) {
+	root, err := w.state.GetLastStateRoot(ctx, nil)
+	if err != nil {
+		err = fmt.Errorf("error getting last state root from hashdb service, error: %v", err)
+		log.Error(err)
+		return nil, err
+	}
+	nonce, err := w.state.GetNonceByStateRoot(ctx, from, root)
+	if err != nil {
+		err = fmt.Errorf("error getting nonce for address %s from hashdb service, error: %v", from, err)
+		log.Error(err)
+		return nil, err
+	}
+	balance, err := w.state.GetBalanceByStateRoot(ctx, from, root)
+	if err != nil {
+		err = fmt.Errorf("error getting balance for address %s from hashdb service, error: %v", from, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	return newAddrQueue(from, nonce.Uint64(), balance), nil
+}
+
+// NewBundleTracker creates and inits a BundleTracker with the provided txs
+func (w *Worker) NewBundleTracker(hash common.Hash, txs []*TxTracker) *BundleTracker {
+	return newBundleTracker(hash, txs)
+}
+
+// AddBundleTracker adds a new bundle to the Worker, the bundles are offered to the finalizer in the order they are added
+func (w *Worker) AddBundleTracker(ctx context.Context, bundle *BundleTracker) error {
+	// Make sure the IPs are valid.
+	for _, tx := range bundle.Txs {
+		if tx.IP != "" && !pool.IsValidIP(tx.IP) {
+			return pool.ErrInvalidIP
+		}
+	}
+
+	// Make sure the bundle's reserved ZKCounters are within the constraints.
+	if !w.batchConstraints.IsWithinConstraints(bundle.ReservedZKCounters) {
+		log.Errorf("outOfCounters error (node level) for bundle %s", bundle.HashStr)
+		return pool.ErrOutOfCounters
+	}
+
+	// The addrQueues of the senders keep their nonces and balances updated after processing the bundle
+	for _, tx := range bundle.Txs {
+		w.workerMutex.Lock()
+		_, found := w.pool[tx.FromStr]
+		w.workerMutex.Unlock()
+		if found {
+			continue
+		}
+
+		addr, err := w.newAddrQueueFromState(ctx, tx.From)
+		if err != nil {
+			return err
+		}
+
+		w.workerMutex.Lock()
+		if _, found := w.pool[tx.FromStr]; !found {
+			w.pool[tx.FromStr] = addr
+			log.Debugf("new addrQueue %s created (nonce: %d, balance: %s)", tx.FromStr, addr.currentNonce, addr.currentBalance.String())
+		}
+		w.workerMutex.Unlock()
+	}
+
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	log.Infof("added new bundle %s with %d txs", bundle.HashStr, len(bundle.Txs))
+	w.bundles = append(w.bundles, bundle)
+	if len(w.bundles) == 1 {
+		// There were no bundles before to add the new one, we notify finalizer that we have a new bundle to process
+		w.readyTxsCond.L.Lock()
+		w.readyTxsCond.Signal()
+		w.readyTxsCond.L.Unlock()
+	}
+
+	return nil
+}
+
+// DeleteBundle deletes a bundle from the worker
+func (w *Worker) DeleteBundle(bundleHash common.Hash) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for i, bundle := range w.bundles {
+		if bundle.Hash == bundleHash {
+			w.bundles = append(w.bundles[:i], w.bundles[i+1:]...)
+			return
+		}
+	}
+	log.Warnf("bundle %s not found", bundleHash.String())
+}
+
+// UpdateBundleZKCounters updates the reserved ZKCounters of a bundle
+func (w *Worker) UpdateBundleZKCounters(bundleHash common.Hash, reservedZKCounters state.ZKCounters) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for _, bundle := range w.bundles {
+		if bundle.Hash == bundleHash {
+			log.Infof("update ZK counters for bundle %s", bundle.HashStr)
+			bundle.ReservedZKCounters = reservedZKCounters
+			return
+		}
+	}
+	log.Warnf("bundle %s not found", bundleHash.String())
+}
+
+// GetBestFittingBundle gets the first ready bundle added to the worker that fits in the available batch resources.
+// A bundle is ready when the current nonce of each of its senders is the nonce of its first tx in the bundle.
+func (w *Worker) GetBestFittingBundle(resources state.BatchResources) (*BundleTracker, error) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	readyBundles := false
+	for _, bundle := range w.bundles {
+		if !w.isBundleReady(bundle) {
+			continue
+		}
+		readyBundles = true
+		if fits, _ := resources.Fits(bundle.reservedResources()); fits {
+			log.Debugf("best fitting bundle %s found", bundle.HashStr)
+			return bundle, nil
+		}
+	}
+
+	if !readyBundles {
+		return nil, ErrTransactionsListEmpty
+	}
+	return nil, ErrNoFittingTransaction
+}
+
+// isBundleReady checks if the current nonces of the senders of a bundle are the nonces
+// of their first txs in the bundle, the worker must be locked
+func (w *Worker) isBundleReady(bundle *BundleTracker) bool {
+	for fromStr, nonce := range bundle.firstNonces {
+		addrQueue, found := w.pool[fromStr]
+		if !found || addrQueue.currentNonce != nonce {
+			return false
+		}
+	}
+	return true
+}
+
 func (w *Worker) applyAddressUpdate(from common.Address, fromNonce *uint64, fromBalance *big.Int) (*TxTracker, *TxTracker, []*TxTracker) {
 	addrQueue, found := w.pool[from.String()]
 
@@ -240,7 +372,13 @@ func (w *Worker) UpdateTxZKCounters(txHash common.Hash, addr common.Address, use
 	addrQueue, found := w.pool[addr.String()]
 
 	if found {
+		// the order of the ready tx can depend on its ZK counters, so it is sorted again
+		readyTx := addrQueue.readyTx
+		resort := readyTx != nil && readyTx.Hash == txHash && w.txSortedList.delete(readyTx)
 		addrQueue.UpdateTxZKCounters(txHash, usedZKCounters, reservedZKCounters)
+		if resort {
+			w.txSortedList.add(readyTx)
+		}
 	} else {
 		log.Warnf("addrQueue %s not found", addr.String())
 	}
@@ -368,11 +506,43 @@ func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 			delete(w.pool, addrQueue.fromStr)
 		}*/
 	}
+
+	// The txs of the old bundles are expired together
+	bundles := w.bundles[:0]
+	for _, bundle := range w.bundles {
+		if time.Since(bundle.ReceivedAt) > maxTime {
+			log.Debugf("bundle %s expired", bundle.HashStr)
+			txs = append(txs, bundle.Txs...)
+		} else {
+			bundles = append(bundles, bundle)
+		}
+	}
+	w.bundles = bundles
 	log.Debugf("expire transactions ended, addrQueue length: %d, delete count: %d ", len(w.pool), len(txs))
 
 	return txs
 }
 
+// ExpirePrivateTransactions deletes the private txs that were not included in an L2 block
+// up to their max L2 block number, once the L2 block with the provided number is stored
+func (w *Worker) ExpirePrivateTransactions(l2BlockNumber uint64) []*TxTracker {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	var txs []*TxTracker
+
+	for _, addrQueue := range w.pool {
+		subTxs, prevReadyTx := addrQueue.ExpirePrivateTransactions(l2BlockNumber)
+		txs = append(txs, subTxs...)
+
+		if prevReadyTx != nil {
+			w.txSortedList.delete(prevReadyTx)
+		}
+	}
+
+	return txs
+}
+
 func (w *Worker) addTxToSortedList(readyTx *TxTracker) {
 	w.txSortedList.add(readyTx)
 	if w.txSortedList.len() == 1 {
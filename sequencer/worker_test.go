@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
@@ -41,6 +42,8 @@ type workerAddTxTestCase struct {
 	reservedZKCounters   state.ZKCounters
 	usedBytes            uint64
 	gasPrice             *big.Int
+	receivedAt           time.Time
+	maxL2BlockNumber     *uint64
 	expectedTxSortedList []common.Hash
 	ip                   string
 	expectedErr          error
@@ -65,6 +68,8 @@ func processWorkerAddTxTestCases(ctx context.Context, t *testing.T, worker *Work
 			tx.Cost = testCase.cost
 			tx.Bytes = testCase.usedBytes
 			tx.GasPrice = testCase.gasPrice
+			tx.PoolReceivedAt = testCase.receivedAt
+			tx.MaxL2BlockNumber = testCase.maxL2BlockNumber
 			tx.updateZKCounters(testCase.reservedZKCounters, testCase.reservedZKCounters)
 			if testCase.ip == "" {
 				// A random valid IP Address
@@ -97,7 +102,7 @@ func TestWorkerAddTx(t *testing.T) {
 	var nilErr error
 
 	stateMock := NewStateMock(t)
-	worker := initWorker(stateMock, rcMax)
+	worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
 
 	ctx := context.Background()
 
@@ -195,7 +200,7 @@ func TestWorkerGetBestTx(t *testing.T) {
 	}
 
 	stateMock := NewStateMock(t)
-	worker := initWorker(stateMock, rcMax)
+	worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
 
 	ctx := context.Background()
 
@@ -283,7 +288,302 @@ func TestWorkerGetBestTx(t *testing.T) {
 	}
 }
 
-func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg) *Worker {
-	worker := NewWorker(stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+func TestWorkerTxOrdering(t *testing.T) {
+	var nilErr error
+
+	receivedAt := time.Now()
+	// efficiency = gasPrice * gasUsed / largest share of a batch limit
+	txs := []workerAddTxTestCase{
+		// efficiency: 10 * 1 / 0.1 = 100
+		{
+			name: "Adding from:0x01, tx:0x01/gp:10", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1, KeccakHashes: 1, PoseidonHashes: 1, PoseidonPaddings: 1, MemAligns: 1, Arithmetics: 1, Binaries: 1, Steps: 1, Sha256Hashes_V2: 1},
+			usedBytes:          1,
+			receivedAt:         receivedAt.Add(3 * time.Second),
+		},
+		// efficiency: 20 * 1 / 0.8 = 25
+		{
+			name: "Adding from:0x02, tx:0x02/gp:20", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(20),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1, KeccakHashes: 1, PoseidonHashes: 1, PoseidonPaddings: 1, MemAligns: 1, Arithmetics: 1, Binaries: 1, Steps: 8, Sha256Hashes_V2: 1},
+			usedBytes:          1,
+			receivedAt:         receivedAt.Add(1 * time.Second),
+		},
+		// efficiency: 15 * 2 / 0.2 = 150
+		{
+			name: "Adding from:0x03, tx:0x03/gp:15", from: common.Address{3}, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(15),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 2, KeccakHashes: 2, PoseidonHashes: 2, PoseidonPaddings: 2, MemAligns: 2, Arithmetics: 2, Binaries: 2, Steps: 2, Sha256Hashes_V2: 2},
+			usedBytes:          2,
+			receivedAt:         receivedAt.Add(2 * time.Second),
+		},
+		// efficiency: 5 * 1 / 0.1 = 50
+		{
+			name: "Adding from:0x04, tx:0x04/gp:5", from: common.Address{4}, txHash: common.Hash{4}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1, KeccakHashes: 1, PoseidonHashes: 1, PoseidonPaddings: 1, MemAligns: 1, Arithmetics: 1, Binaries: 1, Steps: 1, Sha256Hashes_V2: 1},
+			usedBytes:          1,
+			receivedAt:         receivedAt,
+		},
+	}
+
+	testCases := []struct {
+		name                  string
+		cfg                   TxOrderingCfg
+		expectedTxSortedLists [][]common.Hash
+	}{
+		{
+			name: "gas price",
+			cfg:  TxOrderingCfg{Policy: TxOrderingGasPrice},
+			expectedTxSortedLists: [][]common.Hash{
+				{{1}}, {{2}, {1}}, {{2}, {3}, {1}}, {{2}, {3}, {1}, {4}},
+			},
+		},
+		{
+			name: "fifo",
+			cfg:  TxOrderingCfg{Policy: TxOrderingFIFO},
+			expectedTxSortedLists: [][]common.Hash{
+				{{1}}, {{2}, {1}}, {{2}, {3}, {1}}, {{4}, {2}, {3}, {1}},
+			},
+		},
+		{
+			name: "zk efficiency",
+			cfg:  TxOrderingCfg{Policy: TxOrderingZKEfficiency},
+			expectedTxSortedLists: [][]common.Hash{
+				{{1}}, {{1}, {2}}, {{3}, {1}, {2}}, {{3}, {1}, {4}, {2}},
+			},
+		},
+		{
+			name: "priority lanes",
+			cfg:  TxOrderingCfg{Policy: TxOrderingGasPrice, PriorityAddresses: []common.Address{{1}, {4}}},
+			expectedTxSortedLists: [][]common.Hash{
+				{{1}}, {{1}, {2}}, {{1}, {2}, {3}}, {{1}, {4}, {2}, {3}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			stateMock := NewStateMock(t)
+			txOrdering, err := NewTxOrdering(testCase.cfg, rcMax)
+			assert.NoError(t, err)
+			worker := initWorker(stateMock, rcMax, txOrdering)
+
+			ctx := context.Background()
+
+			stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+			for _, tx := range txs {
+				stateMock.On("GetNonceByStateRoot", ctx, tx.from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+				stateMock.On("GetBalanceByStateRoot", ctx, tx.from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+			}
+
+			addTxsTC := make([]workerAddTxTestCase, len(txs))
+			for i, tx := range txs {
+				addTxsTC[i] = tx
+				addTxsTC[i].expectedTxSortedList = testCase.expectedTxSortedLists[i]
+			}
+			processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+		})
+	}
+}
+
+func TestWorkerTxOrderingUpdateZKCounters(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	txOrdering, err := NewTxOrdering(TxOrderingCfg{Policy: TxOrderingZKEfficiency}, rcMax)
+	assert.NoError(t, err)
+	worker := initWorker(stateMock, rcMax, txOrdering)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, from := range []common.Address{{1}, {2}} {
+		stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x01/gp:10", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1, Steps: 1},
+			usedBytes:          1,
+			expectedTxSortedList: []common.Hash{
+				{1},
+			},
+		},
+		{
+			name: "Adding from:0x02, tx:0x02/gp:20", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(20),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1, Steps: 8},
+			usedBytes:          1,
+			expectedTxSortedList: []common.Hash{
+				{1}, {2},
+			},
+		},
+	}
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// the tx is sorted again when it reserves less steps
+	counters := state.ZKCounters{GasUsed: 1, Steps: 1}
+	worker.UpdateTxZKCounters(common.Hash{2}, common.Address{2}, counters, counters)
+	assert.Equal(t, 2, worker.txSortedList.len())
+	assert.Equal(t, common.Hash{2}.String(), worker.txSortedList.getByIndex(0).HashStr)
+	assert.Equal(t, common.Hash{1}.String(), worker.txSortedList.getByIndex(1).HashStr)
+}
+
+func TestWorkerExpirePrivateTransactions(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, from := range []common.Address{{1}, {2}, {3}} {
+		stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding public from:0x01, tx:0x01/gp:10", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1},
+			usedBytes:          1,
+			expectedTxSortedList: []common.Hash{
+				{1},
+			},
+		},
+		{
+			name: "Adding private from:0x02, tx:0x02/gp:20/maxL2BlockNumber:5", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(20),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1},
+			usedBytes:          1,
+			maxL2BlockNumber:   state.Ptr(uint64(5)),
+			expectedTxSortedList: []common.Hash{
+				{2}, {1},
+			},
+		},
+		{
+			name: "Adding private from:0x03, tx:0x03/gp:30/maxL2BlockNumber:8", from: common.Address{3}, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(30),
+			cost:               new(big.Int).SetInt64(5),
+			reservedZKCounters: state.ZKCounters{GasUsed: 1},
+			usedBytes:          1,
+			maxL2BlockNumber:   state.Ptr(uint64(8)),
+			expectedTxSortedList: []common.Hash{
+				{3}, {2}, {1},
+			},
+		},
+	}
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// the private txs can still be included in the L2 block of their max L2 block number
+	assert.Empty(t, worker.ExpirePrivateTransactions(4))
+	assert.Equal(t, 3, worker.txSortedList.len())
+
+	expiredTxs := worker.ExpirePrivateTransactions(5)
+	assert.Len(t, expiredTxs, 1)
+	assert.Equal(t, common.Hash{2}, expiredTxs[0].Hash)
+	assert.Equal(t, 2, worker.txSortedList.len())
+	assert.Equal(t, common.Hash{3}.String(), worker.txSortedList.getByIndex(0).HashStr)
+	assert.Equal(t, common.Hash{1}.String(), worker.txSortedList.getByIndex(1).HashStr)
+
+	expiredTxs = worker.ExpirePrivateTransactions(10)
+	assert.Len(t, expiredTxs, 1)
+	assert.Equal(t, common.Hash{3}, expiredTxs[0].Hash)
+	assert.Equal(t, 1, worker.txSortedList.len())
+	assert.Equal(t, common.Hash{1}.String(), worker.txSortedList.getByIndex(0).HashStr)
+}
+
+func TestWorkerBundles(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax, gasPriceTxOrdering{})
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+	for _, from := range []common.Address{{1}, {2}} {
+		stateMock.On("GetNonceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, from, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr)
+	}
+
+	newTx := func(hash common.Hash, from common.Address, nonce uint64, gasUsed uint64) *TxTracker {
+		return &TxTracker{
+			Hash: hash, HashStr: hash.String(), From: from, FromStr: from.String(), Nonce: nonce,
+			GasPrice: new(big.Int).SetInt64(10), Cost: new(big.Int).SetInt64(5), Bytes: 2,
+			ReservedZKCounters: state.ZKCounters{GasUsed: gasUsed}, IP: validIP,
+		}
+	}
+
+	bundle1 := worker.NewBundleTracker(common.Hash{0xb1}, []*TxTracker{newTx(common.Hash{1}, common.Address{1}, 1, 3), newTx(common.Hash{2}, common.Address{1}, 2, 3)})
+	assert.Equal(t, uint64(6), bundle1.ReservedZKCounters.GasUsed)
+	assert.Equal(t, uint64(4), bundle1.Bytes)
+	assert.NoError(t, worker.AddBundleTracker(ctx, bundle1))
+
+	bundle2 := worker.NewBundleTracker(common.Hash{0xb2}, []*TxTracker{newTx(common.Hash{3}, common.Address{2}, 1, 2)})
+	assert.NoError(t, worker.AddBundleTracker(ctx, bundle2))
+
+	// a bundle must fit in an empty batch
+	bundle3 := worker.NewBundleTracker(common.Hash{0xb3}, []*TxTracker{newTx(common.Hash{4}, common.Address{1}, 3, 6), newTx(common.Hash{5}, common.Address{2}, 2, 6)})
+	assert.ErrorIs(t, worker.AddBundleTracker(ctx, bundle3), pool.ErrOutOfCounters)
+
+	// the addrQueues of the senders are created, but the bundle txs are not offered as single txs
+	assert.Len(t, worker.pool, 2)
+	assert.Equal(t, 0, worker.txSortedList.len())
+
+	// the bundles are offered in the order they were added
+	bundle, err := worker.GetBestFittingBundle(getMaxRemainingResources(rcMax))
+	assert.NoError(t, err)
+	assert.Equal(t, bundle1.Hash, bundle.Hash)
+
+	bundle, err = worker.GetBestFittingBundle(state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 5}, Bytes: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, bundle2.Hash, bundle.Hash)
+
+	_, err = worker.GetBestFittingBundle(state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1}, Bytes: 10})
+	assert.ErrorIs(t, err, ErrNoFittingTransaction)
+
+	worker.UpdateBundleZKCounters(bundle2.Hash, state.ZKCounters{GasUsed: 9})
+	_, err = worker.GetBestFittingBundle(state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 5}, Bytes: 10})
+	assert.ErrorIs(t, err, ErrNoFittingTransaction)
+
+	worker.DeleteBundle(bundle1.Hash)
+	bundle, err = worker.GetBestFittingBundle(getMaxRemainingResources(rcMax))
+	assert.NoError(t, err)
+	assert.Equal(t, bundle2.Hash, bundle.Hash)
+
+	// the txs of the expired bundles are returned to be failed
+	expiredTxs := worker.ExpireTransactions(0)
+	assert.Len(t, expiredTxs, 1)
+	assert.Equal(t, common.Hash{3}, expiredTxs[0].Hash)
+
+	_, err = worker.GetBestFittingBundle(getMaxRemainingResources(rcMax))
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+
+	// a bundle is only offered once the nonces of its senders reach their first txs in it
+	bundle4 := worker.NewBundleTracker(common.Hash{0xb4}, []*TxTracker{newTx(common.Hash{6}, common.Address{2}, 2, 1)})
+	assert.NoError(t, worker.AddBundleTracker(ctx, bundle4))
+	_, err = worker.GetBestFittingBundle(getMaxRemainingResources(rcMax))
+	assert.ErrorIs(t, err, ErrTransactionsListEmpty)
+
+	worker.pool[common.Address{2}.String()].currentNonce = 2
+	bundle, err = worker.GetBestFittingBundle(getMaxRemainingResources(rcMax))
+	assert.NoError(t, err)
+	assert.Equal(t, bundle4.Hash, bundle.Hash)
+}
+
+func TestNewTxOrderingUnknownPolicy(t *testing.T) {
+	_, err := NewTxOrdering(TxOrderingCfg{Policy: "unknown"}, rcMax)
+	assert.Error(t, err)
+}
+
+func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg, txOrdering TxOrdering) *Worker {
+	worker := NewWorker(stateMock, rcMax, newTimeoutCond(&sync.Mutex{}), txOrdering)
 	return worker
 }
@@ -0,0 +1,216 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const newBatchesCheckInterval = time.Second
+
+// BatchEventType is the status change of a batch notified by a NewBatchEvent
+type BatchEventType string
+
+const (
+	// BatchEventClosed is triggered when a trusted batch is closed
+	BatchEventClosed BatchEventType = "closed"
+	// BatchEventVirtualized is triggered when a batch is sequenced on L1
+	BatchEventVirtualized BatchEventType = "virtualized"
+	// BatchEventVerified is triggered when a batch is verified on L1
+	BatchEventVerified BatchEventType = "verified"
+)
+
+// NewBatchEventHandler represent a func that will be called by the
+// state when a NewBatchEvent is triggered
+type NewBatchEventHandler func(e NewBatchEvent)
+
+// NewBatchEvent is a struct provided from the state to the NewBatchEventHandler
+// when a batch is closed, virtualized or verified, the virtual and verified
+// batches are nil until the batch reaches that status on L1
+type NewBatchEvent struct {
+	Type          BatchEventType
+	Batch         Batch
+	VirtualBatch  *VirtualBatch
+	VerifiedBatch *VerifiedBatch
+}
+
+// SyncingStatusEventHandler represent a func that will be called by the
+// state when a SyncingStatusEvent is triggered
+type SyncingStatusEventHandler func(e SyncingStatusEvent)
+
+// SyncingStatusEvent is a struct provided from the state to the
+// SyncingStatusEventHandler when the node starts or stops syncing
+type SyncingStatusEvent struct {
+	SyncingInfo SyncingInfo
+}
+
+// batchEventsCursor keeps the last batch number notified for each batch event type
+type batchEventsCursor struct {
+	lastClosedBatch   uint64
+	lastVirtualBatch  uint64
+	lastVerifiedBatch uint64
+	isSynchronizing   *bool
+}
+
+// StartToMonitorNewBatches starts a go routine that will monitor the
+// batches closed, virtualized and verified and the syncing status of
+// the node, executing the handlers registered to be executed when they
+// change. This is used by the RPC WebSocket subscriptions.
+func (s *State) StartToMonitorNewBatches() {
+	go InfiniteSafeRun(s.monitorNewBatches, "fail to monitor new batches: %v:", time.Second)
+}
+
+// RegisterNewBatchEventHandler add the provided handler to the list of handlers
+// that will be triggered when a new batch event is triggered
+func (s *State) RegisterNewBatchEventHandler(h NewBatchEventHandler) {
+	log.Info("new batch event handler registered")
+	s.newBatchEventHandlers = append(s.newBatchEventHandlers, h)
+}
+
+// RegisterSyncingStatusEventHandler add the provided handler to the list of handlers
+// that will be triggered when a syncing status event is triggered
+func (s *State) RegisterSyncingStatusEventHandler(h SyncingStatusEventHandler) {
+	log.Info("syncing status event handler registered")
+	s.syncingStatusEventHandlers = append(s.syncingStatusEventHandlers, h)
+}
+
+func (s *State) monitorNewBatches() {
+	ctx := context.Background()
+	cursor := &batchEventsCursor{}
+
+	// batches already in the state when the monitor starts are not notified
+	var err error
+	if cursor.lastClosedBatch, err = s.GetLastClosedBatchNumber(ctx, nil); err != nil && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrStateNotSynchronized) {
+		log.Fatalf("failed to load the last closed batch: %v", err)
+	}
+	if cursor.lastVirtualBatch, err = s.GetLastVirtualBatchNum(ctx, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		log.Fatalf("failed to load the last virtual batch: %v", err)
+	}
+	if cursor.lastVerifiedBatch, err = s.lastVerifiedBatchNumber(ctx); err != nil {
+		log.Fatalf("failed to load the last verified batch: %v", err)
+	}
+
+	for {
+		time.Sleep(newBatchesCheckInterval)
+
+		if len(s.syncingStatusEventHandlers) > 0 {
+			s.checkSyncingStatus(ctx, cursor)
+		}
+
+		if len(s.newBatchEventHandlers) == 0 {
+			continue
+		}
+
+		lastClosedBatch, err := s.GetLastClosedBatchNumber(ctx, nil)
+		if err != nil {
+			log.Errorf("failed to get last closed batch while monitoring new batches: %v", err)
+		} else {
+			cursor.lastClosedBatch = s.notifyNewBatches(ctx, BatchEventClosed, cursor.lastClosedBatch, lastClosedBatch)
+		}
+
+		lastVirtualBatch, err := s.GetLastVirtualBatchNum(ctx, nil)
+		if err != nil {
+			log.Errorf("failed to get last virtual batch while monitoring new batches: %v", err)
+		} else {
+			cursor.lastVirtualBatch = s.notifyNewBatches(ctx, BatchEventVirtualized, cursor.lastVirtualBatch, lastVirtualBatch)
+		}
+
+		lastVerifiedBatch, err := s.lastVerifiedBatchNumber(ctx)
+		if err != nil {
+			log.Errorf("failed to get last verified batch while monitoring new batches: %v", err)
+		} else {
+			cursor.lastVerifiedBatch = s.notifyNewBatches(ctx, BatchEventVerified, cursor.lastVerifiedBatch, lastVerifiedBatch)
+		}
+	}
+}
+
+// notifyNewBatches triggers the events of the batches after the last one seen
+// up to the provided one, and returns the last batch number notified. If the
+// last batch moved back due to a reorg, nothing is notified and the monitor
+// continues from there
+func (s *State) notifyNewBatches(ctx context.Context, eventType BatchEventType, lastBatchNumberSeen, lastBatchNumber uint64) uint64 {
+	if lastBatchNumber < lastBatchNumberSeen {
+		log.Infof("last %v batch moved back from %v to %v", eventType, lastBatchNumberSeen, lastBatchNumber)
+		return lastBatchNumber
+	}
+
+	for batchNumber := lastBatchNumberSeen + 1; batchNumber <= lastBatchNumber; batchNumber++ {
+		event, err := s.newBatchEvent(ctx, eventType, batchNumber)
+		if err != nil {
+			log.Errorf("failed to build the %v batch event for batch %v: %v", eventType, batchNumber, err)
+			return batchNumber - 1
+		}
+
+		log.Debugf("[monitorNewBatches] sending NewBatchEvent %v for batch %v", eventType, batchNumber)
+		for _, handler := range s.newBatchEventHandlers {
+			SafeRun(func() { handler(*event) }, "failed and recovered in NewBatchEventHandler: %v")
+		}
+	}
+	return lastBatchNumber
+}
+
+// newBatchEvent loads the data of the batch notified by a NewBatchEvent
+func (s *State) newBatchEvent(ctx context.Context, eventType BatchEventType, batchNumber uint64) (*NewBatchEvent, error) {
+	batch, err := s.GetBatchByNumber(ctx, batchNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualBatch, err := s.GetVirtualBatch(ctx, batchNumber, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	// the batches verified in a range only have the verified batch of the last one
+	verifiedBatch, err := s.GetVerifiedBatch(ctx, batchNumber, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	return &NewBatchEvent{
+		Type:          eventType,
+		Batch:         *batch,
+		VirtualBatch:  virtualBatch,
+		VerifiedBatch: verifiedBatch,
+	}, nil
+}
+
+// lastVerifiedBatchNumber returns the number of the last verified batch, zero if there is none
+func (s *State) lastVerifiedBatchNumber(ctx context.Context) (uint64, error) {
+	verifiedBatch, err := s.GetLastVerifiedBatch(ctx, nil)
+	if errors.Is(err, ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return verifiedBatch.BatchNumber, nil
+}
+
+// checkSyncingStatus triggers a syncing status event when the node starts or stops syncing
+func (s *State) checkSyncingStatus(ctx context.Context, cursor *batchEventsCursor) {
+	syncingInfo, err := s.GetSyncingInfo(ctx, nil)
+	if errors.Is(err, ErrStateNotSynchronized) {
+		return
+	} else if err != nil {
+		log.Errorf("failed to get syncing info while monitoring new batches: %v", err)
+		return
+	}
+
+	// the status found when the monitor starts is not notified
+	isFirstCheck := cursor.isSynchronizing == nil
+	if !isFirstCheck && *cursor.isSynchronizing == syncingInfo.IsSynchronizing {
+		return
+	}
+	cursor.isSynchronizing = &syncingInfo.IsSynchronizing
+	if isFirstCheck {
+		return
+	}
+
+	log.Debugf("[monitorNewBatches] sending SyncingStatusEvent, synchronizing: %v", syncingInfo.IsSynchronizing)
+	event := SyncingStatusEvent{SyncingInfo: syncingInfo}
+	for _, handler := range s.syncingStatusEventHandlers {
+		SafeRun(func() { handler(event) }, "failed and recovered in SyncingStatusEventHandler: %v")
+	}
+}
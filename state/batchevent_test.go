@@ -0,0 +1,95 @@
+package state_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorNewBatches(t *testing.T) {
+	mockStorage := mocks.NewStorageMock(t)
+	testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil)
+
+	lastClosedBatch := atomic.Uint64{}
+	lastClosedBatch.Store(3)
+	lastVirtualBatch := atomic.Uint64{}
+	lastVirtualBatch.Store(2)
+	lastBatchSeen := atomic.Uint64{}
+	lastBatchSeen.Store(3)
+	syncingInfoChecks := atomic.Uint64{}
+
+	mockStorage.On("GetLastClosedBatchNumber", mock.Anything, nil).Return(func(context.Context, pgx.Tx) (uint64, error) {
+		return lastClosedBatch.Load(), nil
+	})
+	mockStorage.On("GetLastVirtualBatchNum", mock.Anything, nil).Return(func(context.Context, pgx.Tx) (uint64, error) {
+		return lastVirtualBatch.Load(), nil
+	})
+	mockStorage.On("GetLastVerifiedBatch", mock.Anything, nil).Return(nil, state.ErrNotFound)
+	mockStorage.On("GetBatchByNumber", mock.Anything, mock.Anything, nil).Return(func(_ context.Context, batchNumber uint64, _ pgx.Tx) (*state.Batch, error) {
+		return &state.Batch{BatchNumber: batchNumber}, nil
+	}).Maybe()
+	mockStorage.On("GetVirtualBatch", mock.Anything, uint64(3), nil).Return(&state.VirtualBatch{BatchNumber: 3}, nil).Maybe()
+	mockStorage.On("GetVirtualBatch", mock.Anything, mock.Anything, nil).Return(nil, state.ErrNotFound).Maybe()
+	mockStorage.On("GetVerifiedBatch", mock.Anything, mock.Anything, nil).Return(nil, state.ErrNotFound).Maybe()
+
+	mockStorage.On("GetSyncInfoData", mock.Anything, nil).Return(func(context.Context, pgx.Tx) (state.SyncInfoDataOnStorage, error) {
+		syncInfoData := state.SyncInfoDataOnStorage{InitialSyncingBatch: 1, LastBatchNumberSeen: lastBatchSeen.Load(), LastBatchNumberConsolidated: lastBatchSeen.Load()}
+		syncingInfoChecks.Add(1)
+		return syncInfoData, nil
+	})
+	mockStorage.On("GetFirstL2BlockNumberForBatchNumber", mock.Anything, uint64(1), nil).Return(uint64(1), nil)
+	mockStorage.On("GetLastL2BlockNumber", mock.Anything, nil).Return(uint64(10), nil)
+	mockStorage.On("GetLastBatchNumber", mock.Anything, nil).Return(uint64(3), nil)
+
+	mutex := sync.Mutex{}
+	batchEvents := []state.NewBatchEvent{}
+	syncingEvents := []state.SyncingStatusEvent{}
+	testState.RegisterNewBatchEventHandler(func(e state.NewBatchEvent) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		batchEvents = append(batchEvents, e)
+	})
+	testState.RegisterSyncingStatusEventHandler(func(e state.SyncingStatusEvent) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		syncingEvents = append(syncingEvents, e)
+	})
+	testState.StartToMonitorNewBatches()
+
+	// wait for the monitor to load the initial status, which is not notified
+	require.Eventually(t, func() bool {
+		return syncingInfoChecks.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	lastClosedBatch.Store(5)
+	lastVirtualBatch.Store(3)
+	lastBatchSeen.Store(6)
+
+	require.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(batchEvents) == 3 && len(syncingEvents) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, state.BatchEventClosed, batchEvents[0].Type)
+	assert.Equal(t, uint64(4), batchEvents[0].Batch.BatchNumber)
+	assert.Equal(t, state.BatchEventClosed, batchEvents[1].Type)
+	assert.Equal(t, uint64(5), batchEvents[1].Batch.BatchNumber)
+	assert.Nil(t, batchEvents[1].VirtualBatch)
+	assert.Equal(t, state.BatchEventVirtualized, batchEvents[2].Type)
+	assert.Equal(t, uint64(3), batchEvents[2].Batch.BatchNumber)
+	require.NotNil(t, batchEvents[2].VirtualBatch)
+	assert.True(t, syncingEvents[0].SyncingInfo.IsSynchronizing)
+	assert.Equal(t, uint64(13), syncingEvents[0].SyncingInfo.EstimatedHighestBlock)
+}
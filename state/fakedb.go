@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -16,6 +17,9 @@ type FakeDB struct {
 	State     *State
 	stateRoot []byte
 	refund    uint64
+	// overrides are applied on top of the state root, so the tracers
+	// read the same state the tx was executed with
+	overrides StateOverride
 }
 
 // SetStateRoot is the stateRoot setter.
@@ -40,6 +44,10 @@ func (f *FakeDB) AddBalance(common.Address, *big.Int) {
 
 // GetBalance returns the balance of the given address.
 func (f *FakeDB) GetBalance(address common.Address) *big.Int {
+	if balance := f.overrides.balanceFor(address); balance != nil {
+		return new(big.Int).Set(balance)
+	}
+
 	ctx := context.Background()
 	balance, err := f.State.GetTree().GetBalance(ctx, address, f.stateRoot)
 
@@ -53,6 +61,10 @@ func (f *FakeDB) GetBalance(address common.Address) *big.Int {
 
 // GetNonce returns the nonce of the given address.
 func (f *FakeDB) GetNonce(address common.Address) uint64 {
+	if nonce := f.overrides.nonceFor(address); nonce != nil {
+		return *nonce
+	}
+
 	ctx := context.Background()
 	nonce, err := f.State.GetTree().GetNonce(ctx, address, f.stateRoot)
 
@@ -72,6 +84,15 @@ func (f *FakeDB) SetNonce(common.Address, uint64) {
 
 // GetCodeHash gets the hash for the code at a given address
 func (f *FakeDB) GetCodeHash(address common.Address) common.Hash {
+	if code := f.overrides.codeFor(address); code != nil {
+		hash, err := merkletree.HashContractBytecode(*code)
+		if err != nil {
+			log.Errorf("error on FakeDB GetCodeHash for overridden address %v, err: %v", address, err)
+			return ZeroHash
+		}
+		return common.HexToHash(merkletree.H4ToString(hash))
+	}
+
 	ctx := context.Background()
 	hash, err := f.State.GetTree().GetCodeHash(ctx, address, f.stateRoot)
 	if err != nil {
@@ -84,6 +105,10 @@ func (f *FakeDB) GetCodeHash(address common.Address) common.Hash {
 
 // GetCode returns the SC code of the given address.
 func (f *FakeDB) GetCode(address common.Address) []byte {
+	if code := f.overrides.codeFor(address); code != nil {
+		return *code
+	}
+
 	ctx := context.Background()
 	code, err := f.State.GetTree().GetCode(ctx, address, f.stateRoot)
 
@@ -131,6 +156,10 @@ func (f *FakeDB) GetCommittedState(common.Address, common.Hash) common.Hash {
 
 // GetState retrieves a value from the given account's storage trie.
 func (f *FakeDB) GetState(address common.Address, hash common.Hash) common.Hash {
+	if value, found := f.overrides.storageFor(address, hash); found {
+		return value
+	}
+
 	ctx := context.Background()
 	storage, err := f.State.GetTree().GetStorageAt(ctx, address, hash.Big(), f.stateRoot)
 
@@ -58,6 +58,12 @@ func EncodeTransactions(txs []types.Transaction, effectivePercentages []uint8, f
 }
 
 func prepareRPLTxData(tx types.Transaction) ([]byte, error) {
+	// The batch L2 data only carries legacy txs. Typed txs can't be re-encoded as legacy,
+	// their signature covers the typed signing hash, so a different sender would be recovered.
+	if tx.Type() != types.LegacyTxType {
+		return nil, types.ErrTxTypeNotSupported
+	}
+
 	v, r, s := tx.RawSignatureValues()
 	sign := 1 - (v.Uint64() & 1)
 
@@ -418,6 +418,54 @@ func (_c *StorageMock_AddL1InfoRootToExitRoot_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// AddL1Reorg provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) AddL1Reorg(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddL1Reorg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddL1Reorg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddL1Reorg'
+type StorageMock_AddL1Reorg_Call struct {
+	*mock.Call
+}
+
+// AddL1Reorg is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddL1Reorg(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_AddL1Reorg_Call {
+	return &StorageMock_AddL1Reorg_Call{Call: _e.mock.On("AddL1Reorg", ctx, blockNumber, dbTx)}
+}
+
+func (_c *StorageMock_AddL1Reorg_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_AddL1Reorg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddL1Reorg_Call) Return(_a0 error) *StorageMock_AddL1Reorg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddL1Reorg_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) error) *StorageMock_AddL1Reorg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, dbTx
 func (_m *StorageMock) AddL2Block(ctx context.Context, batchNumber uint64, l2Block *state.L2Block, receipts []*types.Receipt, txsL2Hash []common.Hash, txsEGPData []state.StoreTxEGPData, imStateRoots []common.Hash, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, l2Block, receipts, txsL2Hash, txsEGPData, imStateRoots, dbTx)
@@ -1185,6 +1233,63 @@ func (_c *StorageMock_CloseWIPBatchInStorage_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// CountL1Reorgs provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) CountL1Reorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountL1Reorgs")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_CountL1Reorgs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountL1Reorgs'
+type StorageMock_CountL1Reorgs_Call struct {
+	*mock.Call
+}
+
+// CountL1Reorgs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) CountL1Reorgs(ctx interface{}, dbTx interface{}) *StorageMock_CountL1Reorgs_Call {
+	return &StorageMock_CountL1Reorgs_Call{Call: _e.mock.On("CountL1Reorgs", ctx, dbTx)}
+}
+
+func (_c *StorageMock_CountL1Reorgs_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_CountL1Reorgs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_CountL1Reorgs_Call) Return(_a0 uint64, _a1 error) *StorageMock_CountL1Reorgs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_CountL1Reorgs_Call) RunAndReturn(run func(context.Context, pgx.Tx) (uint64, error)) *StorageMock_CountL1Reorgs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountReorgs provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -6522,6 +6627,75 @@ func (_c *StorageMock_GetTransactionReceipt_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetTransactionReceiptsByBlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) GetTransactionReceiptsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Receipt, []*common.Hash, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceiptsByBlockNumber")
+	}
+
+	var r0 []*types.Receipt
+	var r1 []*common.Hash
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]*types.Receipt, []*common.Hash, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []*types.Receipt); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) []*common.Hash); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// StorageMock_GetTransactionReceiptsByBlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionReceiptsByBlockNumber'
+type StorageMock_GetTransactionReceiptsByBlockNumber_Call struct {
+	*mock.Call
+}
+
+// GetTransactionReceiptsByBlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetTransactionReceiptsByBlockNumber(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetTransactionReceiptsByBlockNumber_Call {
+	return &StorageMock_GetTransactionReceiptsByBlockNumber_Call{Call: _e.mock.On("GetTransactionReceiptsByBlockNumber", ctx, blockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetTransactionReceiptsByBlockNumber_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetTransactionReceiptsByBlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetTransactionReceiptsByBlockNumber_Call) Return(_a0 []*types.Receipt, _a1 []*common.Hash, _a2 error) *StorageMock_GetTransactionReceiptsByBlockNumber_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *StorageMock_GetTransactionReceiptsByBlockNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]*types.Receipt, []*common.Hash, error)) *StorageMock_GetTransactionReceiptsByBlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTransactionsByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]types.Transaction, []uint8, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -8351,7 +8525,7 @@ func (_c *StorageMock_UpdateForkIDIntervalsInMemory_Call) Return() *StorageMock_
 }
 
 func (_c *StorageMock_UpdateForkIDIntervalsInMemory_Call) RunAndReturn(run func([]state.ForkIDInterval)) *StorageMock_UpdateForkIDIntervalsInMemory_Call {
-	_c.Call.Return(run)
+	_c.Run(run)
 	return _c
 }
 
@@ -0,0 +1,162 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateOverride is the collection of accounts to be ephemerally overridden
+// before processing an unsigned transaction
+type StateOverride map[common.Address]OverrideAccount
+
+// OverrideAccount contains the fields of an account to be overridden, the
+// fields set to nil are not overridden.
+// State replaces the whole storage of the account while StateDiff only
+// replaces the given slots, so they can't be set at the same time.
+type OverrideAccount struct {
+	Nonce     *uint64
+	Code      *[]byte
+	Balance   *big.Int
+	State     map[common.Hash]common.Hash
+	StateDiff map[common.Hash]common.Hash
+}
+
+// BlockOverride contains the fields of the block context to be overridden
+// before processing an unsigned transaction, the fields set to nil are not
+// overridden
+type BlockOverride struct {
+	Time     *uint64
+	Coinbase *common.Address
+}
+
+// unsignedTxProcessingOptions groups the optional settings used to process an
+// unsigned transaction
+type unsignedTxProcessingOptions struct {
+	stateOverride StateOverride
+	blockOverride *BlockOverride
+	traceConfig   *TraceConfig
+}
+
+// Validate checks the state override is consistent.
+// The executor takes the zero nonce and balance as not overridden, so they
+// are rejected instead of being silently ignored
+func (so StateOverride) Validate() error {
+	for address, account := range so {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", address.String())
+		}
+		if account.Nonce != nil && *account.Nonce == 0 {
+			return fmt.Errorf("account %s can't override the nonce to zero", address.String())
+		}
+		if account.Balance != nil && account.Balance.Sign() == 0 {
+			return fmt.Errorf("account %s can't override the balance to zero", address.String())
+		}
+	}
+	return nil
+}
+
+// nonceFor returns the overridden nonce of the given address, if any
+func (so StateOverride) nonceFor(address common.Address) *uint64 {
+	account, found := so[address]
+	if !found {
+		return nil
+	}
+	return account.Nonce
+}
+
+// balanceFor returns the overridden balance of the given address, if any
+func (so StateOverride) balanceFor(address common.Address) *big.Int {
+	account, found := so[address]
+	if !found {
+		return nil
+	}
+	return account.Balance
+}
+
+// codeFor returns the overridden code of the given address, if any
+func (so StateOverride) codeFor(address common.Address) *[]byte {
+	account, found := so[address]
+	if !found {
+		return nil
+	}
+	return account.Code
+}
+
+// storageFor returns the overridden value of the given storage slot, if any.
+// Overriding the whole state makes the slots not included in it empty
+func (so StateOverride) storageFor(address common.Address, slot common.Hash) (common.Hash, bool) {
+	account, found := so[address]
+	if !found {
+		return common.Hash{}, false
+	}
+	if account.State != nil {
+		return account.State[slot], true
+	}
+	value, found := account.StateDiff[slot]
+	return value, found
+}
+
+// toExecutorV1 converts the state override to the pre ETROG executor format
+func (so StateOverride) toExecutorV1() map[string]*executor.OverrideAccount {
+	if len(so) == 0 {
+		return nil
+	}
+	overrides := make(map[string]*executor.OverrideAccount, len(so))
+	for address, account := range so {
+		balance, nonce, code, state, stateDiff := account.toExecutorFields()
+		overrides[address.String()] = &executor.OverrideAccount{
+			Balance:   balance,
+			Nonce:     nonce,
+			Code:      code,
+			State:     state,
+			StateDiff: stateDiff,
+		}
+	}
+	return overrides
+}
+
+// toExecutorV2 converts the state override to the post ETROG executor format
+func (so StateOverride) toExecutorV2() map[string]*executor.OverrideAccountV2 {
+	if len(so) == 0 {
+		return nil
+	}
+	overrides := make(map[string]*executor.OverrideAccountV2, len(so))
+	for address, account := range so {
+		balance, nonce, code, state, stateDiff := account.toExecutorFields()
+		overrides[address.String()] = &executor.OverrideAccountV2{
+			Balance:   balance,
+			Nonce:     nonce,
+			Code:      code,
+			State:     state,
+			StateDiff: stateDiff,
+		}
+	}
+	return overrides
+}
+
+func (a OverrideAccount) toExecutorFields() (balance []byte, nonce uint64, code []byte, state, stateDiff map[string]string) {
+	if a.Balance != nil {
+		balance = a.Balance.Bytes()
+	}
+	if a.Nonce != nil {
+		nonce = *a.Nonce
+	}
+	if a.Code != nil {
+		code = *a.Code
+	}
+	return balance, nonce, code, storageToExecutor(a.State), storageToExecutor(a.StateDiff)
+}
+
+func storageToExecutor(storage map[common.Hash]common.Hash) map[string]string {
+	if storage == nil {
+		return nil
+	}
+	converted := make(map[string]string, len(storage))
+	for key, value := range storage {
+		converted[key.String()] = value.String()
+	}
+	return converted
+}
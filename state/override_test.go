@@ -0,0 +1,59 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateOverrideToExecutor(t *testing.T) {
+	address := common.HexToAddress("0x1")
+	slot := common.HexToHash("0x2")
+	value := common.HexToHash("0x3")
+	nonce := uint64(5)
+	code := []byte{0x60, 0x01}
+
+	stateOverride := StateOverride{
+		address: OverrideAccount{
+			Nonce:     &nonce,
+			Code:      &code,
+			Balance:   big.NewInt(256),
+			StateDiff: map[common.Hash]common.Hash{slot: value},
+		},
+	}
+	require.NoError(t, stateOverride.Validate())
+	assert.Equal(t, &nonce, stateOverride.nonceFor(address))
+	assert.Nil(t, stateOverride.nonceFor(common.HexToAddress("0x4")))
+
+	overridesV2 := stateOverride.toExecutorV2()
+	require.Len(t, overridesV2, 1)
+	account := overridesV2[address.String()]
+	require.NotNil(t, account)
+	assert.Equal(t, []byte{0x01, 0x00}, account.Balance)
+	assert.Equal(t, nonce, account.Nonce)
+	assert.Equal(t, code, account.Code)
+	assert.Nil(t, account.State)
+	assert.Equal(t, map[string]string{slot.String(): value.String()}, account.StateDiff)
+
+	overridesV1 := stateOverride.toExecutorV1()
+	require.Len(t, overridesV1, 1)
+	assert.Equal(t, account.StateDiff, overridesV1[address.String()].StateDiff)
+
+	assert.Nil(t, StateOverride(nil).toExecutorV2())
+
+	stateOverride[address] = OverrideAccount{
+		State:     map[common.Hash]common.Hash{slot: value},
+		StateDiff: map[common.Hash]common.Hash{slot: value},
+	}
+	assert.EqualError(t, stateOverride.Validate(), "account 0x0000000000000000000000000000000000000001 has both 'state' and 'stateDiff'")
+
+	zeroNonce := uint64(0)
+	stateOverride[address] = OverrideAccount{Nonce: &zeroNonce}
+	assert.EqualError(t, stateOverride.Validate(), "account 0x0000000000000000000000000000000000000001 can't override the nonce to zero")
+
+	stateOverride[address] = OverrideAccount{Balance: big.NewInt(0)}
+	assert.EqualError(t, stateOverride.Validate(), "account 0x0000000000000000000000000000000000000001 can't override the balance to zero")
+}
@@ -288,6 +288,28 @@ func (p *PostgresStorage) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64,
 	return count, nil
 }
 
+// AddL1Reorg records a reset of the state to the given L1 block number
+func (p *PostgresStorage) AddL1Reorg(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) error {
+	const insertL1ReorgSQL = "INSERT INTO state.l1_reorg (block_num) VALUES ($1)"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, insertL1ReorgSQL, blockNumber)
+	return err
+}
+
+// CountL1Reorgs returns the number of resets of the state to a previous L1 block
+func (p *PostgresStorage) CountL1Reorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	const countL1ReorgsSQL = "SELECT COUNT(*) FROM state.l1_reorg"
+
+	var count uint64
+	q := p.getExecQuerier(dbTx)
+	err := q.QueryRow(ctx, countL1ReorgsSQL).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // GetReorgedTransactions returns the transactions that were reorged
 func (p *PostgresStorage) GetReorgedTransactions(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]*types.Transaction, error) {
 	const getReorgedTransactionsSql = "SELECT encoded FROM state.transaction t INNER JOIN state.l2block b ON t.l2_block_num = b.block_num WHERE b.batch_num >= $1 ORDER BY l2_block_num ASC"
@@ -1004,6 +1004,106 @@ func TestGetLogs(t *testing.T) {
 	require.NoError(t, dbTx.Commit(ctx))
 }
 
+func TestGetTransactionReceiptsByBlockNumber(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	err = testState.AddBlock(ctx, block, dbTx)
+	assert.NoError(t, err)
+
+	batchNumber := uint64(1)
+	_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+	assert.NoError(t, err)
+
+	blockNumber := big.NewInt(1)
+	transactions := []*types.Transaction{}
+	receipts := []*types.Receipt{}
+	for i := 0; i < 3; i++ {
+		tx := types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       nil,
+			Value:    new(big.Int),
+			Gas:      uint64(21000 + i),
+			GasPrice: big.NewInt(0),
+		})
+
+		logs := []*types.Log{}
+		for j := 0; j < i; j++ {
+			logs = append(logs, &types.Log{TxHash: tx.Hash(), Index: uint(j)})
+		}
+
+		transactions = append(transactions, tx)
+		receipts = append(receipts, &types.Receipt{
+			Type:              tx.Type(),
+			PostState:         state.ZeroHash.Bytes(),
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			EffectiveGasPrice: big.NewInt(int64(i)),
+			BlockNumber:       blockNumber,
+			GasUsed:           tx.Gas(),
+			TxHash:            tx.Hash(),
+			TransactionIndex:  uint(i),
+			Status:            types.ReceiptStatusSuccessful,
+			Logs:              logs,
+		})
+	}
+
+	header := state.NewL2Header(&types.Header{
+		Number:     blockNumber,
+		ParentHash: state.ZeroHash,
+		Coinbase:   state.ZeroAddress,
+		Root:       state.ZeroHash,
+		GasUsed:    1,
+		GasLimit:   10,
+		Time:       uint64(time.Now().Unix()),
+	})
+
+	st := trie.NewStackTrie(nil)
+	l2Block := state.NewL2Block(header, transactions, []*state.L2Header{}, receipts, st)
+	for _, receipt := range receipts {
+		receipt.BlockHash = l2Block.Hash()
+	}
+
+	storeTxsEGPData := make([]state.StoreTxEGPData, len(transactions))
+	txsL2Hash := make([]common.Hash, len(transactions))
+	stateRoots := make([]common.Hash, len(transactions))
+	for i := range transactions {
+		storeTxsEGPData[i] = state.StoreTxEGPData{EGPLog: nil, EffectivePercentage: state.MaxEffectivePercentage}
+		txsL2Hash[i] = common.HexToHash(fmt.Sprintf("0x%d", i))
+	}
+
+	err = testState.AddL2Block(ctx, batchNumber, l2Block, receipts, txsL2Hash, storeTxsEGPData, stateRoots, dbTx)
+	require.NoError(t, err)
+
+	actualReceipts, actualL2Hashes, err := testState.GetTransactionReceiptsByBlockNumber(ctx, blockNumber.Uint64(), dbTx)
+	require.NoError(t, err)
+	require.Len(t, actualReceipts, len(receipts))
+	require.Len(t, actualL2Hashes, len(receipts))
+	for i, actual := range actualReceipts {
+		expected, err := testState.GetTransactionReceipt(ctx, transactions[i].Hash(), dbTx)
+		require.NoError(t, err)
+		assert.Equal(t, expected.TxHash, actual.TxHash)
+		assert.Equal(t, expected.TransactionIndex, actual.TransactionIndex)
+		assert.Equal(t, expected.CumulativeGasUsed, actual.CumulativeGasUsed)
+		assert.Equal(t, expected.GasUsed, actual.GasUsed)
+		assert.Equal(t, expected.EffectiveGasPrice, actual.EffectiveGasPrice)
+		assert.Equal(t, expected.BlockHash, actual.BlockHash)
+		assert.Equal(t, expected.BlockNumber, actual.BlockNumber)
+		assert.Equal(t, expected.Bloom, actual.Bloom)
+		assert.Equal(t, len(expected.Logs), len(actual.Logs))
+		require.NotNil(t, actualL2Hashes[i])
+		assert.Equal(t, txsL2Hash[i], *actualL2Hashes[i])
+	}
+
+	actualReceipts, actualL2Hashes, err = testState.GetTransactionReceiptsByBlockNumber(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, actualReceipts)
+	assert.Empty(t, actualL2Hashes)
+
+	require.NoError(t, dbTx.Commit(ctx))
+}
+
 func TestGetNativeBlockHashesInRange(t *testing.T) {
 	initOrResetDB()
 
@@ -1490,3 +1590,24 @@ func TestGetLastGER(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, common.HexToHash("0x2").String(), ger.String())
 }
+
+func TestResetCountsL1Reorgs(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	count, err := testState.CountL1Reorgs(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	require.NoError(t, testState.AddBlock(ctx, block, dbTx))
+	require.NoError(t, testState.Reset(ctx, 0, dbTx))
+	require.NoError(t, testState.Reset(ctx, 0, dbTx))
+
+	count, err = testState.CountL1Reorgs(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
@@ -311,6 +311,101 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 	return &receipt, nil
 }
 
+// GetTransactionReceiptsByBlockNumber gets the receipts of all the transactions
+// of the provided l2 block number, sorted by transaction index, along with the
+// l2 hash of each transaction, which is nil when it isn't available
+func (p *PostgresStorage) GetTransactionReceiptsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Receipt, []*common.Hash, error) {
+	const getReceiptsSQL = `
+		SELECT 
+			r.tx_index,
+			r.tx_hash,
+		    r.type,
+			r.post_state,
+			r.status,
+			r.cumulative_gas_used,
+			r.gas_used,
+			r.contract_address,
+			r.effective_gas_price,
+			b.block_hash,
+			t.l2_hash
+	      FROM state.receipt r
+		 INNER JOIN state.transaction t
+		    ON t.hash = r.tx_hash
+		 INNER JOIN state.l2block b
+		    ON b.block_num = t.l2_block_num
+		 WHERE t.l2_block_num = $1
+		 ORDER BY r.tx_index ASC`
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getReceiptsSQL, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	receipts := []*types.Receipt{}
+	l2Hashes := []*common.Hash{}
+	receiptsByTxHash := map[common.Hash]*types.Receipt{}
+	for rows.Next() {
+		var txHash, contractAddress, l2BlockHash string
+		var effectiveGasPrice *uint64
+		var l2HashHex *string
+		receipt := types.Receipt{}
+		err := rows.Scan(&receipt.TransactionIndex,
+			&txHash,
+			&receipt.Type,
+			&receipt.PostState,
+			&receipt.Status,
+			&receipt.CumulativeGasUsed,
+			&receipt.GasUsed,
+			&contractAddress,
+			&effectiveGasPrice,
+			&l2BlockHash,
+			&l2HashHex,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		receipt.TxHash = common.HexToHash(txHash)
+		receipt.ContractAddress = common.HexToAddress(contractAddress)
+		receipt.BlockNumber = big.NewInt(0).SetUint64(blockNumber)
+		receipt.BlockHash = common.HexToHash(l2BlockHash)
+		if effectiveGasPrice != nil {
+			receipt.EffectiveGasPrice = big.NewInt(0).SetUint64(*effectiveGasPrice)
+		}
+		receipt.Logs = []*types.Log{}
+		receipts = append(receipts, &receipt)
+		receiptsByTxHash[receipt.TxHash] = &receipt
+
+		var l2Hash *common.Hash
+		if l2HashHex != nil {
+			hash := common.HexToHash(*l2HashHex)
+			l2Hash = &hash
+		}
+		l2Hashes = append(l2Hashes, l2Hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	logs, err := p.getBlockLogs(ctx, blockNumber, dbTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, txLog := range logs {
+		if receipt, found := receiptsByTxHash[txLog.TxHash]; found {
+			receipt.Logs = append(receipt.Logs, txLog)
+		}
+	}
+
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	}
+
+	return receipts, l2Hashes, nil
+}
+
 // GetTransactionByL2BlockHashAndIndex gets a transaction accordingly to the block hash and transaction index provided.
 // since we only have a single transaction per l2 block, any index different from 0 will return a not found result
 func (p *PostgresStorage) GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error) {
@@ -381,6 +476,24 @@ func (p *PostgresStorage) getTransactionLogs(ctx context.Context, transactionHas
 	return scanLogs(rows)
 }
 
+func (p *PostgresStorage) getBlockLogs(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Log, error) {
+	q := p.getExecQuerier(dbTx)
+
+	const getBlockLogsSQL = `
+	SELECT t.l2_block_num, b.block_hash, l.tx_hash, r.tx_index, l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3
+	FROM state.log l
+	INNER JOIN state.transaction t ON t.hash = l.tx_hash
+	INNER JOIN state.l2block b ON b.block_num = t.l2_block_num 
+	INNER JOIN state.receipt r ON r.tx_hash = t.hash
+	WHERE t.l2_block_num = $1
+	ORDER BY r.tx_index ASC, l.log_index ASC`
+	rows, err := q.Query(ctx, getBlockLogsSQL, blockNumber)
+	if !errors.Is(err, pgx.ErrNoRows) && err != nil {
+		return nil, err
+	}
+	return scanLogs(rows)
+}
+
 func scanLogs(rows pgx.Rows) ([]*types.Log, error) {
 	defer rows.Close()
 
@@ -13,6 +13,11 @@ func (s *State) Reset(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) erro
 	//  - VerifiedBatches
 	//  - Entries in exit_root table
 	err := s.ResetToL1BlockNumber(ctx, blockNumber, dbTx)
+	if err == nil {
+		// Record the reset so the components using a different state instance,
+		// like the JSON-RPC response cache, can detect it
+		err = s.AddL1Reorg(ctx, blockNumber, dbTx)
+	}
 	if err == nil {
 		// Discard L1InfoTree cache
 		// We can't rebuild cache, because we are inside a transaction, so we dont known
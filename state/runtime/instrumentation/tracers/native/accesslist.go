@@ -0,0 +1,130 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"sync/atomic"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/fakevm"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("accessListTracer", NewAccessListTracer, false)
+}
+
+// accessListTracer collects the addresses and storage slots touched by a tx
+// to build its EIP-2930 access list. As in geth, the sender, the receiver and
+// the precompiles are excluded since they are always warm.
+//
+// Example:
+//
+//	> debug.traceCall({from: "0x...", to: "0x...", data: "0x..."}, "latest", {tracer: "accessListTracer"})
+//	[
+//	  {
+//	    "address": "0x...",
+//	    "storageKeys": ["0x..."]
+//	  }
+//	]
+type accessListTracer struct {
+	noopTracer
+	excluded  map[common.Address]struct{}
+	list      map[common.Address]map[common.Hash]struct{}
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+// NewAccessListTracer returns a native go tracer which collects the access
+// list of a tx, and implements vm.EVMLogger.
+func NewAccessListTracer(ctx *tracers.Context, _ json.RawMessage) (tracers.Tracer, error) {
+	return &accessListTracer{
+		excluded: make(map[common.Address]struct{}),
+		list:     make(map[common.Address]map[common.Hash]struct{}),
+	}, nil
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *accessListTracer) CaptureStart(env *fakevm.FakeEVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	rules := env.ChainConfig().Rules(env.Context.BlockNumber, env.Context.Random != nil, env.Context.Time)
+	for _, addr := range fakevm.ActivePrecompiles(rules) {
+		t.excluded[addr] = struct{}{}
+	}
+	t.excluded[from] = struct{}{}
+	t.excluded[to] = struct{}{}
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *accessListTracer) CaptureState(pc uint64, op fakevm.OpCode, gas, cost uint64, scope *fakevm.ScopeContext, rData []byte, depth int, err error) {
+	// Skip if tracing was interrupted
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	stackData := scope.Stack.Data()
+	stackLen := len(stackData)
+	switch {
+	case stackLen >= 1 && (op == fakevm.SLOAD || op == fakevm.SSTORE):
+		slot := common.Hash(stackData[stackLen-1].Bytes32())
+		t.addSlot(scope.Contract.Address(), slot)
+	case stackLen >= 1 && (op == fakevm.EXTCODECOPY || op == fakevm.EXTCODEHASH || op == fakevm.EXTCODESIZE || op == fakevm.BALANCE || op == fakevm.SELFDESTRUCT):
+		t.addAddress(common.Address(stackData[stackLen-1].Bytes20()))
+	case stackLen >= 5 && (op == fakevm.DELEGATECALL || op == fakevm.CALL || op == fakevm.STATICCALL || op == fakevm.CALLCODE):
+		t.addAddress(common.Address(stackData[stackLen-2].Bytes20()))
+	}
+}
+
+func (t *accessListTracer) addAddress(addr common.Address) {
+	if _, excluded := t.excluded[addr]; excluded {
+		return
+	}
+	if _, found := t.list[addr]; !found {
+		t.list[addr] = make(map[common.Hash]struct{})
+	}
+}
+
+func (t *accessListTracer) addSlot(addr common.Address, slot common.Hash) {
+	// the slots of excluded addresses are still relevant, since only the
+	// account is warm, not its storage
+	if _, found := t.list[addr]; !found {
+		t.list[addr] = make(map[common.Hash]struct{})
+	}
+	t.list[addr][slot] = struct{}{}
+}
+
+// AccessList returns the collected access list sorted by address and slot
+func (t *accessListTracer) AccessList() types.AccessList {
+	accessList := make(types.AccessList, 0, len(t.list))
+	for addr, slots := range t.list {
+		tuple := types.AccessTuple{Address: addr, StorageKeys: make([]common.Hash, 0, len(slots))}
+		for slot := range slots {
+			tuple.StorageKeys = append(tuple.StorageKeys, slot)
+		}
+		sort.Slice(tuple.StorageKeys, func(i, j int) bool {
+			return bytes.Compare(tuple.StorageKeys[i].Bytes(), tuple.StorageKeys[j].Bytes()) < 0
+		})
+		accessList = append(accessList, tuple)
+	}
+	sort.Slice(accessList, func(i, j int) bool {
+		return bytes.Compare(accessList[i].Address.Bytes(), accessList[j].Address.Bytes()) < 0
+	})
+	return accessList
+}
+
+// GetResult returns the json-encoded access list, and any error arising from
+// the encoding or forceful termination (via `Stop`).
+func (t *accessListTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.AccessList())
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *accessListTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
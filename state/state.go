@@ -39,6 +39,9 @@ type State struct {
 
 	newL2BlockEvents        chan NewL2BlockEvent
 	newL2BlockEventHandlers []NewL2BlockEventHandler
+
+	newBatchEventHandlers      []NewBatchEventHandler
+	syncingStatusEventHandlers []SyncingStatusEventHandler
 }
 
 // NewState creates a new State
@@ -57,6 +60,9 @@ func NewState(cfg Config, storage storage, executorClient executor.ExecutorServi
 		newL2BlockEvents:        make(chan NewL2BlockEvent, newL2BlockEventBufferSize),
 		newL2BlockEventHandlers: []NewL2BlockEventHandler{},
 		l1InfoTree:              mt,
+
+		newBatchEventHandlers:      []NewBatchEventHandler{},
+		syncingStatusEventHandlers: []SyncingStatusEventHandler{},
 	}
 
 	return state
@@ -107,6 +113,15 @@ func (s *State) GetStorageAt(ctx context.Context, address common.Address, positi
 	return s.tree.GetStorageAt(ctx, address, position, root.Bytes())
 }
 
+// GetAccountProof returns the merkle tree proofs of the balance, nonce, code hash
+// and the provided storage positions of an address
+func (s *State) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error) {
+	if s.tree == nil {
+		return nil, ErrStateTreeNil
+	}
+	return s.tree.GetAccountProof(ctx, address, positions, root.Bytes())
+}
+
 // GetLastStateRoot returns the latest state root
 func (s *State) GetLastStateRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error) {
 	lastBlockHeader, err := s.GetLastL2BlockHeader(ctx, dbTx)
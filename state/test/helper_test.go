@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -141,6 +142,58 @@ func TestEncodePre155BatchL2DataForkID5(t *testing.T) {
 	assert.Equal(t, pre155, rawtxs)
 }
 
+func TestTypedTxs(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	chainID := big.NewInt(1000)
+	signer := types.LatestSignerForChainID(chainID)
+	to := common.HexToAddress("0x1")
+
+	testCases := []struct {
+		name          string
+		tx            types.TxData
+		expectedError error
+	}{
+		{
+			name: "legacy tx",
+			tx:   &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(7), Gas: 21000, To: &to, Value: big.NewInt(1)},
+		},
+		{
+			name:          "access list tx",
+			tx:            &types.AccessListTx{ChainID: chainID, Nonce: 1, GasPrice: big.NewInt(7), Gas: 21000, To: &to, Value: big.NewInt(1)},
+			expectedError: types.ErrTxTypeNotSupported,
+		},
+		{
+			name:          "dynamic fee tx",
+			tx:            &types.DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(5), Gas: 21000, To: &to, Value: big.NewInt(1)},
+			expectedError: types.ErrTxTypeNotSupported,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tx, err := types.SignNewTx(privateKey, signer, testCase.tx)
+			require.NoError(t, err)
+
+			// the typed txs can't be encoded in the batch, the legacy encoding would change their sender
+			batchL2Data, err := state.EncodeTransaction(*tx, state.MaxEffectivePercentage, state.FORKID_ETROG)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			txs, _, _, err := state.DecodeTxs(batchL2Data, state.FORKID_ETROG)
+			require.NoError(t, err)
+			require.Len(t, txs, 1)
+			assert.Equal(t, tx.Hash(), txs[0].Hash())
+			sender, err := state.GetSender(txs[0])
+			require.NoError(t, err)
+			assert.Equal(t, from, sender)
+		})
+	}
+}
+
 func TestMaliciousTransaction(t *testing.T) {
 	b := []byte{
 		0xee, 0x80, 0x84, 0x3b, 0x9a, 0xca, 0x00, 0x83, 0x01, 0x86, 0xa0, 0x94,
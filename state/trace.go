@@ -93,32 +93,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 	var response *ProcessTransactionResponse
 	var startTime, endTime time.Time
 	if forkId < FORKID_ETROG {
-		traceConfigRequest := &executor.TraceConfig{
-			TxHashToGenerateFullTrace: transactionHash.Bytes(),
-			// set the defaults to the maximum information we can have.
-			// this is needed to process custom tracers later
-			DisableStorage:   cFalse,
-			DisableStack:     cFalse,
-			EnableMemory:     cTrue,
-			EnableReturnData: cTrue,
-		}
-
-		// if the default tracer is used, then we review the information
-		// we want to have in the trace related to the parameters we received.
-		if traceConfig.IsDefaultTracer() {
-			if traceConfig.DisableStorage {
-				traceConfigRequest.DisableStorage = cTrue
-			}
-			if traceConfig.DisableStack {
-				traceConfigRequest.DisableStack = cTrue
-			}
-			if !traceConfig.EnableMemory {
-				traceConfigRequest.EnableMemory = cFalse
-			}
-			if !traceConfig.EnableReturnData {
-				traceConfigRequest.EnableReturnData = cFalse
-			}
-		}
+		traceConfigRequest := newExecutorTraceConfig(transactionHash, traceConfig)
 		// generate batch l2 data for the transaction
 		batchL2Data, err := EncodeTransactions(txsToEncode, effectivePercentage, forkId)
 		if err != nil {
@@ -172,32 +147,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		}
 		response = convertedResponse.BlockResponses[0].TransactionResponses[0]
 	} else {
-		traceConfigRequestV2 := &executor.TraceConfigV2{
-			TxHashToGenerateFullTrace: transactionHash.Bytes(),
-			// set the defaults to the maximum information we can have.
-			// this is needed to process custom tracers later
-			DisableStorage:   cFalse,
-			DisableStack:     cFalse,
-			EnableMemory:     cTrue,
-			EnableReturnData: cTrue,
-		}
-
-		// if the default tracer is used, then we review the information
-		// we want to have in the trace related to the parameters we received.
-		if traceConfig.IsDefaultTracer() {
-			if traceConfig.DisableStorage {
-				traceConfigRequestV2.DisableStorage = cTrue
-			}
-			if traceConfig.DisableStack {
-				traceConfigRequestV2.DisableStack = cTrue
-			}
-			if !traceConfig.EnableMemory {
-				traceConfigRequestV2.EnableMemory = cFalse
-			}
-			if !traceConfig.EnableReturnData {
-				traceConfigRequestV2.EnableReturnData = cFalse
-			}
-		}
+		traceConfigRequestV2 := newExecutorTraceConfigV2(transactionHash, traceConfig)
 
 		// if the l2 block number is 1, it means this is a network that started
 		// at least on Etrog fork, in this case the l2 block 1 will contain the
@@ -374,21 +324,120 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 
 	result.FullTrace.Context = context
 
-	gasPrice, ok := new(big.Int).SetString(context.GasPrice, encoding.Base10)
-	if !ok {
-		log.Errorf("debug transaction: failed to parse gasPrice")
-		return nil, fmt.Errorf("failed to parse gasPrice")
-	}
-
-	// select and prepare tracer
-	var tracer tracers.Tracer
 	tracerContext := &tracers.Context{
 		BlockHash:   receipt.BlockHash,
 		BlockNumber: receipt.BlockNumber,
 		TxIndex:     int(receipt.TransactionIndex),
 		TxHash:      transactionHash,
 	}
+	traceResult, err := s.buildTraceResult(result, *receipt, traceConfig, tracerContext, batch.StateRoot.Bytes(), nil)
+	if err != nil {
+		return nil, err
+	}
 
+	result.TraceResult = traceResult
+
+	return result, nil
+}
+
+// DebugUnsignedTransaction executes an unsigned tx on top of the given l2
+// block, applying the provided state and block overrides, to generate its trace
+func (s *State) DebugUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, traceConfig TraceConfig, stateOverride StateOverride, blockOverride *BlockOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	if err := stateOverride.Validate(); err != nil {
+		return nil, err
+	}
+
+	var l2Block *L2Block
+	var err error
+	if l2BlockNumber == nil {
+		l2Block, err = s.GetLastL2Block(ctx, dbTx)
+	} else {
+		l2Block, err = s.GetL2BlockByNumber(ctx, *l2BlockNumber, dbTx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	blockNumber := l2Block.NumberU64()
+
+	opts := unsignedTxProcessingOptions{
+		stateOverride: stateOverride,
+		blockOverride: blockOverride,
+		traceConfig:   &traceConfig,
+	}
+	startTime := time.Now()
+	processBatchResponse, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, &blockNumber, true, opts, dbTx)
+	endTime := time.Now()
+	// errors that still produce a tx response, like the ones raised by the
+	// evm execution, are part of the trace
+	if err != nil && (processBatchResponse == nil ||
+		len(processBatchResponse.BlockResponses) == 0 ||
+		len(processBatchResponse.BlockResponses[0].TransactionResponses) == 0) {
+		return nil, err
+	}
+	response := processBatchResponse.BlockResponses[0].TransactionResponses[0]
+
+	result := &runtime.ExecutionResult{
+		CreateAddress: response.CreateAddress,
+		GasLeft:       response.GasLeft,
+		GasUsed:       response.GasUsed,
+		ReturnValue:   response.ReturnValue,
+		StateRoot:     response.StateRoot.Bytes(),
+		FullTrace:     response.FullTrace,
+		Err:           response.RomError,
+	}
+
+	context := instrumentation.Context{
+		From:         senderAddress.String(),
+		Input:        tx.Data(),
+		Gas:          tx.Gas(),
+		Value:        tx.Value(),
+		Output:       result.ReturnValue,
+		GasPrice:     tx.GasPrice().String(),
+		OldStateRoot: l2Block.Root(),
+		Time:         uint64(endTime.Sub(startTime)),
+		GasUsed:      result.GasUsed,
+	}
+
+	// Fill trace context
+	if tx.To() == nil {
+		context.Type = "CREATE"
+		context.To = result.CreateAddress.Hex()
+	} else {
+		context.Type = "CALL"
+		context.To = tx.To().Hex()
+	}
+
+	result.FullTrace.Context = context
+
+	// the tx is not mined, so the receipt only contains the execution outcome
+	receipt := types.Receipt{
+		Status:  types.ReceiptStatusSuccessful,
+		GasUsed: result.GasUsed,
+		TxHash:  response.TxHash,
+	}
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	}
+
+	tracerContext := &tracers.Context{
+		BlockHash:   l2Block.Hash(),
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+		TxHash:      response.TxHash,
+	}
+	traceResult, err := s.buildTraceResult(result, receipt, traceConfig, tracerContext, l2Block.Root().Bytes(), stateOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	result.TraceResult = traceResult
+
+	return result, nil
+}
+
+// buildTraceResult parses the full trace of the execution result using the
+// tracer selected in the trace config, the state override is applied on top
+// of the state root read by the tracers
+func (s *State) buildTraceResult(result *runtime.ExecutionResult, receipt types.Receipt, traceConfig TraceConfig, tracerContext *tracers.Context, stateRoot []byte, stateOverride StateOverride) (json.RawMessage, error) {
 	if traceConfig.IsDefaultTracer() {
 		structLoggerCfg := structlogger.Config{
 			EnableMemory:     traceConfig.EnableMemory,
@@ -397,13 +446,19 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 			EnableReturnData: traceConfig.EnableReturnData,
 		}
 		tracer := structlogger.NewStructLogger(structLoggerCfg)
-		traceResult, err := tracer.ParseTrace(result, *receipt)
-		if err != nil {
-			return nil, err
-		}
-		result.TraceResult = traceResult
-		return result, nil
-	} else if traceConfig.Is4ByteTracer() {
+		return tracer.ParseTrace(result, receipt)
+	}
+
+	gasPrice, ok := new(big.Int).SetString(result.FullTrace.Context.GasPrice, encoding.Base10)
+	if !ok {
+		log.Errorf("debug transaction: failed to parse gasPrice")
+		return nil, fmt.Errorf("failed to parse gasPrice")
+	}
+
+	// select and prepare tracer
+	var tracer tracers.Tracer
+	var err error
+	if traceConfig.Is4ByteTracer() {
 		tracer, err = native.NewFourByteTracer(tracerContext, traceConfig.TracerConfig)
 		if err != nil {
 			log.Errorf("debug transaction: failed to create 4byteTracer, err: %v", err)
@@ -427,6 +482,12 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 			log.Errorf("debug transaction: failed to create prestateTracer, err: %v", err)
 			return nil, fmt.Errorf("failed to create prestateTracer, err: %v", err)
 		}
+	} else if traceConfig.IsAccessListTracer() {
+		tracer, err = native.NewAccessListTracer(tracerContext, traceConfig.TracerConfig)
+		if err != nil {
+			log.Errorf("debug transaction: failed to create accessListTracer, err: %v", err)
+			return nil, fmt.Errorf("failed to create accessListTracer, err: %v", err)
+		}
 	} else if traceConfig.IsJSCustomTracer() {
 		tracer, err = js.NewJsTracer(*traceConfig.Tracer, tracerContext, traceConfig.TracerConfig)
 		if err != nil {
@@ -437,7 +498,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("invalid tracer: %v, err: %v", traceConfig.Tracer, err)
 	}
 
-	fakeDB := &FakeDB{State: s, stateRoot: batch.StateRoot.Bytes()}
+	fakeDB := &FakeDB{State: s, stateRoot: stateRoot, overrides: stateOverride}
 	evm := fakevm.NewFakeEVM(fakevm.BlockContext{BlockNumber: big.NewInt(1)}, fakevm.TxContext{GasPrice: gasPrice}, fakeDB, params.TestChainConfig, fakevm.Config{Debug: true, Tracer: tracer})
 
 	traceResult, err := s.buildTrace(evm, result, tracer)
@@ -446,9 +507,71 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("failed parse the trace using the tracer: %v", err)
 	}
 
-	result.TraceResult = traceResult
+	return traceResult, nil
+}
 
-	return result, nil
+// newExecutorTraceConfig builds the pre ETROG executor trace config to
+// generate the full trace of the given tx
+func newExecutorTraceConfig(txHash common.Hash, traceConfig TraceConfig) *executor.TraceConfig {
+	traceConfigRequest := &executor.TraceConfig{
+		TxHashToGenerateFullTrace: txHash.Bytes(),
+		// set the defaults to the maximum information we can have.
+		// this is needed to process custom tracers later
+		DisableStorage:   cFalse,
+		DisableStack:     cFalse,
+		EnableMemory:     cTrue,
+		EnableReturnData: cTrue,
+	}
+
+	// if the default tracer is used, then we review the information
+	// we want to have in the trace related to the parameters we received.
+	if traceConfig.IsDefaultTracer() {
+		if traceConfig.DisableStorage {
+			traceConfigRequest.DisableStorage = cTrue
+		}
+		if traceConfig.DisableStack {
+			traceConfigRequest.DisableStack = cTrue
+		}
+		if !traceConfig.EnableMemory {
+			traceConfigRequest.EnableMemory = cFalse
+		}
+		if !traceConfig.EnableReturnData {
+			traceConfigRequest.EnableReturnData = cFalse
+		}
+	}
+	return traceConfigRequest
+}
+
+// newExecutorTraceConfigV2 builds the post ETROG executor trace config to
+// generate the full trace of the given tx
+func newExecutorTraceConfigV2(txHash common.Hash, traceConfig TraceConfig) *executor.TraceConfigV2 {
+	traceConfigRequestV2 := &executor.TraceConfigV2{
+		TxHashToGenerateFullTrace: txHash.Bytes(),
+		// set the defaults to the maximum information we can have.
+		// this is needed to process custom tracers later
+		DisableStorage:   cFalse,
+		DisableStack:     cFalse,
+		EnableMemory:     cTrue,
+		EnableReturnData: cTrue,
+	}
+
+	// if the default tracer is used, then we review the information
+	// we want to have in the trace related to the parameters we received.
+	if traceConfig.IsDefaultTracer() {
+		if traceConfig.DisableStorage {
+			traceConfigRequestV2.DisableStorage = cTrue
+		}
+		if traceConfig.DisableStack {
+			traceConfigRequestV2.DisableStack = cTrue
+		}
+		if !traceConfig.EnableMemory {
+			traceConfigRequestV2.EnableMemory = cFalse
+		}
+		if !traceConfig.EnableReturnData {
+			traceConfigRequestV2.EnableReturnData = cFalse
+		}
+	}
+	return traceConfigRequestV2
 }
 
 // ParseTheTraceUsingTheTracer parses the given trace with the given tracer.
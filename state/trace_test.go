@@ -0,0 +1,74 @@
+package state
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTraceResultPrestateTracerWithStateOverride(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	coinbase := common.Address{}
+	nonce := uint64(5)
+	code := []byte{0x60, 0x01}
+	toNonce := uint64(1)
+	emptyCode := []byte{}
+	slot := common.HexToHash("0x3")
+	value := common.HexToHash("0x4")
+
+	// all the accounts read by the tracer are overridden, so the tree is
+	// never reached
+	stateOverride := StateOverride{
+		from:     OverrideAccount{Nonce: &nonce, Code: &emptyCode, Balance: big.NewInt(1000)},
+		to:       OverrideAccount{Nonce: &toNonce, Code: &code, Balance: big.NewInt(7), StateDiff: map[common.Hash]common.Hash{slot: value}},
+		coinbase: OverrideAccount{Nonce: &toNonce, Code: &emptyCode, Balance: big.NewInt(1)},
+	}
+	require.NoError(t, stateOverride.Validate())
+
+	result := &runtime.ExecutionResult{
+		FullTrace: instrumentation.FullTrace{
+			Context: instrumentation.Context{
+				Type:     "CALL",
+				From:     from.String(),
+				To:       to.String(),
+				Gas:      21000,
+				Value:    big.NewInt(0),
+				GasPrice: "0",
+			},
+		},
+	}
+	tracer := "prestateTracer"
+	traceConfig := TraceConfig{Tracer: &tracer}
+
+	s := &State{}
+	traceResult, err := s.buildTraceResult(result, types.Receipt{}, traceConfig, &tracers.Context{}, ZeroHash.Bytes(), stateOverride)
+	require.NoError(t, err)
+
+	var prestate map[common.Address]struct {
+		Balance string `json:"balance"`
+		Nonce   uint64 `json:"nonce"`
+		Code    string `json:"code"`
+	}
+	require.NoError(t, json.Unmarshal(traceResult, &prestate))
+
+	// the tracer reverts the sender nonce increment to get the pre tx state
+	assert.Equal(t, "0x3e8", prestate[from].Balance)
+	assert.Equal(t, nonce-1, prestate[from].Nonce)
+	assert.Equal(t, "0x7", prestate[to].Balance)
+	assert.Equal(t, "0x6001", prestate[to].Code)
+	assert.Equal(t, "0x1", prestate[coinbase].Balance)
+
+	fakeDB := &FakeDB{State: s, overrides: stateOverride}
+	assert.Equal(t, value, fakeDB.GetState(to, slot))
+	assert.Equal(t, code, fakeDB.GetCode(to))
+	assert.NotEqual(t, ZeroHash, fakeDB.GetCodeHash(to))
+}
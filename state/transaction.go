@@ -288,7 +288,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 
 // PreProcessUnsignedTransaction processes the unsigned transaction in order to calculate its zkCounters
 func (s *State) PreProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, sender common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, unsignedTxProcessingOptions{}, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -303,7 +303,7 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 		return nil, err
 	}
 
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, unsignedTxProcessingOptions{}, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -311,10 +311,25 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 	return response, nil
 }
 
+// PreProcessUnsignedTransactions processes the unsigned transactions of the
+// sender one after the other in a single L2 block, as the sequencer would do,
+// in order to calculate the zkCounters used by all of them
+func (s *State) PreProcessUnsignedTransactions(ctx context.Context, txs []*types.Transaction, sender common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+	if len(txs) == 0 {
+		return nil, ErrInvalidData
+	}
+	return s.internalProcessUnsignedTransactions(ctx, txs, sender, l2BlockNumber, false, unsignedTxProcessingOptions{}, dbTx)
+}
+
 // ProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	if err := stateOverride.Validate(); err != nil {
+		return nil, err
+	}
+
 	result := new(runtime.ExecutionResult)
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	opts := unsignedTxProcessingOptions{stateOverride: stateOverride}
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, opts, dbTx)
 	if err != nil {
 		return nil, err
 	}
@@ -336,7 +351,13 @@ func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transa
 }
 
 // internalProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, opts unsignedTxProcessingOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+	return s.internalProcessUnsignedTransactions(ctx, []*types.Transaction{tx}, senderAddress, l2BlockNumber, noZKEVMCounters, opts, dbTx)
+}
+
+// internalProcessUnsignedTransactions processes the given unsigned transactions
+// of the sender in order, increasing its nonce after each one.
+func (s *State) internalProcessUnsignedTransactions(ctx context.Context, txs []*types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, opts unsignedTxProcessingOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var l2Block *L2Block
 	var err error
 	if l2BlockNumber == nil {
@@ -355,15 +376,15 @@ func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *type
 
 	forkID := s.GetForkIDByBatchNumber(batch.BatchNumber)
 	if forkID < FORKID_ETROG {
-		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV1(ctx, txs, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, opts, dbTx)
 	} else {
-		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV2(ctx, txs, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, opts, dbTx)
 	}
 }
 
-// internalProcessUnsignedTransactionV1 processes the given unsigned transaction.
+// internalProcessUnsignedTransactionV1 processes the given unsigned transactions.
 // pre ETROG
-func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, txs []*types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, opts unsignedTxProcessingOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -382,16 +403,23 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 	if l2Block.NumberU64() == latestL2BlockNumber {
 		timestamp = uint64(time.Now().Unix())
 	}
+	coinbase := l2Block.Coinbase()
+	if opts.blockOverride != nil {
+		if opts.blockOverride.Time != nil {
+			timestamp = *opts.blockOverride.Time
+		}
+		if opts.blockOverride.Coinbase != nil {
+			coinbase = *opts.blockOverride.Coinbase
+		}
+	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getUnsignedTransactionNonce(ctx, senderAddress, l2Block, opts.stateOverride)
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
-	batchL2Data, err := EncodeUnsignedTransaction(*tx, s.cfg.ChainID, &nonce, forkID)
+	batchL2Data, err := encodeUnsignedTransactions(txs, s.cfg.ChainID, nonce, forkID)
 	if err != nil {
-		log.Errorf("error encoding unsigned transaction ", err)
 		return nil, err
 	}
 
@@ -402,11 +430,12 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 		OldStateRoot:     l2Block.Root().Bytes(),
 		OldAccInputHash:  batch.AccInputHash.Bytes(),
 		ForkId:           forkID,
-		Coinbase:         l2Block.Coinbase().String(),
+		Coinbase:         coinbase.String(),
 		BatchL2Data:      batchL2Data,
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    opts.stateOverride.toExecutorV1(),
 
 		// v1 fields
 		GlobalExitRoot: l2Block.GlobalExitRoot().Bytes(),
@@ -415,6 +444,13 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 	if noZKEVMCounters {
 		processBatchRequestV1.NoCounters = cTrue
 	}
+	if opts.traceConfig != nil {
+		txHash, err := getUnsignedTransactionHash(batchL2Data, forkID)
+		if err != nil {
+			return nil, err
+		}
+		processBatchRequestV1.TraceConfig = newExecutorTraceConfig(txHash, *opts.traceConfig)
+	}
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.From]: %v", processBatchRequestV1.From)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.OldBatchNum]: %v", processBatchRequestV1.OldBatchNum)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.OldStateRoot]: %v", hex.EncodeToHex(processBatchRequestV1.OldStateRoot))
@@ -456,7 +492,7 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 				Source:      event.Source_Node,
 				Level:       event.Level_Error,
 				EventID:     event.EventID_ExecutorError,
-				Description: fmt.Sprintf("error processing unsigned transaction %s: %v", tx.Hash(), err),
+				Description: fmt.Sprintf("error processing unsigned transaction %s: %v", txs[0].Hash(), err),
 			}
 
 			err2 := s.eventLog.LogEvent(context.Background(), event)
@@ -479,19 +515,21 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 		return nil, err
 	}
 
-	if processBatchResponse.Responses[0].Error != executor.RomError_ROM_ERROR_NO_ERROR {
-		err := executor.RomErr(processBatchResponse.Responses[0].Error)
-		if !isEVMRevertError(err) {
-			return response, err
+	for _, txResponse := range processBatchResponse.Responses {
+		if txResponse.Error != executor.RomError_ROM_ERROR_NO_ERROR {
+			err := executor.RomErr(txResponse.Error)
+			if !isEVMRevertError(err) {
+				return response, err
+			}
 		}
 	}
 
 	return response, nil
 }
 
-// internalProcessUnsignedTransactionV2 processes the given unsigned transaction.
+// internalProcessUnsignedTransactionV2 processes the given unsigned transactions.
 // post ETROG
-func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, txs []*types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, opts unsignedTxProcessingOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -501,18 +539,34 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 		return nil, ErrStateTreeNil
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getUnsignedTransactionNonce(ctx, senderAddress, l2Block, opts.stateOverride)
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
-	deltaTimestamp := uint32(uint64(time.Now().Unix()) - l2Block.Time())
+	timestamp := uint64(time.Now().Unix())
+	timestampLimit := timestamp
+	coinbase := batch.Coinbase
+	if opts.blockOverride != nil {
+		if opts.blockOverride.Time != nil {
+			timestamp = *opts.blockOverride.Time
+			if timestamp < l2Block.Time() {
+				return nil, fmt.Errorf("block timestamp override %d is lower than the timestamp %d of the l2 block %d", timestamp, l2Block.Time(), l2Block.NumberU64())
+			}
+			if timestamp > timestampLimit {
+				timestampLimit = timestamp
+			}
+		}
+		if opts.blockOverride.Coinbase != nil {
+			coinbase = *opts.blockOverride.Coinbase
+		}
+	}
+
+	deltaTimestamp := uint32(timestamp - l2Block.Time())
 	transactions := s.BuildChangeL2Block(deltaTimestamp, uint32(0))
 
-	batchL2Data, err := EncodeUnsignedTransaction(*tx, s.cfg.ChainID, &nonce, forkID)
+	batchL2Data, err := encodeUnsignedTransactions(txs, s.cfg.ChainID, nonce, forkID)
 	if err != nil {
-		log.Errorf("error encoding unsigned transaction ", err)
 		return nil, err
 	}
 
@@ -524,16 +578,17 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 		OldBatchNum:      batch.BatchNumber,
 		OldStateRoot:     l2Block.Root().Bytes(),
 		OldAccInputHash:  batch.AccInputHash.Bytes(),
-		Coinbase:         batch.Coinbase.String(),
+		Coinbase:         coinbase.String(),
 		ForkId:           forkID,
 		BatchL2Data:      transactions,
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    opts.stateOverride.toExecutorV2(),
 
 		// v2 fields
 		L1InfoRoot:             l2Block.BlockInfoRoot().Bytes(),
-		TimestampLimit:         uint64(time.Now().Unix()),
+		TimestampLimit:         timestampLimit,
 		SkipFirstChangeL2Block: cFalse,
 		SkipWriteBlockInfoRoot: cTrue,
 		ExecutionMode:          executor.ExecutionMode0,
@@ -541,6 +596,13 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 	if noZKEVMCounters {
 		processBatchRequestV2.NoCounters = cTrue
 	}
+	if opts.traceConfig != nil {
+		txHash, err := getUnsignedTransactionHash(batchL2Data, forkID)
+		if err != nil {
+			return nil, err
+		}
+		processBatchRequestV2.TraceConfig = newExecutorTraceConfigV2(txHash, *opts.traceConfig)
+	}
 
 	log.Debugf("internalProcessUnsignedTransactionV2[processBatchRequestV2.From]: %v", processBatchRequestV2.From)
 	log.Debugf("internalProcessUnsignedTransactionV2[processBatchRequestV2.OldBatchNum]: %v", processBatchRequestV2.OldBatchNum)
@@ -586,7 +648,7 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 				Source:      event.Source_Node,
 				Level:       event.Level_Error,
 				EventID:     event.EventID_ExecutorError,
-				Description: fmt.Sprintf("error processing unsigned transaction %s: %v", tx.Hash(), err),
+				Description: fmt.Sprintf("error processing unsigned transaction %s: %v", txs[0].Hash(), err),
 			}
 
 			err2 := s.eventLog.LogEvent(context.Background(), event)
@@ -618,16 +680,61 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 		return nil, err
 	}
 
-	if processBatchResponseV2.BlockResponses[0].Responses[0].Error != executor.RomError_ROM_ERROR_NO_ERROR {
-		err := executor.RomErr(processBatchResponseV2.BlockResponses[0].Responses[0].Error)
-		if !isEVMRevertError(err) {
-			return response, err
+	for _, txResponse := range processBatchResponseV2.BlockResponses[0].Responses {
+		if txResponse.Error != executor.RomError_ROM_ERROR_NO_ERROR {
+			err := executor.RomErr(txResponse.Error)
+			if !isEVMRevertError(err) {
+				return response, err
+			}
 		}
 	}
 
 	return response, nil
 }
 
+// getUnsignedTransactionNonce returns the nonce used to encode an unsigned
+// transaction, which is the overridden nonce of the sender if provided or its
+// nonce at the given l2 block otherwise
+func (s *State) getUnsignedTransactionNonce(ctx context.Context, senderAddress common.Address, l2Block L2Block, stateOverride StateOverride) (uint64, error) {
+	if nonce := stateOverride.nonceFor(senderAddress); nonce != nil {
+		return *nonce, nil
+	}
+	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	if err != nil {
+		return 0, err
+	}
+	return loadedNonce.Uint64(), nil
+}
+
+// encodeUnsignedTransactions encodes the unsigned transactions of a sender one
+// after the other, using consecutive nonces starting from the given one
+func encodeUnsignedTransactions(txs []*types.Transaction, chainID uint64, nonce uint64, forkID uint64) ([]byte, error) {
+	var batchL2Data []byte
+	for _, tx := range txs {
+		txData, err := EncodeUnsignedTransaction(*tx, chainID, &nonce, forkID)
+		if err != nil {
+			log.Errorf("error encoding unsigned transaction ", err)
+			return nil, err
+		}
+		batchL2Data = append(batchL2Data, txData...)
+		nonce++
+	}
+	return batchL2Data, nil
+}
+
+// getUnsignedTransactionHash returns the hash the executor computes for the
+// unsigned transaction encoded in the given batch l2 data
+func getUnsignedTransactionHash(batchL2Data []byte, forkID uint64) (common.Hash, error) {
+	txs, _, _, err := DecodeTxs(batchL2Data, forkID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(txs) == 0 {
+		return common.Hash{}, ErrInvalidData
+	}
+	return txs[0].Hash(), nil
+}
+
 // isContractCreation checks if the tx is a contract creation
 func (s *State) isContractCreation(tx *types.Transaction) bool {
 	return tx.To() == nil && len(tx.Data()) > 0
@@ -703,11 +810,15 @@ func CheckSupersetBatchTransactions(existingTxHashes []common.Hash, processedTxs
 }
 
 // EstimateGas for a transaction
-func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
+func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
 	const ethTransferGas = 21000
 
 	ctx := context.Background()
 
+	if err := stateOverride.Validate(); err != nil {
+		return 0, nil, err
+	}
+
 	var l2Block *L2Block
 	var err error
 	if l2BlockNumber == nil {
@@ -730,11 +841,10 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		return 0, nil, err
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getUnsignedTransactionNonce(ctx, senderAddress, *l2Block, stateOverride)
 	if err != nil {
 		return 0, nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	highEnd := MaxTxGasLimit
 
@@ -748,6 +858,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		} else if err != nil {
 			return 0, nil, err
 		}
+		if balance := stateOverride.balanceFor(senderAddress); balance != nil {
+			senderBalance = balance
+		}
 
 		availableBalance := new(big.Int).Set(senderBalance)
 		// check if the account has funds to pay the transfer value
@@ -786,6 +899,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		receiver := *transaction.To()
 		// check if the receiver address is not a smart contract
 		code, err := s.tree.GetCode(ctx, receiver, l2Block.Root().Bytes())
+		if overriddenCode := stateOverride.codeFor(receiver); overriddenCode != nil {
+			code, err = *overriddenCode, nil
+		}
 		if err != nil {
 			log.Warnf("error while getting code for address %v: %v", receiver.String(), err)
 		} else if len(code) == 0 {
@@ -808,9 +924,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	var gasUsed uint64
 	var returnValue []byte
 	if forkID < FORKID_ETROG {
-		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, false)
+		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, stateOverride, false)
 	} else {
-		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, false)
+		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, stateOverride, false)
 	}
 
 	if failed {
@@ -843,9 +959,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 
 		log.Debugf("Estimate gas. Trying to execute TX with %v gas", mid)
 		if forkID < FORKID_ETROG {
-			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, true)
+			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, stateOverride, true)
 		} else {
-			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, true)
+			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, stateOverride, true)
 		}
 		executionTime := time.Since(txExecutionStart)
 		totalExecutionTime += executionTime
@@ -879,7 +995,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 // before ETROG
 func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address,
-	gas uint64, nonce uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
 	timestamp := l2Block.Time()
 	if l2Block.NumberU64() == latestL2BlockNumber {
 		timestamp = uint64(time.Now().Unix())
@@ -912,6 +1028,7 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    stateOverride.toExecutorV1(),
 
 		// v1 fields
 		GlobalExitRoot: batch.GlobalExitRoot.Bytes(),
@@ -975,7 +1092,7 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 // after ETROG
 func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address,
-	gas uint64, nonce uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
 	deltaTimestamp := uint32(uint64(time.Now().Unix()) - l2Block.Time())
 	transactions := s.BuildChangeL2Block(deltaTimestamp, uint32(0))
 
@@ -1008,6 +1125,7 @@ func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batc
 		ChainId:          s.cfg.ChainID,
 		UpdateMerkleTree: cFalse,
 		ContextId:        uuid.NewString(),
+		StateOverride:    stateOverride.toExecutorV2(),
 
 		// v2 fields
 		L1InfoRoot:             l2Block.BlockInfoRoot().Bytes(),
@@ -336,6 +336,11 @@ func (t *TraceConfig) IsPrestateTracer() bool {
 	return t.Tracer != nil && *t.Tracer == "prestateTracer"
 }
 
+// IsAccessListTracer returns true when should use accessListTracer
+func (t *TraceConfig) IsAccessListTracer() bool {
+	return t.Tracer != nil && *t.Tracer == "accessListTracer"
+}
+
 // IsJSCustomTracer returns true when should use js custom tracer
 func (t *TraceConfig) IsJSCustomTracer() bool {
 	return t.Tracer != nil && strings.Contains(*t.Tracer, "result") && strings.Contains(*t.Tracer, "fault")